@@ -26,7 +26,16 @@ import (
 
 // GetLogger returns a new FieldLogger and an associated Context derived from command context.
 func GetLogger(cmd *cobra.Command, opts *option.Common) (context.Context, logrus.FieldLogger) {
-	ctx, logger := trace.NewLogger(cmd.Context(), opts.Debug)
+	loggerOpts := trace.LoggerOptions{
+		Level:  opts.LogLevel,
+		Format: opts.LogFormat,
+	}
+	if opts.LogFormat == trace.LogFormatJSON {
+		loggerOpts.Fields = logrus.Fields{
+			trace.FieldCommand: cmd.CommandPath(),
+		}
+	}
+	ctx, logger := trace.NewLogger(cmd.Context(), loggerOpts)
 	cmd.SetContext(ctx)
 	return ctx, logger
 }
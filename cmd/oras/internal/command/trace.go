@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+// Traced returns a command whose execution is recorded as the root span of a
+// trace when tracing is enabled by opts.
+// Failing to export the trace does not fail the command but prints a warning.
+func Traced(cmd *cobra.Command, opts *option.Trace) *cobra.Command {
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		tracer, err := opts.NewTracer(cmd)
+		if err != nil {
+			return err
+		}
+		if tracer == nil {
+			return runE(cmd, args)
+		}
+		ctx, span := tracer.Start(cmd.Context(), cmd.CommandPath())
+		cmd.SetContext(ctx)
+		defer func() {
+			span.End(err)
+			if exportErr := tracer.Shutdown(context.WithoutCancel(ctx)); exportErr != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "WARNING! Failed to export trace %s: %v\n", tracer.TraceID(), exportErr)
+			}
+		}()
+		return runE(cmd, args)
+	}
+	return cmd
+}
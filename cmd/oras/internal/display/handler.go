@@ -25,88 +25,84 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/content"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/descriptor"
-	"oras.land/oras/cmd/oras/internal/display/metadata/json"
+	"oras.land/oras/cmd/oras/internal/display/metadata/structured"
 	"oras.land/oras/cmd/oras/internal/display/metadata/table"
 	"oras.land/oras/cmd/oras/internal/display/metadata/template"
 	"oras.land/oras/cmd/oras/internal/display/metadata/text"
 	"oras.land/oras/cmd/oras/internal/display/metadata/tree"
 	"oras.land/oras/cmd/oras/internal/display/status"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
 )
 
 // NewPushHandler returns status and metadata handlers for push command.
-func NewPushHandler(printer *output.Printer, format option.Format, tty *os.File, fetcher fetcher.Fetcher) (status.PushHandler, metadata.PushHandler, error) {
+func NewPushHandler(printer *output.Printer, format option.Format, terminal option.Terminal, fetcher fetcher.Fetcher) (status.PushHandler, metadata.PushHandler, error) {
 	var statusHandler status.PushHandler
-	if tty != nil {
-		statusHandler = status.NewTTYPushHandler(tty, fetcher)
+	if terminal.ProgressEvents != nil {
+		statusHandler = status.NewJSONProgressPushHandler(terminal.ProgressEvents, fetcher)
+	} else if tty := terminal.TTY; tty != nil {
+		statusHandler = status.NewTTYPushHandlerWithView(tty, ProgressView(terminal), fetcher)
 	} else if format.Type == option.FormatTypeText.Name {
 		statusHandler = status.NewTextPushHandler(printer, fetcher)
 	} else {
 		statusHandler = status.NewDiscardHandler()
 	}
 
-	var metadataHandler metadata.PushHandler
-	switch format.Type {
-	case option.FormatTypeText.Name:
-		metadataHandler = text.NewPushHandler(printer)
-	case option.FormatTypeJSON.Name:
-		metadataHandler = json.NewPushHandler(printer)
-	case option.FormatTypeGoTemplate.Name:
-		metadataHandler = template.NewPushHandler(printer, format.Template)
-	default:
-		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
+	metadataHandler, err := newFormatHandler(printer, format, func() metadata.PushHandler {
+		return text.NewPushHandler(printer)
+	}, structured.NewPushHandler, template.NewPushHandler)
+	if err != nil {
+		return nil, nil, err
 	}
 	return statusHandler, metadataHandler, nil
 }
 
 // NewAttachHandler returns status and metadata handlers for attach command.
-func NewAttachHandler(printer *output.Printer, format option.Format, tty *os.File, fetcher fetcher.Fetcher) (status.AttachHandler, metadata.AttachHandler, error) {
+func NewAttachHandler(printer *output.Printer, format option.Format, terminal option.Terminal, fetcher fetcher.Fetcher) (status.AttachHandler, metadata.AttachHandler, error) {
 	var statusHandler status.AttachHandler
-	if tty != nil {
-		statusHandler = status.NewTTYAttachHandler(tty, fetcher)
+	if terminal.ProgressEvents != nil {
+		statusHandler = status.NewJSONProgressAttachHandler(terminal.ProgressEvents, fetcher)
+	} else if tty := terminal.TTY; tty != nil {
+		statusHandler = status.NewTTYAttachHandlerWithView(tty, ProgressView(terminal), fetcher)
 	} else if format.Type == option.FormatTypeText.Name {
 		statusHandler = status.NewTextAttachHandler(printer, fetcher)
 	} else {
 		statusHandler = status.NewDiscardHandler()
 	}
 
-	var metadataHandler metadata.AttachHandler
-	switch format.Type {
-	case option.FormatTypeText.Name:
-		metadataHandler = text.NewAttachHandler(printer)
-	case option.FormatTypeJSON.Name:
-		metadataHandler = json.NewAttachHandler(printer)
-	case option.FormatTypeGoTemplate.Name:
-		metadataHandler = template.NewAttachHandler(printer, format.Template)
-	default:
-		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
+	metadataHandler, err := newFormatHandler(printer, format, func() metadata.AttachHandler {
+		return text.NewAttachHandler(printer)
+	}, structured.NewAttachHandler, template.NewAttachHandler)
+	if err != nil {
+		return nil, nil, err
 	}
 	return statusHandler, metadataHandler, nil
 }
 
 // NewPullHandler returns status and metadata handlers for pull command.
-func NewPullHandler(printer *output.Printer, format option.Format, path string, tty *os.File) (status.PullHandler, metadata.PullHandler, error) {
+func NewPullHandler(printer *output.Printer, format option.Format, path string, terminal option.Terminal) (status.PullHandler, metadata.PullHandler, error) {
 	var statusHandler status.PullHandler
-	if tty != nil {
-		statusHandler = status.NewTTYPullHandler(tty)
+	if terminal.ProgressEvents != nil {
+		statusHandler = status.NewJSONProgressPullHandler(terminal.ProgressEvents)
+	} else if tty := terminal.TTY; tty != nil {
+		statusHandler = status.NewTTYPullHandlerWithView(tty, ProgressView(terminal))
 	} else if format.Type == option.FormatTypeText.Name {
 		statusHandler = status.NewTextPullHandler(printer)
 	} else {
 		statusHandler = status.NewDiscardHandler()
 	}
 
-	var metadataHandler metadata.PullHandler
-	switch format.Type {
-	case option.FormatTypeText.Name:
-		metadataHandler = text.NewPullHandler(printer)
-	case option.FormatTypeJSON.Name:
-		metadataHandler = json.NewPullHandler(printer, path)
-	case option.FormatTypeGoTemplate.Name:
-		metadataHandler = template.NewPullHandler(printer, path, format.Template)
-	default:
-		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
+	metadataHandler, err := newFormatHandler(printer, format, func() metadata.PullHandler {
+		return text.NewPullHandler(printer)
+	}, func(out io.Writer, encode structured.Encoder) metadata.PullHandler {
+		return structured.NewPullHandler(out, encode, path)
+	}, func(out io.Writer, tmpl string) metadata.PullHandler {
+		return template.NewPullHandler(out, path, tmpl)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 	return statusHandler, metadataHandler, nil
 }
@@ -119,8 +115,8 @@ func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawRef
 		handler = tree.NewDiscoverHandler(out, path, desc, verbose, tty)
 	case option.FormatTypeTable.Name:
 		handler = table.NewDiscoverHandler(out, rawReference, desc, verbose)
-	case option.FormatTypeJSON.Name:
-		handler = json.NewDiscoverHandler(out, desc, path)
+	case option.FormatTypeJSON.Name, option.FormatTypeYAML.Name:
+		handler = structured.NewDiscoverHandler(out, structuredEncoder(format.Type), desc, path)
 	case option.FormatTypeGoTemplate.Name:
 		handler = template.NewDiscoverHandler(out, desc, path, format.Template)
 	default:
@@ -142,9 +138,9 @@ func NewManifestFetchHandler(out io.Writer, format option.Format, outputDescript
 		} else {
 			metadataHandler = metadata.NewDiscardHandler()
 		}
-	case option.FormatTypeJSON.Name:
-		// json
-		metadataHandler = json.NewManifestFetchHandler(out)
+	case option.FormatTypeJSON.Name, option.FormatTypeYAML.Name:
+		// json or yaml
+		metadataHandler = structured.NewManifestFetchHandler(out, structuredEncoder(format.Type))
 		if outputPath == "" {
 			contentHandler = content.NewDiscardHandler()
 		}
@@ -165,8 +161,14 @@ func NewManifestFetchHandler(out io.Writer, format option.Format, outputDescript
 }
 
 // NewTagHandler returns a tag handler.
-func NewTagHandler(printer *output.Printer, target option.Target) metadata.TagHandler {
-	return text.NewTagHandler(printer, target)
+func NewTagHandler(printer *output.Printer, format option.Format, target option.Target) (metadata.TagHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.TagHandler {
+		return text.NewTagHandler(printer, target)
+	}, func(out io.Writer, encode structured.Encoder) metadata.TagHandler {
+		return structured.NewTagHandler(out, encode, target.Path)
+	}, func(out io.Writer, tmpl string) metadata.TagHandler {
+		return template.NewTagHandler(out, tmpl, target.Path)
+	})
 }
 
 // NewManifestPushHandler returns a manifest push handler.
@@ -183,83 +185,196 @@ func NewManifestDeleteHandler(printer *output.Printer, target *option.Target) me
 }
 
 // NewManifestIndexCreateHandler returns status, metadata and content handlers for index create command.
-func NewManifestIndexCreateHandler(outputPath string, printer *output.Printer, pretty bool) (status.ManifestIndexCreateHandler, metadata.ManifestIndexCreateHandler, content.ManifestIndexCreateHandler) {
-	var statusHandler status.ManifestIndexCreateHandler
-	var metadataHandler metadata.ManifestIndexCreateHandler
-	var contentHandler content.ManifestIndexCreateHandler
+func NewManifestIndexCreateHandler(outputPath string, printer *output.Printer, format option.Format, pretty bool, target *option.Target) (status.ManifestIndexCreateHandler, metadata.ManifestIndexCreateHandler, content.ManifestIndexCreateHandler, error) {
+	var statusHandler status.ManifestIndexCreateHandler = status.NewTextManifestIndexCreateHandler(printer)
+	contentHandler := content.NewManifestIndexCreateHandler(printer, pretty, outputPath)
 	switch outputPath {
 	case "":
-		statusHandler = status.NewTextManifestIndexCreateHandler(printer)
-		metadataHandler = text.NewManifestIndexCreateHandler(printer)
 		contentHandler = content.NewDiscardHandler()
 	case "-":
+		return status.NewDiscardHandler(), metadata.NewDiscardHandler(), contentHandler, nil
+	}
+	metadataHandler, err := newManifestIndexMetadataHandler(printer, format, target, outputPath == "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if format.Type != option.FormatTypeText.Name {
 		statusHandler = status.NewDiscardHandler()
-		metadataHandler = metadata.NewDiscardHandler()
-		contentHandler = content.NewManifestIndexCreateHandler(printer, pretty, outputPath)
-	default:
-		statusHandler = status.NewTextManifestIndexCreateHandler(printer)
-		metadataHandler = text.NewManifestIndexCreateHandler(printer)
-		contentHandler = content.NewManifestIndexCreateHandler(printer, pretty, outputPath)
 	}
-	return statusHandler, metadataHandler, contentHandler
+	return statusHandler, metadataHandler, contentHandler, nil
 }
 
 // NewManifestIndexUpdateHandler returns status, metadata and content handlers for index update command.
-func NewManifestIndexUpdateHandler(outputPath string, printer *output.Printer, pretty bool) (
+func NewManifestIndexUpdateHandler(outputPath string, printer *output.Printer, format option.Format, pretty bool, target *option.Target) (
 	status.ManifestIndexUpdateHandler,
 	metadata.ManifestIndexUpdateHandler,
-	content.ManifestIndexUpdateHandler) {
-	statusHandler := status.NewTextManifestIndexUpdateHandler(printer)
-	metadataHandler := text.NewManifestIndexCreateHandler(printer)
+	content.ManifestIndexUpdateHandler,
+	error) {
+	var statusHandler status.ManifestIndexUpdateHandler = status.NewTextManifestIndexUpdateHandler(printer)
 	contentHandler := content.NewManifestIndexCreateHandler(printer, pretty, outputPath)
 	switch outputPath {
 	case "":
 		contentHandler = content.NewDiscardHandler()
 	case "-":
+		return status.NewDiscardHandler(), metadata.NewDiscardHandler(), contentHandler, nil
+	}
+	metadataHandler, err := newManifestIndexMetadataHandler(printer, format, target, outputPath == "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if format.Type != option.FormatTypeText.Name {
 		statusHandler = status.NewDiscardHandler()
-		metadataHandler = metadata.NewDiscardHandler()
 	}
-	return statusHandler, metadataHandler, contentHandler
+	return statusHandler, metadataHandler, contentHandler, nil
+}
+
+// newManifestIndexMetadataHandler returns a metadata handler for index create
+// and update commands.
+func newManifestIndexMetadataHandler(printer *output.Printer, format option.Format, target *option.Target, pushed bool) (metadata.ManifestIndexCreateHandler, error) {
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return text.NewManifestIndexCreateHandler(printer), nil
+	case option.FormatTypeJSON.Name, option.FormatTypeYAML.Name:
+		return structured.NewManifestIndexCreateHandler(printer, structuredEncoder(format.Type), target, pushed), nil
+	case option.FormatTypeGoTemplate.Name:
+		return template.NewManifestIndexCreateHandler(printer, format.Template, target, pushed), nil
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
 }
 
 // NewCopyHandler returns copy handlers.
-func NewCopyHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fetcher) (status.CopyHandler, metadata.CopyHandler) {
-	if tty != nil {
-		return status.NewTTYCopyHandler(tty), text.NewCopyHandler(printer)
+func NewCopyHandler(printer *output.Printer, format option.Format, terminal option.Terminal, fetcher fetcher.Fetcher) (status.CopyHandler, metadata.CopyHandler, error) {
+	var statusHandler status.CopyHandler
+	if terminal.ProgressEvents != nil {
+		statusHandler = status.NewJSONProgressCopyHandler(terminal.ProgressEvents)
+	} else if tty := terminal.TTY; tty != nil {
+		statusHandler = status.NewTTYCopyHandlerWithView(tty, ProgressView(terminal))
+	} else if format.Type == option.FormatTypeText.Name {
+		statusHandler = status.NewTextCopyHandler(printer, fetcher)
+	} else {
+		statusHandler = status.NewDiscardHandler()
+	}
+
+	metadataHandler, err := newFormatHandler(printer, format, func() metadata.CopyHandler {
+		return text.NewCopyHandler(printer)
+	}, structured.NewCopyHandler, template.NewCopyHandler)
+	if err != nil {
+		return nil, nil, err
 	}
-	return status.NewTextCopyHandler(printer, fetcher), text.NewCopyHandler(printer)
+	return statusHandler, metadataHandler, nil
 }
 
 // NewBackupHandler returns backup handlers.
-func NewBackupHandler(printer *output.Printer, tty *os.File, repo string, fetcher fetcher.Fetcher) (status.BackupHandler, metadata.BackupHandler) {
-	if tty != nil {
-		return status.NewTTYBackupHandler(tty, fetcher), text.NewBackupHandler(repo, printer)
+func NewBackupHandler(printer *output.Printer, format option.Format, terminal option.Terminal, repo string, fetcher fetcher.Fetcher) (status.BackupHandler, metadata.BackupHandler, error) {
+	var statusHandler status.BackupHandler
+	if terminal.ProgressEvents != nil {
+		statusHandler = status.NewJSONProgressBackupHandler(terminal.ProgressEvents, fetcher)
+	} else if tty := terminal.TTY; tty != nil {
+		statusHandler = status.NewTTYBackupHandlerWithView(tty, ProgressView(terminal), fetcher)
+	} else if format.Type == option.FormatTypeText.Name {
+		statusHandler = status.NewTextBackupHandler(printer, fetcher)
+	} else {
+		statusHandler = status.NewDiscardHandler()
 	}
-	return status.NewTextBackupHandler(printer, fetcher), text.NewBackupHandler(repo, printer)
+
+	metadataHandler, err := newFormatHandler(printer, format, func() metadata.BackupHandler {
+		return text.NewBackupHandler(repo, printer)
+	}, func(out io.Writer, encode structured.Encoder) metadata.BackupHandler {
+		return structured.NewBackupHandler(out, encode, repo)
+	}, func(out io.Writer, tmpl string) metadata.BackupHandler {
+		return template.NewBackupHandler(out, tmpl, repo)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return statusHandler, metadataHandler, nil
 }
 
 // NewRestoreHandler returns restore handlers.
-func NewRestoreHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fetcher, dryRun bool) (status.RestoreHandler, metadata.RestoreHandler) {
-	if tty != nil {
-		return status.NewTTYRestoreHandler(tty, fetcher), text.NewRestoreHandler(printer, dryRun)
+func NewRestoreHandler(printer *output.Printer, format option.Format, terminal option.Terminal, fetcher fetcher.Fetcher, input string, dryRun bool) (status.RestoreHandler, metadata.RestoreHandler, error) {
+	var statusHandler status.RestoreHandler
+	if terminal.ProgressEvents != nil {
+		statusHandler = status.NewJSONProgressRestoreHandler(terminal.ProgressEvents, fetcher)
+	} else if tty := terminal.TTY; tty != nil {
+		statusHandler = status.NewTTYRestoreHandlerWithView(tty, ProgressView(terminal), fetcher)
+	} else if format.Type == option.FormatTypeText.Name {
+		statusHandler = status.NewTextRestoreHandler(printer, fetcher)
+	} else {
+		statusHandler = status.NewDiscardHandler()
 	}
-	return status.NewTextRestoreHandler(printer, fetcher), text.NewRestoreHandler(printer, dryRun)
+
+	metadataHandler, err := newFormatHandler(printer, format, func() metadata.RestoreHandler {
+		return text.NewRestoreHandler(printer, dryRun)
+	}, func(out io.Writer, encode structured.Encoder) metadata.RestoreHandler {
+		return structured.NewRestoreHandler(out, encode, input, dryRun)
+	}, func(out io.Writer, tmpl string) metadata.RestoreHandler {
+		return template.NewRestoreHandler(out, tmpl, input, dryRun)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return statusHandler, metadataHandler, nil
 }
 
 // NewBlobPushHandler returns blob push handlers.
-func NewBlobPushHandler(printer *output.Printer, outputDescriptor bool, pretty bool, desc ocispec.Descriptor, tty *os.File) (status.BlobPushHandler, metadata.BlobPushHandler) {
+func NewBlobPushHandler(printer *output.Printer, outputDescriptor bool, pretty bool, desc ocispec.Descriptor, terminal option.Terminal) (status.BlobPushHandler, metadata.BlobPushHandler) {
 	if outputDescriptor {
 		return status.NewDiscardHandler(), metadata.NewDiscardHandler()
 	}
-	if tty != nil {
-		return status.NewTTYBlobPushHandler(tty, desc), text.NewBlobPushHandler(printer, desc)
+	if terminal.ProgressEvents != nil {
+		return status.NewJSONProgressBlobPushHandler(terminal.ProgressEvents, desc), text.NewBlobPushHandler(printer, desc)
+	}
+	if tty := terminal.TTY; tty != nil {
+		return status.NewTTYBlobPushHandlerWithView(tty, ProgressView(terminal), desc), text.NewBlobPushHandler(printer, desc)
 	}
 	return status.NewTextBlobPushHandler(printer, desc), text.NewBlobPushHandler(printer, desc)
 }
 
 // NewResolveHandler returns a resolve metadata handler.
-func NewResolveHandler(printer *output.Printer, fullRef bool, path string) metadata.ResolveHandler {
-	return text.NewResolveHandler(printer, fullRef, path)
+func NewResolveHandler(printer *output.Printer, format option.Format, fullRef bool, path string) (metadata.ResolveHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.ResolveHandler {
+		return text.NewResolveHandler(printer, fullRef, path)
+	}, func(out io.Writer, encode structured.Encoder) metadata.ResolveHandler {
+		return structured.NewResolveHandler(out, encode, path)
+	}, func(out io.Writer, tmpl string) metadata.ResolveHandler {
+		return template.NewResolveHandler(out, tmpl, path)
+	})
+}
+
+// NewInspectHandler returns an inspect handler.
+func NewInspectHandler(printer *output.Printer, format option.Format) (metadata.InspectHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.InspectHandler {
+		return text.NewInspectHandler(printer)
+	}, structured.NewInspectHandler, template.NewInspectHandler)
+}
+
+// NewDiffHandler returns a diff handler.
+func NewDiffHandler(printer *output.Printer, format option.Format) (metadata.DiffHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.DiffHandler {
+		return text.NewDiffHandler(printer)
+	}, structured.NewDiffHandler, template.NewDiffHandler)
+}
+
+// NewRegistryCheckHandler returns a registry check handler.
+func NewRegistryCheckHandler(printer *output.Printer, format option.Format) (metadata.RegistryCheckHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.RegistryCheckHandler {
+		return text.NewRegistryCheckHandler(printer)
+	}, structured.NewRegistryCheckHandler, template.NewRegistryCheckHandler)
+}
+
+// NewBenchHandler returns a bench handler.
+func NewBenchHandler(printer *output.Printer, format option.Format) (metadata.BenchHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.BenchHandler {
+		return text.NewBenchHandler(printer)
+	}, structured.NewBenchHandler, template.NewBenchHandler)
+}
+
+// NewDoctorHandler returns a doctor handler.
+func NewDoctorHandler(printer *output.Printer, format option.Format) (metadata.DoctorHandler, error) {
+	return newFormatHandler(printer, format, func() metadata.DoctorHandler {
+		return text.NewDoctorHandler(printer)
+	}, structured.NewDoctorHandler, template.NewDoctorHandler)
 }
 
 // NewBlobDeleteHandler returns blob delete handlers.
@@ -269,32 +384,59 @@ func NewBlobDeleteHandler(printer *output.Printer, target *option.Target) metada
 
 // NewRepoTagsHandler returns a repo tags handler.
 func NewRepoTagsHandler(out io.Writer, format option.Format) (metadata.RepoTagsHandler, error) {
-	var handler metadata.RepoTagsHandler
-	switch format.Type {
-	case option.FormatTypeText.Name:
-		handler = text.NewRepoTagsHandler(out)
-	case option.FormatTypeJSON.Name:
-		handler = json.NewRepoTagsHandler(out)
-	case option.FormatTypeGoTemplate.Name:
-		handler = template.NewRepoTagsHandler(out, format.Template)
-	default:
-		return nil, errors.UnsupportedFormatTypeError(format.Type)
-	}
-	return handler, nil
+	return newFormatHandler(out, format, func() metadata.RepoTagsHandler {
+		return text.NewRepoTagsHandler(out)
+	}, structured.NewRepoTagsHandler, template.NewRepoTagsHandler)
 }
 
 // NewRepoListHandler returns a repo ls handler.
 func NewRepoListHandler(out io.Writer, format option.Format, registry, namespace string) (metadata.RepoListHandler, error) {
-	var handler metadata.RepoListHandler
+	return newFormatHandler(out, format, func() metadata.RepoListHandler {
+		return text.NewRepoListHandler(out, namespace)
+	}, func(out io.Writer, encode structured.Encoder) metadata.RepoListHandler {
+		return structured.NewRepoListHandler(out, encode, registry)
+	}, func(out io.Writer, tmpl string) metadata.RepoListHandler {
+		return template.NewRepoListHandler(out, tmpl, registry)
+	})
+}
+
+// newFormatHandler returns the metadata handler of the format type. The
+// handler of a structured format is created with the encoder of the format.
+func newFormatHandler[T any](out io.Writer, format option.Format, newText func() T, newStructured func(io.Writer, structured.Encoder) T, newTemplate func(io.Writer, string) T) (T, error) {
 	switch format.Type {
 	case option.FormatTypeText.Name:
-		handler = text.NewRepoListHandler(out, namespace)
-	case option.FormatTypeJSON.Name:
-		handler = json.NewRepoListHandler(out, registry)
+		return newText(), nil
 	case option.FormatTypeGoTemplate.Name:
-		handler = template.NewRepoListHandler(out, format.Template, registry)
+		return newTemplate(out, format.Template), nil
+	}
+	if encode := structuredEncoder(format.Type); encode != nil {
+		return newStructured(out, encode), nil
+	}
+	var zero T
+	return zero, errors.UnsupportedFormatTypeError(format.Type)
+}
+
+// structuredEncoder returns the encoder of a structured format type, or nil if
+// the format type is not structured.
+func structuredEncoder(formatType string) structured.Encoder {
+	switch formatType {
+	case option.FormatTypeJSON.Name:
+		return output.PrintPrettyJSON
+	case option.FormatTypeYAML.Name:
+		return output.PrintYAML
 	default:
-		return nil, errors.UnsupportedFormatTypeError(format.Type)
+		return nil
+	}
+}
+
+// ProgressView returns the view of progress bars of the progress type.
+func ProgressView(terminal option.Terminal) sprogress.View {
+	switch terminal.ProgressType() {
+	case option.ProgressTypeCompact:
+		return sprogress.ViewCompact
+	case option.ProgressTypeAggregate:
+		return sprogress.ViewAggregate
+	default:
+		return sprogress.ViewDetailed
 	}
-	return handler, nil
 }
@@ -16,14 +16,24 @@ limitations under the License.
 package display
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content/memory"
+
 	"oras.land/oras/internal/testutils"
 
+	"oras.land/oras/cmd/oras/internal/display/metadata/structured"
+	"oras.land/oras/cmd/oras/internal/display/metadata/template"
 	"oras.land/oras/cmd/oras/internal/display/metadata/text"
 	"oras.land/oras/cmd/oras/internal/display/status"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
 )
@@ -31,7 +41,7 @@ import (
 func TestNewPushHandler(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 	printer := output.NewPrinter(os.Stdout, os.Stderr)
-	_, _, err := NewPushHandler(printer, option.Format{Type: option.FormatTypeText.Name}, os.Stdout, mockFetcher.Fetcher)
+	_, _, err := NewPushHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{TTY: os.Stdout}, mockFetcher.Fetcher)
 	if err != nil {
 		t.Errorf("NewPushHandler() error = %v, want nil", err)
 	}
@@ -40,7 +50,7 @@ func TestNewPushHandler(t *testing.T) {
 func TestNewAttachHandler(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 	printer := output.NewPrinter(os.Stdout, os.Stderr)
-	_, _, err := NewAttachHandler(printer, option.Format{Type: option.FormatTypeText.Name}, os.Stdout, mockFetcher.Fetcher)
+	_, _, err := NewAttachHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{TTY: os.Stdout}, mockFetcher.Fetcher)
 	if err != nil {
 		t.Errorf("NewAttachHandler() error = %v, want nil", err)
 	}
@@ -48,7 +58,7 @@ func TestNewAttachHandler(t *testing.T) {
 
 func TestNewPullHandler(t *testing.T) {
 	printer := output.NewPrinter(os.Stdout, os.Stderr)
-	_, _, err := NewPullHandler(printer, option.Format{Type: option.FormatTypeText.Name}, "", os.Stdout)
+	_, _, err := NewPullHandler(printer, option.Format{Type: option.FormatTypeText.Name}, "", option.Terminal{TTY: os.Stdout})
 	if err != nil {
 		t.Errorf("NewPullHandler() error = %v, want nil", err)
 	}
@@ -56,14 +66,20 @@ func TestNewPullHandler(t *testing.T) {
 
 func TestNewCopyHandler(t *testing.T) {
 	printer := output.NewPrinter(os.Stdout, os.Stderr)
-	copyHandler, copyMetadataHandler := NewCopyHandler(printer, os.Stdout, nil)
+	copyHandler, copyMetadataHandler, err := NewCopyHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{TTY: os.Stdout}, nil)
+	if err != nil {
+		t.Fatalf("NewCopyHandler() error = %v, want nil", err)
+	}
 	if _, ok := copyHandler.(*status.TTYCopyHandler); !ok {
 		t.Errorf("expected *status.TTYCopyHandler actual %v", reflect.TypeOf(copyHandler))
 	}
 	if _, ok := copyMetadataHandler.(*text.CopyHandler); !ok {
 		t.Errorf("expected metadata.CopyHandler actual %v", reflect.TypeOf(copyMetadataHandler))
 	}
-	copyHandler, copyMetadataHandler = NewCopyHandler(printer, nil, nil)
+	copyHandler, copyMetadataHandler, err = NewCopyHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{}, nil)
+	if err != nil {
+		t.Fatalf("NewCopyHandler() error = %v, want nil", err)
+	}
 	if _, ok := copyHandler.(*status.TextCopyHandler); !ok {
 		t.Errorf("expected *status.TextCopyHandler actual %v", reflect.TypeOf(copyHandler))
 	}
@@ -80,6 +96,7 @@ func TestNewRepoTagsHandler(t *testing.T) {
 	}{
 		{"text format", option.Format{Type: option.FormatTypeText.Name}, false},
 		{"JSON format", option.Format{Type: option.FormatTypeJSON.Name}, false},
+		{"YAML format", option.Format{Type: option.FormatTypeYAML.Name}, false},
 		{"Go template", option.Format{Type: option.FormatTypeGoTemplate.Name, Template: "{{.tags}}"}, false},
 		{"unsupported", option.Format{Type: "unsupported"}, true},
 	}
@@ -111,6 +128,7 @@ func TestNewRepoListHandler(t *testing.T) {
 	}{
 		{"text format", option.Format{Type: option.FormatTypeText.Name}, false},
 		{"JSON format", option.Format{Type: option.FormatTypeJSON.Name}, false},
+		{"YAML format", option.Format{Type: option.FormatTypeYAML.Name}, false},
 		{"Go template", option.Format{Type: option.FormatTypeGoTemplate.Name, Template: "{{.repositories}}"}, false},
 		{"unsupported", option.Format{Type: "unsupported"}, true},
 	}
@@ -142,7 +160,10 @@ func TestNewBackupHandler(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 
 	t.Run("with TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewBackupHandler(printer, os.Stdout, repo, mockFetcher.Fetcher)
+		statusHandler, metadataHandler, err := NewBackupHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{TTY: os.Stdout}, repo, mockFetcher.Fetcher)
+		if err != nil {
+			t.Fatalf("NewBackupHandler() error = %v, want nil", err)
+		}
 		if _, ok := statusHandler.(*status.TTYBackupHandler); !ok {
 			t.Errorf("expected *status.TTYBackupHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -152,7 +173,10 @@ func TestNewBackupHandler(t *testing.T) {
 	})
 
 	t.Run("without TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewBackupHandler(printer, nil, repo, mockFetcher.Fetcher)
+		statusHandler, metadataHandler, err := NewBackupHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{}, repo, mockFetcher.Fetcher)
+		if err != nil {
+			t.Fatalf("NewBackupHandler() error = %v, want nil", err)
+		}
 		if _, ok := statusHandler.(*status.TextBackupHandler); !ok {
 			t.Errorf("expected *status.TextBackupHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -167,7 +191,10 @@ func TestNewRestoreHandler(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 
 	t.Run("with TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewRestoreHandler(printer, os.Stdout, mockFetcher.Fetcher, false)
+		statusHandler, metadataHandler, err := NewRestoreHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{TTY: os.Stdout}, mockFetcher.Fetcher, "backup.tar", false)
+		if err != nil {
+			t.Fatalf("NewRestoreHandler() error = %v, want nil", err)
+		}
 		if _, ok := statusHandler.(*status.TTYRestoreHandler); !ok {
 			t.Errorf("expected *status.TTYRestoreHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -177,7 +204,10 @@ func TestNewRestoreHandler(t *testing.T) {
 	})
 
 	t.Run("without TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewRestoreHandler(printer, nil, mockFetcher.Fetcher, false)
+		statusHandler, metadataHandler, err := NewRestoreHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{}, mockFetcher.Fetcher, "backup.tar", false)
+		if err != nil {
+			t.Fatalf("NewRestoreHandler() error = %v, want nil", err)
+		}
 		if _, ok := statusHandler.(*status.TextRestoreHandler); !ok {
 			t.Errorf("expected *status.TextRestoreHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -186,3 +216,202 @@ func TestNewRestoreHandler(t *testing.T) {
 		}
 	})
 }
+
+func TestNewCopyHandler_formats(t *testing.T) {
+	printer := output.NewPrinter(os.Stdout, os.Stderr)
+	tests := []struct {
+		name         string
+		format       option.Format
+		wantMetadata any
+		wantErr      bool
+	}{
+		{"JSON format", option.Format{Type: option.FormatTypeJSON.Name}, &structured.CopyHandler{}, false},
+		{"YAML format", option.Format{Type: option.FormatTypeYAML.Name}, &structured.CopyHandler{}, false},
+		{"Go template", option.Format{Type: option.FormatTypeGoTemplate.Name, Template: "{{.reference}}"}, &template.CopyHandler{}, false},
+		{"unsupported", option.Format{Type: "unsupported"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusHandler, metadataHandler, err := NewCopyHandler(printer, tt.format, option.Terminal{}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCopyHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// status output is discarded to keep the formatted output parsable
+			if _, ok := statusHandler.(status.DiscardHandler); !ok {
+				t.Errorf("expected status.DiscardHandler actual %v", reflect.TypeOf(statusHandler))
+			}
+			if reflect.TypeOf(metadataHandler) != reflect.TypeOf(tt.wantMetadata) {
+				t.Errorf("expected %v actual %v", reflect.TypeOf(tt.wantMetadata), reflect.TypeOf(metadataHandler))
+			}
+		})
+	}
+}
+
+func TestNewFormattedHandlers(t *testing.T) {
+	printer := output.NewPrinter(os.Stdout, os.Stderr)
+	mockFetcher := testutils.NewMockFetcher()
+	target := &option.Target{}
+	formats := []option.Format{
+		{Type: option.FormatTypeText.Name},
+		{Type: option.FormatTypeJSON.Name},
+		{Type: option.FormatTypeYAML.Name},
+		{Type: option.FormatTypeGoTemplate.Name, Template: "{{.reference}}"},
+	}
+	for _, format := range formats {
+		t.Run(format.Type, func(t *testing.T) {
+			if _, err := NewTagHandler(printer, format, *target); err != nil {
+				t.Errorf("NewTagHandler() error = %v, want nil", err)
+			}
+			if _, err := NewResolveHandler(printer, format, false, "test/repo"); err != nil {
+				t.Errorf("NewResolveHandler() error = %v, want nil", err)
+			}
+			if _, err := NewInspectHandler(printer, format); err != nil {
+				t.Errorf("NewInspectHandler() error = %v, want nil", err)
+			}
+			if _, err := NewDiffHandler(printer, format); err != nil {
+				t.Errorf("NewDiffHandler() error = %v, want nil", err)
+			}
+			if _, err := NewRegistryCheckHandler(printer, format); err != nil {
+				t.Errorf("NewRegistryCheckHandler() error = %v, want nil", err)
+			}
+			if _, err := NewBenchHandler(printer, format); err != nil {
+				t.Errorf("NewBenchHandler() error = %v, want nil", err)
+			}
+			if _, err := NewDoctorHandler(printer, format); err != nil {
+				t.Errorf("NewDoctorHandler() error = %v, want nil", err)
+			}
+			if _, _, err := NewBackupHandler(printer, format, option.Terminal{}, "test/repo", mockFetcher.Fetcher); err != nil {
+				t.Errorf("NewBackupHandler() error = %v, want nil", err)
+			}
+			if _, _, err := NewRestoreHandler(printer, format, option.Terminal{}, mockFetcher.Fetcher, "backup.tar", false); err != nil {
+				t.Errorf("NewRestoreHandler() error = %v, want nil", err)
+			}
+			if _, _, _, err := NewManifestIndexCreateHandler("", printer, format, false, target); err != nil {
+				t.Errorf("NewManifestIndexCreateHandler() error = %v, want nil", err)
+			}
+			if _, _, _, err := NewManifestIndexUpdateHandler("", printer, format, false, target); err != nil {
+				t.Errorf("NewManifestIndexUpdateHandler() error = %v, want nil", err)
+			}
+		})
+	}
+
+	unsupported := option.Format{Type: "unsupported"}
+	if _, err := NewTagHandler(printer, unsupported, *target); err == nil {
+		t.Error("NewTagHandler() error = nil, want error")
+	}
+	if _, err := NewResolveHandler(printer, unsupported, false, "test/repo"); err == nil {
+		t.Error("NewResolveHandler() error = nil, want error")
+	}
+	if _, err := NewInspectHandler(printer, unsupported); err == nil {
+		t.Error("NewInspectHandler() error = nil, want error")
+	}
+	if _, err := NewDiffHandler(printer, unsupported); err == nil {
+		t.Error("NewDiffHandler() error = nil, want error")
+	}
+	if _, err := NewRegistryCheckHandler(printer, unsupported); err == nil {
+		t.Error("NewRegistryCheckHandler() error = nil, want error")
+	}
+	if _, err := NewBenchHandler(printer, unsupported); err == nil {
+		t.Error("NewBenchHandler() error = nil, want error")
+	}
+	if _, err := NewDoctorHandler(printer, unsupported); err == nil {
+		t.Error("NewDoctorHandler() error = nil, want error")
+	}
+	if _, _, err := NewBackupHandler(printer, unsupported, option.Terminal{}, "test/repo", mockFetcher.Fetcher); err == nil {
+		t.Error("NewBackupHandler() error = nil, want error")
+	}
+	if _, _, err := NewRestoreHandler(printer, unsupported, option.Terminal{}, mockFetcher.Fetcher, "backup.tar", false); err == nil {
+		t.Error("NewRestoreHandler() error = nil, want error")
+	}
+	if _, _, _, err := NewManifestIndexCreateHandler("", printer, unsupported, false, target); err == nil {
+		t.Error("NewManifestIndexCreateHandler() error = nil, want error")
+	}
+	// index written to stdout is not mixed with formatted metadata
+	if _, _, _, err := NewManifestIndexCreateHandler("-", printer, unsupported, false, target); err != nil {
+		t.Errorf("NewManifestIndexCreateHandler() error = %v, want nil", err)
+	}
+}
+
+func TestNewCopyHandler_progressEvents(t *testing.T) {
+	printer := output.NewPrinter(os.Stdout, os.Stderr)
+	var events bytes.Buffer
+	copyHandler, _, err := NewCopyHandler(printer, option.Format{Type: option.FormatTypeText.Name}, option.Terminal{TTY: os.Stdout, ProgressEvents: &events}, nil)
+	if err != nil {
+		t.Fatal("NewCopyHandler() error =", err)
+	}
+	// progress events take precedence over progress bars, which would fail
+	// on a non-terminal STDOUT
+	if _, err := copyHandler.StartTracking(memory.New()); err != nil {
+		t.Fatal("StartTracking() error =", err)
+	}
+	if err := copyHandler.OnMounted(context.Background(), ocispec.Descriptor{Size: 1}); err != nil {
+		t.Fatal("OnMounted() error =", err)
+	}
+	if err := copyHandler.StopTracking(); err != nil {
+		t.Fatal("StopTracking() error =", err)
+	}
+	if !strings.Contains(events.String(), `"state":"mounted"`) {
+		t.Errorf("unexpected progress events: %s", events.String())
+	}
+}
+
+func Test_ProgressView(t *testing.T) {
+	tests := []struct {
+		progress string
+		want     sprogress.View
+	}{
+		{"", sprogress.ViewDetailed},
+		{option.ProgressTypeAuto, sprogress.ViewDetailed},
+		{option.ProgressTypeCompact, sprogress.ViewCompact},
+		{option.ProgressTypeAggregate, sprogress.ViewAggregate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.progress, func(t *testing.T) {
+			var terminal option.Terminal
+			cmd := &cobra.Command{}
+			terminal.ApplyFlags(cmd.Flags())
+			if tt.progress != "" {
+				if err := cmd.Flags().Set("progress", tt.progress); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := ProgressView(terminal); got != tt.want {
+				t.Errorf("ProgressView() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_structuredEncoder(t *testing.T) {
+	object := map[string]string{"key": "value"}
+	tests := []struct {
+		formatType string
+		want       string
+	}{
+		{option.FormatTypeJSON.Name, "{\n  \"key\": \"value\"\n}\n"},
+		{option.FormatTypeYAML.Name, "key: value\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formatType, func(t *testing.T) {
+			encode := structuredEncoder(tt.formatType)
+			if encode == nil {
+				t.Fatalf("structuredEncoder(%q) = nil", tt.formatType)
+			}
+			var buf bytes.Buffer
+			if err := encode(&buf, object); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("encoded = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	for _, formatType := range []string{option.FormatTypeText.Name, option.FormatTypeGoTemplate.Name} {
+		if structuredEncoder(formatType) != nil {
+			t.Errorf("structuredEncoder(%q) != nil", formatType)
+		}
+	}
+}
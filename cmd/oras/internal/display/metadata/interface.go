@@ -19,7 +19,9 @@ import (
 	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/stats"
 )
 
 // Renderer renders metadata information when an operation is complete.
@@ -27,9 +29,16 @@ type Renderer interface {
 	Render() error
 }
 
+// StatsHandler handles metadata output for transfer statistics.
+type StatsHandler interface {
+	// OnStats is called with the transfer statistics before rendering.
+	OnStats(summary stats.Summary) error
+}
+
 // PushHandler handles metadata output for push events.
 type PushHandler interface {
 	TaggedHandler
+	StatsHandler
 	Renderer
 
 	OnCopied(opts *option.Target, root ocispec.Descriptor) error
@@ -58,10 +67,14 @@ type ManifestFetchHandler interface {
 
 // PullHandler handles metadata output for pull events.
 type PullHandler interface {
+	StatsHandler
 	Renderer
 
 	// OnLayerSkipped is called when a layer is skipped.
 	OnLayerSkipped(ocispec.Descriptor) error
+	// OnFileSkipped is called when a file is not selected by the filters or
+	// is identical to the existing one with --skip-unchanged.
+	OnFileSkipped(name string, desc ocispec.Descriptor, descPath string) error
 	// OnFilePulled is called after a file is pulled.
 	OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error
 	// OnPulled is called when a pull operation completes.
@@ -76,6 +89,8 @@ type TaggedHandler interface {
 
 // TagHandler handles status output for tag command.
 type TagHandler interface {
+	Renderer
+
 	// OnTagging is called when tagging starts.
 	OnTagging(desc ocispec.Descriptor, tag string) error
 	TaggedHandler
@@ -103,6 +118,7 @@ type ManifestIndexUpdateHandler ManifestIndexCreateHandler
 // CopyHandler handles metadata output for cp events.
 type CopyHandler interface {
 	TaggedHandler
+	StatsHandler
 	Renderer
 
 	OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error
@@ -110,6 +126,7 @@ type CopyHandler interface {
 
 // BackupHandler handles metadata output for backup events.
 type BackupHandler interface {
+	StatsHandler
 	Renderer
 
 	OnTagsFound(tags []string) error
@@ -138,9 +155,51 @@ type BlobPushHandler interface {
 
 // ResolveHandler handles metadata output for resolve events.
 type ResolveHandler interface {
+	Renderer
+
 	OnResolved(desc ocispec.Descriptor) error
 }
 
+// InspectHandler handles metadata output for inspect events.
+type InspectHandler interface {
+	Renderer
+
+	// OnInspected is called after the artifact is inspected.
+	OnInspected(inspected *model.Inspect) error
+}
+
+// DiffHandler handles metadata output for diff events.
+type DiffHandler interface {
+	Renderer
+
+	// OnDiffed is called after the artifacts are compared.
+	OnDiffed(diff *model.Diff) error
+}
+
+// RegistryCheckHandler handles metadata output for registry check events.
+type RegistryCheckHandler interface {
+	Renderer
+
+	// OnRegistryChecked is called after the registry capabilities are probed.
+	OnRegistryChecked(check *model.RegistryCheck) error
+}
+
+// BenchHandler handles metadata output for bench events.
+type BenchHandler interface {
+	Renderer
+
+	// OnBenchmarked is called after the benchmark is completed.
+	OnBenchmarked(bench *model.Bench) error
+}
+
+// DoctorHandler handles metadata output for doctor events.
+type DoctorHandler interface {
+	Renderer
+
+	// OnDiagnosed is called after all diagnostic checks are done.
+	OnDiagnosed(doctor *model.Doctor) error
+}
+
 // ManifestDeleteHandler handles metadata output for manifest delete events.
 type ManifestDeleteHandler interface {
 	OnManifestMissing() error
@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"time"
+)
+
+// TaggedArtifact records a tagged artifact transferred with its referrers.
+type TaggedArtifact struct {
+	// Tag is the tag of the artifact.
+	Tag string `json:"tag"`
+	// ReferrerCount is the number of referrers transferred with the artifact.
+	ReferrerCount int `json:"referrerCount"`
+}
+
+// backup contains metadata formatted by oras backup.
+type backup struct {
+	// Repository is the backed up repository.
+	Repository string `json:"repository"`
+	// Path is the output directory or tar archive.
+	Path string `json:"path"`
+	// Size is the size of the tar archive in bytes. It is omitted if the
+	// output is a directory.
+	Size int64 `json:"size,omitempty"`
+	// Artifacts are the backed up tags.
+	Artifacts []TaggedArtifact `json:"artifacts"`
+	// ElapsedSeconds is the duration of the backup in seconds.
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	// Stats is the transfer statistics, present only with --stats.
+	Stats *Stats `json:"stats,omitempty"`
+}
+
+// NewBackup returns a metadata getter for backup command.
+func NewBackup(repo string, path string, size int64, artifacts []TaggedArtifact, duration time.Duration, stats *Stats) any {
+	if artifacts == nil {
+		artifacts = []TaggedArtifact{}
+	}
+	return backup{
+		Repository:     repo,
+		Path:           path,
+		Size:           size,
+		Artifacts:      artifacts,
+		ElapsedSeconds: duration.Seconds(),
+		Stats:          stats,
+	}
+}
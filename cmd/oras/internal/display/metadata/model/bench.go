@@ -0,0 +1,103 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// Latency summarizes the latencies of requests of a kind, in milliseconds.
+type Latency struct {
+	// Kind is the request method, or TOKEN for token fetches.
+	Kind  string  `json:"kind"`
+	Count int     `json:"count"`
+	P50   float64 `json:"p50Ms"`
+	P90   float64 `json:"p90Ms"`
+	P99   float64 `json:"p99Ms"`
+	Max   float64 `json:"maxMs"`
+}
+
+// NewLatency creates a latency summary.
+func NewLatency(kind string, count int, p50, p90, p99, max time.Duration) Latency {
+	return Latency{
+		Kind:  kind,
+		Count: count,
+		P50:   milliseconds(p50),
+		P90:   milliseconds(p90),
+		P99:   milliseconds(p99),
+		Max:   milliseconds(max),
+	}
+}
+
+// Throughput is the throughput of a transfer phase.
+type Throughput struct {
+	Bytes          int64   `json:"bytes"`
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	BytesPerSecond float64 `json:"bytesPerSecond"`
+}
+
+// NewThroughput creates a throughput of transferring n bytes in elapsed.
+func NewThroughput(n int64, elapsed time.Duration) Throughput {
+	t := Throughput{
+		Bytes:          n,
+		ElapsedSeconds: elapsed.Seconds(),
+	}
+	if elapsed > 0 {
+		t.BytesPerSecond = float64(n) / elapsed.Seconds()
+	}
+	return t
+}
+
+// BenchRun is the result of pushing and pulling blobs of a size at a
+// concurrency level.
+type BenchRun struct {
+	Size        int64      `json:"size"`
+	Count       int        `json:"count"`
+	Concurrency int        `json:"concurrency"`
+	Push        Throughput `json:"push"`
+	Pull        Throughput `json:"pull"`
+	Latencies   []Latency  `json:"latencies"`
+}
+
+// TokenFetches summarizes the overhead of fetching auth tokens.
+type TokenFetches struct {
+	Count   int     `json:"count"`
+	TotalMs float64 `json:"totalMs"`
+}
+
+// Bench is a model for benchmarking a repository.
+type Bench struct {
+	Repository    string       `json:"repository"`
+	Runs          []BenchRun   `json:"runs"`
+	TokenFetches  TokenFetches `json:"tokenFetches"`
+	CleanupErrors []string     `json:"cleanupErrors,omitempty"`
+}
+
+// NewBench creates a new bench model.
+func NewBench(repository string) *Bench {
+	return &Bench{
+		Repository: repository,
+		Runs:       []BenchRun{},
+	}
+}
+
+// AddTokenFetches records count token fetches taking total time.
+func (b *Bench) AddTokenFetches(count int, total time.Duration) {
+	b.TokenFetches.Count += count
+	b.TokenFetches.TotalMs += milliseconds(total)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,40 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+// copied contains metadata formatted by oras cp.
+type copied struct {
+	// Descriptor is the copied root with its reference in the destination.
+	Descriptor
+	// Source is the reference of the copied root in the source.
+	Source DigestReference `json:"source"`
+	// ReferenceAsTags are the tagged references in the destination.
+	ReferenceAsTags []string `json:"referenceAsTags"`
+	// Stats is the transfer statistics, present only with --stats.
+	Stats *Stats `json:"stats,omitempty"`
+}
+
+// NewCopy returns a metadata getter for cp command.
+func NewCopy(desc ocispec.Descriptor, srcPath string, dstPath string, tags []string, stats *Stats) any {
+	return copied{
+		Descriptor:      FromDescriptor(dstPath, desc),
+		Source:          NewDigestReference(srcPath, desc.Digest.String()),
+		ReferenceAsTags: referenceAsTags(dstPath, tags),
+		Stats:           stats,
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/descriptor"
+)
+
+// Kinds of a change.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is a difference between the compared artifacts.
+type Change struct {
+	// Kind is one of added, removed or changed.
+	Kind string `json:"change"`
+	// Key identifies the changed item, e.g. a layer title, an annotation key
+	// or a platform. It is omitted for single-valued fields.
+	Key string `json:"key,omitempty"`
+	// From is the value in the first artifact. It is null if added, so that
+	// empty values, such as empty annotations, are kept.
+	From any `json:"from"`
+	// To is the value in the second artifact. It is null if removed.
+	To any `json:"to"`
+}
+
+// Diff is a model for the differences between two manifests or indexes.
+type Diff struct {
+	From Descriptor `json:"from"`
+	To   Descriptor `json:"to"`
+	// Identical is true if both artifacts have the same digest.
+	Identical    bool     `json:"identical"`
+	MediaType    *Change  `json:"mediaType,omitempty"`
+	ArtifactType *Change  `json:"artifactType,omitempty"`
+	Config       *Change  `json:"config,omitempty"`
+	Subject      *Change  `json:"subject,omitempty"`
+	Layers       []Change `json:"layers,omitempty"`
+	Annotations  []Change `json:"annotations,omitempty"`
+	Platforms    []Change `json:"platforms,omitempty"`
+}
+
+// NewDiff compares the manifest content fromManifest described by fromDesc
+// with toManifest described by toDesc. Layers are matched by title and
+// manifests of indexes by platform.
+func NewDiff(fromPath string, fromDesc ocispec.Descriptor, fromManifest []byte, toPath string, toDesc ocispec.Descriptor, toManifest []byte) (*Diff, error) {
+	// annotations of the resolved descriptors are not part of the manifests
+	fromDesc.Annotations = nil
+	toDesc.Annotations = nil
+	diff := &Diff{
+		From:      FromDescriptor(fromPath, fromDesc),
+		To:        FromDescriptor(toPath, toDesc),
+		Identical: fromDesc.Digest == toDesc.Digest,
+	}
+	if diff.Identical {
+		return diff, nil
+	}
+	var from, to inspectedContent
+	if err := json.Unmarshal(fromManifest, &from); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", fromDesc.Digest, err)
+	}
+	if err := json.Unmarshal(toManifest, &to); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", toDesc.Digest, err)
+	}
+
+	diff.MediaType = diffString(fromDesc.MediaType, toDesc.MediaType)
+	diff.ArtifactType = diffString(from.ArtifactType, to.ArtifactType)
+	diff.Config = diffDescriptor(from.Config, to.Config)
+	diff.Subject = diffDescriptor(from.Subject, to.Subject)
+	diff.Layers = diffDescriptors(from.Layers, to.Layers, layerKey)
+	diff.Annotations = diffAnnotations(from.Annotations, to.Annotations)
+	diff.Platforms = diffDescriptors(from.Manifests, to.Manifests, platformKey)
+	return diff, nil
+}
+
+// HasChanges returns true if any difference is found.
+func (d *Diff) HasChanges() bool {
+	return d.MediaType != nil || d.ArtifactType != nil || d.Config != nil || d.Subject != nil ||
+		len(d.Layers) > 0 || len(d.Annotations) > 0 || len(d.Platforms) > 0
+}
+
+func diffString(from, to string) *Change {
+	switch {
+	case from == to:
+		return nil
+	case from == "":
+		return &Change{Kind: ChangeAdded, To: to}
+	case to == "":
+		return &Change{Kind: ChangeRemoved, From: from}
+	default:
+		return &Change{Kind: ChangeChanged, From: from, To: to}
+	}
+}
+
+func diffDescriptor(from, to *ocispec.Descriptor) *Change {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		return &Change{Kind: ChangeAdded, To: to}
+	case to == nil:
+		return &Change{Kind: ChangeRemoved, From: from}
+	case equalDescriptor(*from, *to):
+		return nil
+	default:
+		return &Change{Kind: ChangeChanged, From: from, To: to}
+	}
+}
+
+// equalDescriptor returns true if both descriptors describe the same content
+// with the same annotations, artifact type and platform.
+func equalDescriptor(from, to ocispec.Descriptor) bool {
+	return content.Equal(from, to) &&
+		from.ArtifactType == to.ArtifactType &&
+		maps.Equal(from.Annotations, to.Annotations) &&
+		equalPlatform(from.Platform, to.Platform)
+}
+
+// equalPlatform returns true if both platforms are nil or equal.
+func equalPlatform(from, to *ocispec.Platform) bool {
+	if from == nil || to == nil {
+		return from == to
+	}
+	return from.OS == to.OS &&
+		from.Architecture == to.Architecture &&
+		from.Variant == to.Variant &&
+		from.OSVersion == to.OSVersion &&
+		slices.Equal(from.OSFeatures, to.OSFeatures)
+}
+
+// diffDescriptors matches descriptors by key and returns the changes in the
+// order of from, followed by the descriptors added in to.
+func diffDescriptors(from, to []ocispec.Descriptor, key func(ocispec.Descriptor) string) []Change {
+	fromKeys := keyDescriptors(from, key)
+	toKeys := keyDescriptors(to, key)
+	toIndex := make(map[string]int, len(to))
+	for i, k := range toKeys {
+		toIndex[k] = i
+	}
+
+	var changes []Change
+	matched := make(map[string]bool, len(from))
+	for i, k := range fromKeys {
+		j, ok := toIndex[k]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Key: k, From: &from[i]})
+			continue
+		}
+		matched[k] = true
+		if !equalDescriptor(from[i], to[j]) {
+			changes = append(changes, Change{Kind: ChangeChanged, Key: k, From: &from[i], To: &to[j]})
+		}
+	}
+	for j, k := range toKeys {
+		if !matched[k] {
+			changes = append(changes, Change{Kind: ChangeAdded, Key: k, To: &to[j]})
+		}
+	}
+	return changes
+}
+
+// keyDescriptors returns the key of each descriptor. Repeated keys are
+// suffixed with their occurrence, e.g. "linux/amd64#2".
+func keyDescriptors(descs []ocispec.Descriptor, key func(ocispec.Descriptor) string) []string {
+	keys := make([]string, len(descs))
+	seen := make(map[string]int, len(descs))
+	for i, desc := range descs {
+		k := key(desc)
+		seen[k]++
+		if n := seen[k]; n > 1 {
+			k = fmt.Sprintf("%s#%d", k, n)
+		}
+		keys[i] = k
+	}
+	return keys
+}
+
+func layerKey(desc ocispec.Descriptor) string {
+	if title := desc.Annotations[ocispec.AnnotationTitle]; title != "" {
+		return title
+	}
+	return "<untitled>"
+}
+
+func platformKey(desc ocispec.Descriptor) string {
+	if desc.Platform == nil {
+		return "<none>"
+	}
+	return descriptor.PlatformString(desc.Platform)
+}
+
+func diffAnnotations(from, to map[string]string) []Change {
+	keys := make(map[string]struct{}, len(from)+len(to))
+	for k := range from {
+		keys[k] = struct{}{}
+	}
+	for k := range to {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, k := range sorted {
+		fromValue, inFrom := from[k]
+		toValue, inTo := to[k]
+		switch {
+		case !inFrom:
+			changes = append(changes, Change{Kind: ChangeAdded, Key: k, To: toValue})
+		case !inTo:
+			changes = append(changes, Change{Kind: ChangeRemoved, Key: k, From: fromValue})
+		case fromValue != toValue:
+			changes = append(changes, Change{Kind: ChangeChanged, Key: k, From: fromValue, To: toValue})
+		}
+	}
+	return changes
+}
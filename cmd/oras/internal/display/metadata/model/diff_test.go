@@ -0,0 +1,185 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestNewDiff(t *testing.T) {
+	from := []byte(`{
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/vnd.test",
+  "config": {"mediaType": "application/vnd.oci.empty.v1+json", "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", "size": 2},
+  "layers": [
+    {"mediaType": "text/plain", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "annotations": {"org.opencontainers.image.title": "a.txt"}},
+    {"mediaType": "text/plain", "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222", "size": 2, "annotations": {"org.opencontainers.image.title": "b.txt"}},
+    {"mediaType": "text/plain", "digest": "sha256:3333333333333333333333333333333333333333333333333333333333333333", "size": 3}
+  ],
+  "annotations": {"foo": "bar", "gone": "1"}
+}`)
+	to := []byte(`{
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/vnd.test",
+  "config": {"mediaType": "application/vnd.oci.empty.v1+json", "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", "size": 2},
+  "subject": {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:4444444444444444444444444444444444444444444444444444444444444444", "size": 4},
+  "layers": [
+    {"mediaType": "text/plain", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "annotations": {"org.opencontainers.image.title": "a.txt"}},
+    {"mediaType": "text/plain", "digest": "sha256:5555555555555555555555555555555555555555555555555555555555555555", "size": 5, "annotations": {"org.opencontainers.image.title": "b.txt"}},
+    {"mediaType": "text/plain", "digest": "sha256:3333333333333333333333333333333333333333333333333333333333333333", "size": 3},
+    {"mediaType": "text/plain", "digest": "sha256:6666666666666666666666666666666666666666666666666666666666666666", "size": 6}
+  ],
+  "annotations": {"foo": "baz", "new": "2"}
+}`)
+	fromDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromBytes(from), Size: int64(len(from))}
+	toDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromBytes(to), Size: int64(len(to))}
+
+	got, err := NewDiff("localhost:5000/a", fromDesc, from, "localhost:5000/b", toDesc, to)
+	if err != nil {
+		t.Fatal("NewDiff() error =", err)
+	}
+	if got.Identical || !got.HasChanges() {
+		t.Fatalf("NewDiff() Identical = %v, HasChanges() = %v", got.Identical, got.HasChanges())
+	}
+	if got.MediaType != nil || got.ArtifactType != nil || got.Config != nil {
+		t.Errorf("NewDiff() reported unchanged fields: %+v %+v %+v", got.MediaType, got.ArtifactType, got.Config)
+	}
+	if got.Subject == nil || got.Subject.Kind != ChangeAdded {
+		t.Errorf("NewDiff() Subject = %+v, want added", got.Subject)
+	}
+
+	var layers []string
+	for _, c := range got.Layers {
+		layers = append(layers, c.Kind+" "+c.Key)
+	}
+	wantLayers := []string{"changed b.txt", "added <untitled>#2"}
+	if !reflect.DeepEqual(layers, wantLayers) {
+		t.Errorf("NewDiff() Layers = %v, want %v", layers, wantLayers)
+	}
+
+	wantAnnotations := []Change{
+		{Kind: ChangeChanged, Key: "foo", From: "bar", To: "baz"},
+		{Kind: ChangeRemoved, Key: "gone", From: "1"},
+		{Kind: ChangeAdded, Key: "new", To: "2"},
+	}
+	if !reflect.DeepEqual(got.Annotations, wantAnnotations) {
+		t.Errorf("NewDiff() Annotations = %+v, want %+v", got.Annotations, wantAnnotations)
+	}
+}
+
+func TestNewDiff_index(t *testing.T) {
+	from := []byte(`{
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "platform": {"os": "linux", "architecture": "amd64"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222", "size": 2, "platform": {"os": "linux", "architecture": "arm64", "variant": "v8"}}
+  ]
+}`)
+	to := []byte(`{
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:3333333333333333333333333333333333333333333333333333333333333333", "size": 3, "platform": {"os": "linux", "architecture": "amd64"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:4444444444444444444444444444444444444444444444444444444444444444", "size": 4, "platform": {"os": "windows", "architecture": "amd64"}}
+  ]
+}`)
+	fromDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromBytes(from), Size: int64(len(from))}
+	toDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromBytes(to), Size: int64(len(to))}
+
+	got, err := NewDiff("a", fromDesc, from, "b", toDesc, to)
+	if err != nil {
+		t.Fatal("NewDiff() error =", err)
+	}
+	var platforms []string
+	for _, c := range got.Platforms {
+		platforms = append(platforms, c.Kind+" "+c.Key)
+	}
+	want := []string{"changed linux/amd64", "removed linux/arm64/v8", "added windows/amd64"}
+	if !reflect.DeepEqual(platforms, want) {
+		t.Errorf("NewDiff() Platforms = %v, want %v", platforms, want)
+	}
+}
+
+func TestNewDiff_identical(t *testing.T) {
+	manifest := []byte(`{"mediaType": "application/vnd.oci.image.manifest.v1+json"}`)
+	desc := ocispec.Descriptor{
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Digest:      digest.FromBytes(manifest),
+		Size:        int64(len(manifest)),
+		Annotations: map[string]string{ocispec.AnnotationRefName: "v1"},
+	}
+	got, err := NewDiff("a", desc, manifest, "b", desc, manifest)
+	if err != nil {
+		t.Fatal("NewDiff() error =", err)
+	}
+	if !got.Identical || got.HasChanges() {
+		t.Errorf("NewDiff() Identical = %v, HasChanges() = %v", got.Identical, got.HasChanges())
+	}
+	if got.From.Annotations != nil {
+		t.Errorf("NewDiff() From.Annotations = %v, want nil", got.From.Annotations)
+	}
+}
+
+func TestNewDiff_descriptorMetadata(t *testing.T) {
+	from := []byte(`{
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "platform": {"os": "linux", "architecture": "amd64"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222", "size": 2, "platform": {"os": "linux", "architecture": "arm64"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:3333333333333333333333333333333333333333333333333333333333333333", "size": 3, "platform": {"os": "windows", "architecture": "amd64", "os.version": "10.0.17763"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:4444444444444444444444444444444444444444444444444444444444444444", "size": 4, "platform": {"os": "linux", "architecture": "s390x"}}
+  ],
+  "annotations": {"foo": "bar"}
+}`)
+	to := []byte(`{
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "platform": {"os": "linux", "architecture": "amd64"}, "annotations": {"foo": "bar"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222", "size": 2, "platform": {"os": "linux", "architecture": "arm64"}, "artifactType": "application/vnd.test"},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:3333333333333333333333333333333333333333333333333333333333333333", "size": 3, "platform": {"os": "windows", "architecture": "amd64", "os.version": "10.0.20348"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:4444444444444444444444444444444444444444444444444444444444444444", "size": 4, "platform": {"os": "linux", "architecture": "s390x"}}
+  ],
+  "annotations": {"foo": ""}
+}`)
+	fromDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromBytes(from), Size: int64(len(from))}
+	toDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromBytes(to), Size: int64(len(to))}
+
+	got, err := NewDiff("a", fromDesc, from, "b", toDesc, to)
+	if err != nil {
+		t.Fatal("NewDiff() error =", err)
+	}
+	var platforms []string
+	for _, c := range got.Platforms {
+		platforms = append(platforms, c.Kind+" "+c.Key)
+	}
+	want := []string{"changed linux/amd64", "changed linux/arm64", "changed windows/amd64"}
+	if !reflect.DeepEqual(platforms, want) {
+		t.Errorf("NewDiff() Platforms = %v, want %v", platforms, want)
+	}
+
+	// empty values are kept in the output
+	annotations, err := json.Marshal(got.Annotations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"change":"changed","key":"foo","from":"bar","to":""}]`; string(annotations) != want {
+		t.Errorf("NewDiff() Annotations = %s, want %s", annotations, want)
+	}
+}
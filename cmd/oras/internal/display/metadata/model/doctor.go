@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// Statuses of a diagnostic check.
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// DiagnosticCheck is the result of a diagnostic check.
+type DiagnosticCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Doctor is a model for the diagnostics of the environment and of an
+// optional registry.
+type Doctor struct {
+	// Registry is the diagnosed registry, omitted if none is specified.
+	Registry string            `json:"registry,omitempty"`
+	Checks   []DiagnosticCheck `json:"checks"`
+}
+
+// NewDoctor creates a new doctor model.
+func NewDoctor(registry string) *Doctor {
+	return &Doctor{
+		Registry: registry,
+		Checks:   []DiagnosticCheck{},
+	}
+}
+
+// Add adds the result of a check.
+func (d *Doctor) Add(name, status, message string) {
+	d.Checks = append(d.Checks, DiagnosticCheck{
+		Name:    name,
+		Status:  status,
+		Message: message,
+	})
+}
+
+// Count returns the number of checks with the given status.
+func (d *Doctor) Count(status string) int {
+	var n int
+	for _, check := range d.Checks {
+		if check.Status == status {
+			n++
+		}
+	}
+	return n
+}
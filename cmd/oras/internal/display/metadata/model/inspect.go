@@ -0,0 +1,201 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/docker"
+)
+
+// InspectedConfig summarizes the config blob of an inspected manifest.
+type InspectedConfig struct {
+	MediaType string        `json:"mediaType"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+	// Platform is read from an image config and omitted for other configs.
+	Platform *ocispec.Platform `json:"platform,omitempty"`
+	// Created is read from an image config and omitted for other configs.
+	Created string `json:"created,omitempty"`
+}
+
+// InspectedLayer summarizes a layer of an inspected manifest.
+type InspectedLayer struct {
+	Title     string        `json:"title,omitempty"`
+	MediaType string        `json:"mediaType"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+}
+
+// InspectedManifest summarizes a manifest of an inspected index.
+type InspectedManifest struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       digest.Digest     `json:"digest"`
+	Size         int64             `json:"size"`
+	Platform     *ocispec.Platform `json:"platform,omitempty"`
+}
+
+// ReferrersSummary summarizes the referrers of an inspected artifact.
+type ReferrersSummary struct {
+	// Count is the number of direct referrers.
+	Count int `json:"count"`
+	// ArtifactTypes counts the direct referrers by artifact type.
+	ArtifactTypes map[string]int `json:"artifactTypes"`
+}
+
+// Inspect is a model for an inspected artifact.
+type Inspect struct {
+	Descriptor
+	// TotalSize is the size of the manifest and of the content it directly
+	// references.
+	TotalSize int64               `json:"totalSize"`
+	Subject   *ocispec.Descriptor `json:"subject,omitempty"`
+	Config    *InspectedConfig    `json:"config,omitempty"`
+	Layers    []InspectedLayer    `json:"layers,omitempty"`
+	Manifests []InspectedManifest `json:"manifests,omitempty"`
+	Referrers ReferrersSummary    `json:"referrers"`
+}
+
+// inspectedContent is the union of the fields of image manifests and indexes
+// that are inspected.
+type inspectedContent struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Config       *ocispec.Descriptor  `json:"config"`
+	Layers       []ocispec.Descriptor `json:"layers"`
+	Manifests    []ocispec.Descriptor `json:"manifests"`
+	Subject      *ocispec.Descriptor  `json:"subject"`
+	Annotations  map[string]string    `json:"annotations"`
+}
+
+// NewInspect creates a new inspect model from the manifest content described
+// by desc.
+func NewInspect(path string, desc ocispec.Descriptor, manifest []byte) (*Inspect, error) {
+	var content inspectedContent
+	if err := json.Unmarshal(manifest, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	if desc.MediaType == "" {
+		desc.MediaType = content.MediaType
+	}
+	desc.ArtifactType = content.ArtifactType
+	desc.Annotations = content.Annotations
+	inspected := &Inspect{
+		Descriptor: FromDescriptor(path, desc),
+		TotalSize:  desc.Size,
+		Subject:    content.Subject,
+		Referrers: ReferrersSummary{
+			ArtifactTypes: map[string]int{},
+		},
+	}
+
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageIndex, docker.MediaTypeManifestList:
+		inspected.Manifests = []InspectedManifest{}
+		for _, m := range content.Manifests {
+			inspected.Manifests = append(inspected.Manifests, InspectedManifest{
+				MediaType:    m.MediaType,
+				ArtifactType: m.ArtifactType,
+				Digest:       m.Digest,
+				Size:         m.Size,
+				Platform:     m.Platform,
+			})
+			inspected.TotalSize += m.Size
+		}
+	default:
+		if content.Config != nil {
+			inspected.Config = &InspectedConfig{
+				MediaType: content.Config.MediaType,
+				Digest:    content.Config.Digest,
+				Size:      content.Config.Size,
+			}
+			inspected.TotalSize += content.Config.Size
+			if inspected.ArtifactType == "" && content.Config.MediaType != ocispec.MediaTypeEmptyJSON {
+				// artifact type falls back to the config media type
+				inspected.ArtifactType = content.Config.MediaType
+			}
+		}
+		inspected.Layers = []InspectedLayer{}
+		for _, layer := range content.Layers {
+			inspected.Layers = append(inspected.Layers, InspectedLayer{
+				Title:     layer.Annotations[ocispec.AnnotationTitle],
+				MediaType: layer.MediaType,
+				Digest:    layer.Digest,
+				Size:      layer.Size,
+			})
+			inspected.TotalSize += layer.Size
+		}
+	}
+	return inspected, nil
+}
+
+// IsIndex returns true if the inspected artifact is an index.
+func (i *Inspect) IsIndex() bool {
+	return i.Manifests != nil
+}
+
+// IsImageConfig returns true if the config of the inspected manifest is an
+// image config which can be summarized by SetImageConfig.
+func (i *Inspect) IsImageConfig() bool {
+	if i.Config == nil {
+		return false
+	}
+	switch i.Config.MediaType {
+	case ocispec.MediaTypeImageConfig, docker.MediaTypeConfig:
+		return true
+	}
+	return false
+}
+
+// SetImageConfig adds the platform and creation time of the image config to
+// the config summary.
+func (i *Inspect) SetImageConfig(config []byte) error {
+	var image ocispec.Image
+	if err := json.Unmarshal(config, &image); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", i.Config.Digest, err)
+	}
+	if image.OS != "" || image.Architecture != "" {
+		platform := image.Platform
+		i.Config.Platform = &platform
+	}
+	if image.Created != nil {
+		i.Config.Created = image.Created.UTC().Format(time.RFC3339)
+	}
+	return nil
+}
+
+// AddReferrer adds a direct referrer to the referrers summary.
+func (i *Inspect) AddReferrer(referrer ocispec.Descriptor) {
+	i.Referrers.Count++
+	i.Referrers.ArtifactTypes[referrer.ArtifactType]++
+}
+
+// ReferrerArtifactTypes returns the artifact types of the referrers in
+// lexical order.
+func (i *Inspect) ReferrerArtifactTypes() []string {
+	types := make([]string, 0, len(i.Referrers.ArtifactTypes))
+	for t := range i.Referrers.ArtifactTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
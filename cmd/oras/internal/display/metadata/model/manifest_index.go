@@ -0,0 +1,36 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+// index contains metadata formatted by oras manifest index create and
+// oras manifest index update.
+type index struct {
+	// Descriptor is the created index with its reference.
+	Descriptor
+	// ReferenceAsTags are the tagged references of the index. It is empty
+	// if the index is not pushed.
+	ReferenceAsTags []string `json:"referenceAsTags"`
+}
+
+// NewIndex returns a metadata getter for index create and update commands.
+func NewIndex(desc ocispec.Descriptor, path string, tags []string) any {
+	return index{
+		Descriptor:      FromDescriptor(path, desc),
+		ReferenceAsTags: referenceAsTags(path, tags),
+	}
+}
@@ -54,25 +54,38 @@ func newFile(name string, outputDir string, desc ocispec.Descriptor, descPath st
 	}, nil
 }
 
+// SkippedFile records metadata of a file not selected by the filters or
+// identical to the existing one.
+type SkippedFile struct {
+	// Name is the file name in the title annotation.
+	Name string `json:"name"`
+	Descriptor
+}
+
 type pull struct {
 	DigestReference
-	Files []File `json:"files"`
+	Files   []File        `json:"files"`
+	Skipped []SkippedFile `json:"skipped,omitempty"`
+	Stats   *Stats        `json:"stats,omitempty"`
 }
 
 // NewPull creates a new metadata struct for pull command.
-func NewPull(digestReference string, files []File) any {
+func NewPull(digestReference string, files []File, skipped []SkippedFile, stats *Stats) any {
 	return pull{
 		DigestReference: DigestReference{
 			Reference: digestReference,
 		},
-		Files: files,
+		Files:   files,
+		Skipped: skipped,
+		Stats:   stats,
 	}
 }
 
-// Pulled records all pulled files.
+// Pulled records all pulled and skipped files.
 type Pulled struct {
-	lock  sync.Mutex
-	files []File
+	lock    sync.Mutex
+	files   []File
+	skipped []SkippedFile
 }
 
 // Files returns all pulled files.
@@ -93,3 +106,20 @@ func (p *Pulled) Add(name string, outputDir string, desc ocispec.Descriptor, des
 	p.files = append(p.files, file)
 	return nil
 }
+
+// Skipped returns all skipped files.
+func (p *Pulled) Skipped() []SkippedFile {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return slices.Clone(p.skipped)
+}
+
+// AddSkipped adds a skipped file.
+func (p *Pulled) AddSkipped(name string, desc ocispec.Descriptor, descPath string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.skipped = append(p.skipped, SkippedFile{
+		Name:       name,
+		Descriptor: FromDescriptor(descPath, desc),
+	})
+}
@@ -23,16 +23,23 @@ import (
 type push struct {
 	Descriptor
 	ReferenceAsTags []string `json:"referenceAsTags"`
+	Stats           *Stats   `json:"stats,omitempty"`
 }
 
 // NewPush returns a metadata getter for push command.
-func NewPush(desc ocispec.Descriptor, path string, tags []string) any {
+func NewPush(desc ocispec.Descriptor, path string, tags []string, stats *Stats) any {
+	return push{
+		Descriptor:      FromDescriptor(path, desc),
+		ReferenceAsTags: referenceAsTags(path, tags),
+		Stats:           stats,
+	}
+}
+
+// referenceAsTags returns the references of path tagged with tags.
+func referenceAsTags(path string, tags []string) []string {
 	var refAsTags []string
 	for _, tag := range tags {
 		refAsTags = append(refAsTags, path+":"+tag)
 	}
-	return push{
-		Descriptor:      FromDescriptor(path, desc),
-		ReferenceAsTags: refAsTags,
-	}
+	return refAsTags
 }
@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// Statuses of a registry capability.
+const (
+	CapabilitySupported   = "supported"
+	CapabilityUnsupported = "unsupported"
+	// CapabilityUnknown is reported if the probe failed for a reason not
+	// related to the capability, e.g. a network error.
+	CapabilityUnknown = "unknown"
+)
+
+// Names of the probed registry capabilities.
+const (
+	CapabilityReferrersAPI      = "referrersAPI"
+	CapabilityArtifactType      = "artifactType"
+	CapabilityMount             = "crossRepositoryMount"
+	CapabilityChunkedUpload     = "chunkedUpload"
+	CapabilityManifestDelete    = "manifestDelete"
+	CapabilityBlobDelete        = "blobDelete"
+	CapabilityTagListPagination = "tagListPagination"
+	CapabilityCatalog           = "catalog"
+)
+
+// Capability is the probe result of a registry capability.
+type Capability struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// RecommendedFlags are the flag values recommended for a registry.
+type RecommendedFlags struct {
+	// DistributionSpec is the value for --distribution-spec. It is empty if
+	// the referrers API could not be probed.
+	DistributionSpec string `json:"distributionSpec,omitempty"`
+	// ImageSpec is the value for --image-spec. It is empty if the artifact
+	// type could not be probed.
+	ImageSpec string `json:"imageSpec,omitempty"`
+}
+
+// RegistryCheck is a model for the capabilities of a registry.
+type RegistryCheck struct {
+	Registry     string           `json:"registry"`
+	Repository   string           `json:"repository"`
+	Capabilities []Capability     `json:"capabilities"`
+	Recommended  RecommendedFlags `json:"recommended"`
+	// CleanedUp is true if the probe content is removed.
+	CleanedUp bool `json:"cleanedUp"`
+	// CleanupErrors are the errors encountered on removing the probe
+	// content.
+	CleanupErrors []string `json:"cleanupErrors,omitempty"`
+}
+
+// NewRegistryCheck creates a new registry check model.
+func NewRegistryCheck(registry, repository string) *RegistryCheck {
+	return &RegistryCheck{
+		Registry:     registry,
+		Repository:   repository,
+		Capabilities: []Capability{},
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"time"
+)
+
+// restore contains metadata formatted by oras restore.
+type restore struct {
+	// Repository is the restored repository.
+	Repository string `json:"repository"`
+	// Path is the input directory or tar archive.
+	Path string `json:"path"`
+	// Size is the size of the tar archive in bytes. It is omitted if the
+	// input is a directory.
+	Size int64 `json:"size,omitempty"`
+	// DryRun indicates that nothing is pushed and artifacts are the ones
+	// that would be restored.
+	DryRun bool `json:"dryRun"`
+	// Artifacts are the restored tags.
+	Artifacts []TaggedArtifact `json:"artifacts"`
+	// ElapsedSeconds is the duration of the restore in seconds.
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+}
+
+// NewRestore returns a metadata getter for restore command.
+func NewRestore(repo string, path string, size int64, dryRun bool, artifacts []TaggedArtifact, duration time.Duration) any {
+	if artifacts == nil {
+		artifacts = []TaggedArtifact{}
+	}
+	return restore{
+		Repository:     repo,
+		Path:           path,
+		Size:           size,
+		DryRun:         dryRun,
+		Artifacts:      artifacts,
+		ElapsedSeconds: duration.Seconds(),
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"oras.land/oras/internal/stats"
+)
+
+// Phase contains the wall time of a phase of an operation.
+type Phase struct {
+	Name           string  `json:"name"`
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+}
+
+// Stats contains transfer statistics of an operation.
+type Stats struct {
+	BytesTransferred int64          `json:"bytesTransferred"`
+	BytesSkipped     int64          `json:"bytesSkipped"`
+	BytesMounted     int64          `json:"bytesMounted"`
+	Counts           map[string]int `json:"counts"`
+	BytesPerSecond   float64        `json:"bytesPerSecond"`
+	ElapsedSeconds   float64        `json:"elapsedSeconds"`
+	Phases           []Phase        `json:"phases"`
+}
+
+// NewStats converts a statistics summary into metadata.
+// It returns nil if summary is nil.
+func NewStats(summary *stats.Summary) *Stats {
+	if summary == nil {
+		return nil
+	}
+	counts := make(map[string]int, len(summary.Counts))
+	for state, count := range summary.Counts {
+		counts[state.String()] = count
+	}
+	phases := make([]Phase, 0, len(summary.Phases))
+	for _, phase := range summary.Phases {
+		phases = append(phases, Phase{
+			Name:           phase.Name,
+			ElapsedSeconds: phase.Elapsed.Seconds(),
+		})
+	}
+	return &Stats{
+		BytesTransferred: summary.BytesTransferred,
+		BytesSkipped:     summary.BytesSkipped,
+		BytesMounted:     summary.BytesMounted,
+		Counts:           counts,
+		BytesPerSecond:   summary.Throughput(),
+		ElapsedSeconds:   summary.Elapsed.Seconds(),
+		Phases:           phases,
+	}
+}
@@ -18,6 +18,8 @@ package model
 import (
 	"slices"
 	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // Tagged contains metadata formatted by oras Tagged.
@@ -41,3 +43,19 @@ func (tag *Tagged) Tags() []string {
 	slices.Sort(tag.tags)
 	return tag.tags
 }
+
+// tagging contains metadata formatted by oras tag.
+type tagging struct {
+	// Descriptor is the tagged descriptor with its reference.
+	Descriptor
+	// ReferenceAsTags are the new tagged references.
+	ReferenceAsTags []string `json:"referenceAsTags"`
+}
+
+// NewTag returns a metadata getter for tag command.
+func NewTag(desc ocispec.Descriptor, path string, tags []string) any {
+	return tagging{
+		Descriptor:      FromDescriptor(path, desc),
+		ReferenceAsTags: referenceAsTags(path, tags),
+	}
+}
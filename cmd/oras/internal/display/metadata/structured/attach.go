@@ -13,7 +13,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"io"
@@ -22,20 +22,21 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
-// AttachHandler handles json metadata output for attach events.
+// AttachHandler handles structured metadata output for attach events.
 type AttachHandler struct {
-	out  io.Writer
-	path string
-	root ocispec.Descriptor
+	out    io.Writer
+	encode Encoder
+	path   string
+	root   ocispec.Descriptor
 }
 
 // NewAttachHandler creates a new handler for attach events.
-func NewAttachHandler(out io.Writer) metadata.AttachHandler {
+func NewAttachHandler(out io.Writer, encode Encoder) metadata.AttachHandler {
 	return &AttachHandler{
-		out: out,
+		out:    out,
+		encode: encode,
 	}
 }
 
@@ -47,5 +48,5 @@ func (ah *AttachHandler) OnAttached(target *option.Target, root ocispec.Descript
 
 // Render is called when the attach command is completed.
 func (ah *AttachHandler) Render() error {
-	return output.PrintPrettyJSON(ah.out, model.NewAttach(ah.root, ah.path))
+	return ah.encode(ah.out, model.NewAttach(ah.root, ah.path))
 }
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestBackupHandler_Render_directory(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewBackupHandler(&buf, output.PrintPrettyJSON, "localhost:5000/hello")
+	if err := h.OnBackupCompleted(0, "hello", time.Second); err != nil {
+		t.Fatal("OnBackupCompleted() error =", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", buf.String(), err)
+	}
+	if _, ok := got["size"]; ok {
+		t.Errorf("size should be omitted for a directory: %s", buf.String())
+	}
+	if artifacts, ok := got["artifacts"].([]any); !ok || len(artifacts) != 0 {
+		t.Errorf("artifacts = %v, want an empty list", got["artifacts"])
+	}
+	if got["path"] != "hello" || got["repository"] != "localhost:5000/hello" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
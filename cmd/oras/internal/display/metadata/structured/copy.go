@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/stats"
+)
+
+// CopyHandler handles structured metadata output for cp events.
+type CopyHandler struct {
+	out     io.Writer
+	encode  Encoder
+	srcPath string
+	dstPath string
+	tagged  model.Tagged
+	root    ocispec.Descriptor
+	stats   *model.Stats
+}
+
+// NewCopyHandler returns a new handler for cp events.
+func NewCopyHandler(out io.Writer, encode Encoder) metadata.CopyHandler {
+	return &CopyHandler{
+		out:    out,
+		encode: encode,
+	}
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *CopyHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	h.tagged.AddTag(tag)
+	return nil
+}
+
+// OnCopied implements metadata.CopyHandler.
+func (h *CopyHandler) OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error {
+	if target.To.RawReference != "" && !contentutil.IsDigest(target.To.Reference) {
+		h.tagged.AddTag(target.To.Reference)
+	}
+	h.srcPath = target.From.Path
+	h.dstPath = target.To.Path
+	h.root = desc
+	return nil
+}
+
+// OnStats implements metadata.StatsHandler.
+func (h *CopyHandler) OnStats(summary stats.Summary) error {
+	h.stats = model.NewStats(&summary)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *CopyHandler) Render() error {
+	return h.encode(h.out, model.NewCopy(h.root, h.srcPath, h.dstPath, h.tagged.Tags(), h.stats))
+}
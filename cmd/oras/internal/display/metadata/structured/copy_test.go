@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestCopyHandler_Render(t *testing.T) {
+	content := []byte("test content")
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	target := &option.BinaryTarget{
+		From: option.Target{RawReference: "localhost:5000/src:v1", Path: "localhost:5000/src", Reference: "v1"},
+		To:   option.Target{RawReference: "localhost:6000/dst:v1", Path: "localhost:6000/dst", Reference: "v1"},
+	}
+
+	var buf bytes.Buffer
+	h := NewCopyHandler(&buf, output.PrintPrettyJSON)
+	if err := h.OnCopied(target, desc); err != nil {
+		t.Fatal("OnCopied() error =", err)
+	}
+	if err := h.OnTagged(desc, "v2"); err != nil {
+		t.Fatal("OnTagged() error =", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+
+	var got struct {
+		Reference       string `json:"reference"`
+		Digest          string `json:"digest"`
+		Source          struct{ Reference string }
+		ReferenceAsTags []string `json:"referenceAsTags"`
+		Stats           any      `json:"stats"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", buf.String(), err)
+	}
+	if want := "localhost:6000/dst@" + desc.Digest.String(); got.Reference != want {
+		t.Errorf("reference = %q, want %q", got.Reference, want)
+	}
+	if want := "localhost:5000/src@" + desc.Digest.String(); got.Source.Reference != want {
+		t.Errorf("source.reference = %q, want %q", got.Source.Reference, want)
+	}
+	if got.Digest != desc.Digest.String() {
+		t.Errorf("digest = %q, want %q", got.Digest, desc.Digest)
+	}
+	if want := []string{"localhost:6000/dst:v1", "localhost:6000/dst:v2"}; !reflect.DeepEqual(got.ReferenceAsTags, want) {
+		t.Errorf("referenceAsTags = %v, want %v", got.ReferenceAsTags, want)
+	}
+	if got.Stats != nil {
+		t.Errorf("stats = %v, want omitted", got.Stats)
+	}
+}
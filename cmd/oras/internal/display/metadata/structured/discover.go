@@ -13,7 +13,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"io"
@@ -21,22 +21,23 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
-// discoverHandler handles json metadata output for discover events.
+// discoverHandler handles structured metadata output for discover events.
 type discoverHandler struct {
-	out   io.Writer
-	path  string
-	model model.Discover
+	out    io.Writer
+	encode Encoder
+	path   string
+	model  model.Discover
 }
 
 // NewDiscoverHandler creates a new handler for discover events.
-func NewDiscoverHandler(out io.Writer, subject ocispec.Descriptor, path string) metadata.DiscoverHandler {
+func NewDiscoverHandler(out io.Writer, encode Encoder, subject ocispec.Descriptor, path string) metadata.DiscoverHandler {
 	return &discoverHandler{
-		out:   out,
-		path:  path,
-		model: model.NewDiscover(path, subject),
+		out:    out,
+		encode: encode,
+		path:   path,
+		model:  model.NewDiscover(path, subject),
 	}
 }
 
@@ -47,5 +48,5 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
-	return output.PrintPrettyJSON(h.out, h.model.Root)
+	return h.encode(h.out, h.model.Root)
 }
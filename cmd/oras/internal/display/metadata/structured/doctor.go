@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// DoctorHandler handles structured metadata output for doctor events.
+type DoctorHandler struct {
+	out    io.Writer
+	encode Encoder
+	doctor *model.Doctor
+}
+
+// NewDoctorHandler returns a new handler for doctor events.
+func NewDoctorHandler(out io.Writer, encode Encoder) metadata.DoctorHandler {
+	return &DoctorHandler{
+		out:    out,
+		encode: encode,
+	}
+}
+
+// OnDiagnosed implements metadata.DoctorHandler.
+func (h *DoctorHandler) OnDiagnosed(doctor *model.Doctor) error {
+	h.doctor = doctor
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *DoctorHandler) Render() error {
+	return h.encode(h.out, h.doctor)
+}
@@ -13,7 +13,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"encoding/json"
@@ -22,18 +22,19 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
-// manifestFetchHandler handles JSON metadata output for manifest fetch events.
+// manifestFetchHandler handles structured metadata output for manifest fetch events.
 type manifestFetchHandler struct {
-	out io.Writer
+	out    io.Writer
+	encode Encoder
 }
 
 // NewManifestFetchHandler creates a new handler for manifest fetch events.
-func NewManifestFetchHandler(out io.Writer) metadata.ManifestFetchHandler {
+func NewManifestFetchHandler(out io.Writer, encode Encoder) metadata.ManifestFetchHandler {
 	return &manifestFetchHandler{
-		out: out,
+		out:    out,
+		encode: encode,
 	}
 }
 
@@ -43,5 +44,5 @@ func (h *manifestFetchHandler) OnFetched(path string, desc ocispec.Descriptor, c
 	if err := json.Unmarshal(content, &manifest); err != nil {
 		manifest = nil
 	}
-	return output.PrintPrettyJSON(h.out, model.NewFetched(path, desc, manifest))
+	return h.encode(h.out, model.NewFetched(path, desc, manifest))
 }
@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+)
+
+// ManifestIndexCreateHandler handles structured metadata output for index create
+// and update events.
+type ManifestIndexCreateHandler struct {
+	out    io.Writer
+	encode Encoder
+	path   string
+	tagged model.Tagged
+	root   ocispec.Descriptor
+}
+
+// NewManifestIndexCreateHandler returns a new handler for index create and
+// update events. The reference of target is recorded as a tag if the index is
+// pushed.
+func NewManifestIndexCreateHandler(out io.Writer, encode Encoder, target *option.Target, pushed bool) metadata.ManifestIndexCreateHandler {
+	h := &ManifestIndexCreateHandler{
+		out:    out,
+		encode: encode,
+		path:   target.Path,
+	}
+	if pushed && target.Reference != "" && !contentutil.IsDigest(target.Reference) {
+		h.tagged.AddTag(target.Reference)
+	}
+	return h
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *ManifestIndexCreateHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	h.tagged.AddTag(tag)
+	return nil
+}
+
+// OnIndexCreated implements metadata.ManifestIndexCreateHandler.
+func (h *ManifestIndexCreateHandler) OnIndexCreated(desc ocispec.Descriptor) {
+	h.root = desc
+}
+
+// Render implements metadata.Renderer.
+func (h *ManifestIndexCreateHandler) Render() error {
+	return h.encode(h.out, model.NewIndex(h.root, h.path, h.tagged.Tags()))
+}
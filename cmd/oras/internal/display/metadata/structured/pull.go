@@ -13,7 +13,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"io"
@@ -22,22 +22,25 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
 )
 
-// PullHandler handles JSON metadata output for pull events.
+// PullHandler handles structured metadata output for pull events.
 type PullHandler struct {
 	path   string
 	pulled model.Pulled
 	out    io.Writer
+	encode Encoder
 	root   ocispec.Descriptor
+	stats  *model.Stats
 }
 
 // NewPullHandler returns a new handler for Pull events.
-func NewPullHandler(out io.Writer, path string) metadata.PullHandler {
+func NewPullHandler(out io.Writer, encode Encoder, path string) metadata.PullHandler {
 	return &PullHandler{
-		out:  out,
-		path: path,
+		out:    out,
+		encode: encode,
+		path:   path,
 	}
 }
 
@@ -46,6 +49,12 @@ func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	return nil
 }
 
+// OnFileSkipped implements metadata.PullHandler.
+func (ph *PullHandler) OnFileSkipped(name string, desc ocispec.Descriptor, descPath string) error {
+	ph.pulled.AddSkipped(name, desc, descPath)
+	return nil
+}
+
 // OnFilePulled implements metadata.PullHandler.
 func (ph *PullHandler) OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error {
 	return ph.pulled.Add(name, outputDir, desc, descPath)
@@ -56,7 +65,13 @@ func (ph *PullHandler) OnPulled(_ *option.Target, desc ocispec.Descriptor) {
 	ph.root = desc
 }
 
+// OnStats implements metadata.StatsHandler.
+func (ph *PullHandler) OnStats(summary stats.Summary) error {
+	ph.stats = model.NewStats(&summary)
+	return nil
+}
+
 // Render implements metadata.PullHandler.
 func (ph *PullHandler) Render() error {
-	return output.PrintPrettyJSON(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files()))
+	return ph.encode(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files(), ph.pulled.Skipped(), ph.stats))
 }
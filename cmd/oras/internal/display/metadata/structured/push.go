@@ -13,7 +13,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"io"
@@ -22,22 +22,25 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
-	"oras.land/oras/cmd/oras/internal/output"
 	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/stats"
 )
 
-// PushHandler handles JSON metadata output for push events.
+// PushHandler handles structured metadata output for push events.
 type PushHandler struct {
 	path   string
 	out    io.Writer
+	encode Encoder
 	tagged model.Tagged
 	root   ocispec.Descriptor
+	stats  *model.Stats
 }
 
 // NewPushHandler creates a new handler for push events.
-func NewPushHandler(out io.Writer) metadata.PushHandler {
+func NewPushHandler(out io.Writer, encode Encoder) metadata.PushHandler {
 	return &PushHandler{
-		out: out,
+		out:    out,
+		encode: encode,
 	}
 }
 
@@ -57,7 +60,13 @@ func (ph *PushHandler) OnCopied(opts *option.Target, root ocispec.Descriptor) er
 	return nil
 }
 
+// OnStats implements metadata.StatsHandler.
+func (ph *PushHandler) OnStats(summary stats.Summary) error {
+	ph.stats = model.NewStats(&summary)
+	return nil
+}
+
 // Render implements PushHandler.
 func (ph *PushHandler) Render() error {
-	return output.PrintPrettyJSON(ph.out, model.NewPush(ph.root, ph.path, ph.tagged.Tags()))
+	return ph.encode(ph.out, model.NewPush(ph.root, ph.path, ph.tagged.Tags(), ph.stats))
 }
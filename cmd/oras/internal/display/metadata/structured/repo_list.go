@@ -13,38 +13,39 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"io"
 
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
-// repoListHandler handles JSON metadata output for repo ls command.
+// repoListHandler handles structured metadata output for repo ls command.
 type repoListHandler struct {
-	out   io.Writer
-	model *model.Repositories
+	out    io.Writer
+	encode Encoder
+	model  *model.Repositories
 }
 
 // NewRepoListHandler creates a new handler for repo ls events.
-func NewRepoListHandler(out io.Writer, registry string) metadata.RepoListHandler {
+func NewRepoListHandler(out io.Writer, encode Encoder, registry string) metadata.RepoListHandler {
 	return &repoListHandler{
-		out:   out,
-		model: model.NewRepositories(registry),
+		out:    out,
+		encode: encode,
+		model:  model.NewRepositories(registry),
 	}
 }
 
 // OnRepositoryListed implements metadata.RepoListHandler.
 func (h *repoListHandler) OnRepositoryListed(repo string) error {
-	// For JSON format, show the full repository name
+	// For structured formats, show the full repository name
 	h.model.AddRepository(repo)
 	return nil
 }
 
 // Render implements metadata.RepoListHandler.
 func (h *repoListHandler) Render() error {
-	return output.PrintPrettyJSON(h.out, h.model)
+	return h.encode(h.out, h.model)
 }
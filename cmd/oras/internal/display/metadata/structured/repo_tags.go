@@ -13,27 +13,28 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package json
+package structured
 
 import (
 	"io"
 
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
-	"oras.land/oras/cmd/oras/internal/output"
 )
 
-// repoTagsHandler handles JSON metadata output for repo tags command.
+// repoTagsHandler handles structured metadata output for repo tags command.
 type repoTagsHandler struct {
-	out   io.Writer
-	model *model.Tags
+	out    io.Writer
+	encode Encoder
+	model  *model.Tags
 }
 
 // NewRepoTagsHandler creates a new handler for repo tags events.
-func NewRepoTagsHandler(out io.Writer) metadata.RepoTagsHandler {
+func NewRepoTagsHandler(out io.Writer, encode Encoder) metadata.RepoTagsHandler {
 	return &repoTagsHandler{
-		out:   out,
-		model: model.NewTags(),
+		out:    out,
+		encode: encode,
+		model:  model.NewTags(),
 	}
 }
 
@@ -45,5 +46,5 @@ func (h *repoTagsHandler) OnTagListed(tag string) error {
 
 // Render implements metadata.TagsHandler.
 func (h *repoTagsHandler) Render() error {
-	return output.PrintPrettyJSON(h.out, h.model)
+	return h.encode(h.out, h.model)
 }
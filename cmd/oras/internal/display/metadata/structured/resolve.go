@@ -0,0 +1,52 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// ResolveHandler handles structured metadata output for resolve events.
+type ResolveHandler struct {
+	out    io.Writer
+	encode Encoder
+	path   string
+	desc   ocispec.Descriptor
+}
+
+// NewResolveHandler returns a new handler for resolve events.
+func NewResolveHandler(out io.Writer, encode Encoder, path string) metadata.ResolveHandler {
+	return &ResolveHandler{
+		out:    out,
+		encode: encode,
+		path:   path,
+	}
+}
+
+// OnResolved implements metadata.ResolveHandler.
+func (h *ResolveHandler) OnResolved(desc ocispec.Descriptor) error {
+	h.desc = desc
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *ResolveHandler) Render() error {
+	return h.encode(h.out, model.NewResolve(h.desc, h.path))
+}
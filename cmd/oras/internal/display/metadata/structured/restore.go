@@ -0,0 +1,75 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"io"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// RestoreHandler handles structured metadata output for restore events.
+type RestoreHandler struct {
+	out       io.Writer
+	encode    Encoder
+	path      string
+	dryRun    bool
+	repo      string
+	size      int64
+	artifacts []model.TaggedArtifact
+	duration  time.Duration
+}
+
+// NewRestoreHandler returns a new handler for restore events.
+func NewRestoreHandler(out io.Writer, encode Encoder, path string, dryRun bool) metadata.RestoreHandler {
+	return &RestoreHandler{
+		out:    out,
+		encode: encode,
+		path:   path,
+		dryRun: dryRun,
+	}
+}
+
+// OnTarLoaded implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnTarLoaded(_ string, size int64) error {
+	h.size = size
+	return nil
+}
+
+// OnTagsFound implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnTagsFound(_ []string) error {
+	return nil
+}
+
+// OnArtifactPushed implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnArtifactPushed(tag string, referrerCount int) error {
+	h.artifacts = append(h.artifacts, model.TaggedArtifact{Tag: tag, ReferrerCount: referrerCount})
+	return nil
+}
+
+// OnRestoreCompleted implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnRestoreCompleted(_ int, repo string, duration time.Duration) error {
+	h.repo = repo
+	h.duration = duration
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *RestoreHandler) Render() error {
+	return h.encode(h.out, model.NewRestore(h.repo, h.path, h.size, h.dryRun, h.artifacts, h.duration))
+}
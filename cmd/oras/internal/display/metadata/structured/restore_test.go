@@ -0,0 +1,72 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestRestoreHandler_Render(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewRestoreHandler(&buf, output.PrintPrettyJSON, "hello.tar", true)
+	if err := h.OnTarLoaded("hello.tar", 1024); err != nil {
+		t.Fatal("OnTarLoaded() error =", err)
+	}
+	if err := h.OnTagsFound([]string{"v1", "v2"}); err != nil {
+		t.Fatal("OnTagsFound() error =", err)
+	}
+	for i, tag := range []string{"v1", "v2"} {
+		if err := h.OnArtifactPushed(tag, i); err != nil {
+			t.Fatal("OnArtifactPushed() error =", err)
+		}
+	}
+	if err := h.OnRestoreCompleted(2, "localhost:5000/hello", 1500*time.Millisecond); err != nil {
+		t.Fatal("OnRestoreCompleted() error =", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+
+	want := `{
+  "repository": "localhost:5000/hello",
+  "path": "hello.tar",
+  "size": 1024,
+  "dryRun": true,
+  "artifacts": [
+    {
+      "tag": "v1",
+      "referrerCount": 0
+    },
+    {
+      "tag": "v2",
+      "referrerCount": 1
+    }
+  ],
+  "elapsedSeconds": 1.5
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %s, want %s", got, want)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Error("Render() output is not valid JSON")
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structured
+
+import (
+	"io"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// TagHandler handles structured metadata output for tag events.
+type TagHandler struct {
+	out      io.Writer
+	encode   Encoder
+	path     string
+	tagged   model.Tagged
+	rootOnce sync.Once
+	root     ocispec.Descriptor
+}
+
+// NewTagHandler returns a new handler for tag events.
+func NewTagHandler(out io.Writer, encode Encoder, path string) metadata.TagHandler {
+	return &TagHandler{
+		out:    out,
+		encode: encode,
+		path:   path,
+	}
+}
+
+// OnTagging implements metadata.TagHandler.
+func (h *TagHandler) OnTagging(desc ocispec.Descriptor, _ string) error {
+	h.rootOnce.Do(func() {
+		h.root = desc
+	})
+	return nil
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *TagHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	h.tagged.AddTag(tag)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *TagHandler) Render() error {
+	return h.encode(h.out, model.NewTag(h.root, h.path, h.tagged.Tags()))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
+)
+
+// BackupHandler handles go-template metadata output for backup events.
+type BackupHandler struct {
+	out       io.Writer
+	template  string
+	repo      string
+	path      string
+	size      int64
+	artifacts []model.TaggedArtifact
+	duration  time.Duration
+	stats     *model.Stats
+}
+
+// NewBackupHandler returns a new handler for backup events.
+func NewBackupHandler(out io.Writer, template string, repo string) metadata.BackupHandler {
+	return &BackupHandler{
+		out:      out,
+		template: template,
+		repo:     repo,
+	}
+}
+
+// OnTagsFound implements metadata.BackupHandler.
+func (h *BackupHandler) OnTagsFound(_ []string) error {
+	return nil
+}
+
+// OnArtifactPulled implements metadata.BackupHandler.
+func (h *BackupHandler) OnArtifactPulled(tag string, referrerCount int) error {
+	h.artifacts = append(h.artifacts, model.TaggedArtifact{Tag: tag, ReferrerCount: referrerCount})
+	return nil
+}
+
+// OnTarExporting implements metadata.BackupHandler.
+func (h *BackupHandler) OnTarExporting(_ string) error {
+	return nil
+}
+
+// OnTarExported implements metadata.BackupHandler.
+func (h *BackupHandler) OnTarExported(_ string, size int64) error {
+	h.size = size
+	return nil
+}
+
+// OnBackupCompleted implements metadata.BackupHandler.
+func (h *BackupHandler) OnBackupCompleted(_ int, path string, duration time.Duration) error {
+	h.path = path
+	h.duration = duration
+	return nil
+}
+
+// OnStats implements metadata.StatsHandler.
+func (h *BackupHandler) OnStats(summary stats.Summary) error {
+	h.stats = model.NewStats(&summary)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *BackupHandler) Render() error {
+	return output.ParseAndWrite(h.out, model.NewBackup(h.repo, h.path, h.size, h.artifacts, h.duration, h.stats), h.template)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// InspectHandler handles go-template metadata output for inspect events.
+type InspectHandler struct {
+	out       io.Writer
+	template  string
+	inspected *model.Inspect
+}
+
+// NewInspectHandler returns a new handler for inspect events.
+func NewInspectHandler(out io.Writer, template string) metadata.InspectHandler {
+	return &InspectHandler{
+		out:      out,
+		template: template,
+	}
+}
+
+// OnInspected implements metadata.InspectHandler.
+func (h *InspectHandler) OnInspected(inspected *model.Inspect) error {
+	h.inspected = inspected
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *InspectHandler) Render() error {
+	return output.ParseAndWrite(h.out, h.inspected, h.template)
+}
@@ -23,6 +23,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
 )
 
 // PullHandler handles text metadata output for pull events.
@@ -32,6 +33,7 @@ type PullHandler struct {
 	out      io.Writer
 	pulled   model.Pulled
 	root     ocispec.Descriptor
+	stats    *model.Stats
 }
 
 // NewPullHandler returns a new handler for pull events.
@@ -48,9 +50,21 @@ func (ph *PullHandler) OnPulled(_ *option.Target, desc ocispec.Descriptor) {
 	ph.root = desc
 }
 
+// OnStats implements metadata.StatsHandler.
+func (ph *PullHandler) OnStats(summary stats.Summary) error {
+	ph.stats = model.NewStats(&summary)
+	return nil
+}
+
 // Render implements metadata.PullHandler.
 func (ph *PullHandler) Render() error {
-	return output.ParseAndWrite(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files()), ph.template)
+	return output.ParseAndWrite(ph.out, model.NewPull(ph.path+"@"+ph.root.Digest.String(), ph.pulled.Files(), ph.pulled.Skipped(), ph.stats), ph.template)
+}
+
+// OnFileSkipped implements metadata.PullHandler.
+func (ph *PullHandler) OnFileSkipped(name string, desc ocispec.Descriptor, descPath string) error {
+	ph.pulled.AddSkipped(name, desc, descPath)
+	return nil
 }
 
 // OnFilePulled implements metadata.PullHandler.
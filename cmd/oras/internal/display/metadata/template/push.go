@@ -24,6 +24,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
 	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/stats"
 )
 
 // PushHandler handles go-template metadata output for push events.
@@ -33,6 +34,7 @@ type PushHandler struct {
 	tagged   model.Tagged
 	out      io.Writer
 	root     ocispec.Descriptor
+	stats    *model.Stats
 }
 
 // NewPushHandler returns a new handler for push events.
@@ -59,7 +61,13 @@ func (ph *PushHandler) OnCopied(opts *option.Target, root ocispec.Descriptor) er
 	return nil
 }
 
+// OnStats implements metadata.StatsHandler.
+func (ph *PushHandler) OnStats(summary stats.Summary) error {
+	ph.stats = model.NewStats(&summary)
+	return nil
+}
+
 // Render implements PushHandler.
 func (ph *PushHandler) Render() error {
-	return output.ParseAndWrite(ph.out, model.NewPush(ph.root, ph.path, ph.tagged.Tags()), ph.template)
+	return output.ParseAndWrite(ph.out, model.NewPush(ph.root, ph.path, ph.tagged.Tags(), ph.stats), ph.template)
 }
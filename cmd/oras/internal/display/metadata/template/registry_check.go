@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// RegistryCheckHandler handles go-template metadata output for registry check events.
+type RegistryCheckHandler struct {
+	out      io.Writer
+	template string
+	check    *model.RegistryCheck
+}
+
+// NewRegistryCheckHandler returns a new handler for registry check events.
+func NewRegistryCheckHandler(out io.Writer, template string) metadata.RegistryCheckHandler {
+	return &RegistryCheckHandler{
+		out:      out,
+		template: template,
+	}
+}
+
+// OnRegistryChecked implements metadata.RegistryCheckHandler.
+func (h *RegistryCheckHandler) OnRegistryChecked(check *model.RegistryCheck) error {
+	h.check = check
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *RegistryCheckHandler) Render() error {
+	return output.ParseAndWrite(h.out, h.check, h.template)
+}
@@ -22,6 +22,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
 )
 
 // BackupHandler handles text metadata output for backup events.
@@ -80,6 +81,11 @@ func (bh *BackupHandler) OnTagsFound(tags []string) error {
 	return nil
 }
 
+// OnStats implements metadata.StatsHandler.
+func (bh *BackupHandler) OnStats(summary stats.Summary) error {
+	return printStats(bh.printer, &summary)
+}
+
 // Render implements metadata.BackupHandler.
 func (bh *BackupHandler) Render() error {
 	return nil
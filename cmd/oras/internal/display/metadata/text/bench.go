@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// BenchHandler handles text metadata output for bench events.
+type BenchHandler struct {
+	printer *output.Printer
+	bench   *model.Bench
+}
+
+// NewBenchHandler returns a new handler for bench events.
+func NewBenchHandler(printer *output.Printer) metadata.BenchHandler {
+	return &BenchHandler{
+		printer: printer,
+	}
+}
+
+// OnBenchmarked implements metadata.BenchHandler.
+func (h *BenchHandler) OnBenchmarked(bench *model.Bench) error {
+	h.bench = bench
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *BenchHandler) Render() error {
+	if err := h.printer.Printf("Benchmarked %s\n\n", h.bench.Repository); err != nil {
+		return err
+	}
+
+	w := newTableWriter(h.printer)
+	fmt.Fprintln(w, "SIZE\tCOUNT\tCONCURRENCY\tPUSH\tPULL")
+	for _, run := range h.bench.Runs {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", humanize.ToBytes(run.Size), run.Count, run.Concurrency, formatThroughput(run.Push), formatThroughput(run.Pull))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if err := h.printer.Println(); err != nil {
+		return err
+	}
+	w = newTableWriter(h.printer)
+	fmt.Fprintln(w, "SIZE\tCONCURRENCY\tREQUEST\tCOUNT\tP50\tP90\tP99\tMAX")
+	for _, run := range h.bench.Runs {
+		for _, l := range run.Latencies {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%.1fms\t%.1fms\t%.1fms\t%.1fms\n", humanize.ToBytes(run.Size), run.Concurrency, l.Kind, l.Count, l.P50, l.P90, l.P99, l.Max)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if err := h.printer.Printf("\nToken fetches: %d (%.1fms in total)\n", h.bench.TokenFetches.Count, h.bench.TokenFetches.TotalMs); err != nil {
+		return err
+	}
+	for _, cleanupErr := range h.bench.CleanupErrors {
+		if err := h.printer.Printf("Cleanup failed: %s\n", cleanupErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatThroughput returns the throughput in bytes per second.
+func formatThroughput(t model.Throughput) string {
+	return humanize.ToBytes(int64(t.BytesPerSecond)).String() + "/s"
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestBenchHandler_Render(t *testing.T) {
+	bench := model.NewBench("localhost:5000/bench")
+	bench.Runs = append(bench.Runs, model.BenchRun{
+		Size:        1 << 20,
+		Count:       4,
+		Concurrency: 2,
+		Push:        model.NewThroughput(4<<20, 2*time.Second),
+		Pull:        model.NewThroughput(4<<20, time.Second),
+		Latencies: []model.Latency{
+			model.NewLatency("GET", 4, 10*time.Millisecond, 12*time.Millisecond, 15*time.Millisecond, 15*time.Millisecond),
+			model.NewLatency("HEAD", 4, time.Millisecond, 2*time.Millisecond, 2500*time.Microsecond, 2500*time.Microsecond),
+		},
+	})
+	bench.AddTokenFetches(2, 40*time.Millisecond)
+	bench.CleanupErrors = []string{"sha256:a: unsupported"}
+
+	var buf bytes.Buffer
+	handler := NewBenchHandler(output.NewPrinter(&buf, &buf))
+	if err := handler.OnBenchmarked(bench); err != nil {
+		t.Fatal("OnBenchmarked() error =", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `Benchmarked localhost:5000/bench
+
+SIZE  COUNT  CONCURRENCY  PUSH    PULL
+1 MB  4      2            2 MB/s  4 MB/s
+
+SIZE  CONCURRENCY  REQUEST  COUNT  P50     P90     P99     MAX
+1 MB  2            GET      4      10.0ms  12.0ms  15.0ms  15.0ms
+1 MB  2            HEAD     4      1.0ms   2.0ms   2.5ms   2.5ms
+
+Token fetches: 2 (40.0ms in total)
+Cleanup failed: sha256:a: unsupported
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
 )
 
 // CopyHandler handles text metadata output for cp events.
 type CopyHandler struct {
 	printer *output.Printer
 	desc    ocispec.Descriptor
+	stats   *stats.Summary
 }
 
 // NewCopyHandler returns a new handler for cp events.
@@ -40,9 +42,18 @@ func (h *CopyHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
 	return h.printer.Println("Tagged", tag)
 }
 
+// OnStats implements metadata.StatsHandler.
+func (h *CopyHandler) OnStats(summary stats.Summary) error {
+	h.stats = &summary
+	return nil
+}
+
 // Render implements metadata.Renderer.
 func (h *CopyHandler) Render() error {
-	return h.printer.Println("Digest:", h.desc.Digest)
+	if err := h.printer.Println("Digest:", h.desc.Digest); err != nil {
+		return err
+	}
+	return printStats(h.printer, h.stats)
 }
 
 // OnCopied implements metadata.CopyHandler.
@@ -0,0 +1,145 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// changeSymbols maps the kind of a change to its symbol.
+var changeSymbols = map[string]string{
+	model.ChangeAdded:   "+",
+	model.ChangeRemoved: "-",
+	model.ChangeChanged: "~",
+}
+
+// DiffHandler handles text metadata output for diff events.
+type DiffHandler struct {
+	printer *output.Printer
+	diff    *model.Diff
+}
+
+// NewDiffHandler returns a new handler for diff events.
+func NewDiffHandler(printer *output.Printer) metadata.DiffHandler {
+	return &DiffHandler{
+		printer: printer,
+	}
+}
+
+// OnDiffed implements metadata.DiffHandler.
+func (h *DiffHandler) OnDiffed(diff *model.Diff) error {
+	h.diff = diff
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *DiffHandler) Render() error {
+	d := h.diff
+	if err := h.printer.Println("---", d.From.Reference); err != nil {
+		return err
+	}
+	if err := h.printer.Println("+++", d.To.Reference); err != nil {
+		return err
+	}
+	if d.Identical {
+		return h.printer.Println("Artifacts are identical")
+	}
+	if !d.HasChanges() {
+		return h.printer.Println("No differences found")
+	}
+
+	w := newTableWriter(h.printer)
+	for _, field := range []struct {
+		name   string
+		change *model.Change
+	}{
+		{"Media type:", d.MediaType},
+		{"Artifact type:", d.ArtifactType},
+		{"Config:", d.Config},
+		{"Subject:", d.Subject},
+	} {
+		if field.change != nil {
+			fmt.Fprintf(w, "%s %s\t%s\n", changeSymbols[field.change.Kind], field.name, formatChange(*field.change))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, section := range []struct {
+		name    string
+		changes []model.Change
+	}{
+		{"Layers:", d.Layers},
+		{"Annotations:", d.Annotations},
+		{"Platforms:", d.Platforms},
+	} {
+		if err := h.printChanges(section.name, section.changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *DiffHandler) printChanges(name string, changes []model.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := h.printer.Println(name); err != nil {
+		return err
+	}
+	w := newTableWriter(h.printer)
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %s %s\t%s\n", changeSymbols[c.Kind], c.Key, formatChange(c))
+	}
+	return w.Flush()
+}
+
+// formatChange formats the values of a change, e.g. "a -> b".
+func formatChange(c model.Change) string {
+	switch c.Kind {
+	case model.ChangeAdded:
+		return formatValue(c.To)
+	case model.ChangeRemoved:
+		return formatValue(c.From)
+	default:
+		from, fromDesc := c.From.(*ocispec.Descriptor)
+		to, toDesc := c.To.(*ocispec.Descriptor)
+		if fromDesc && toDesc && content.Equal(*from, *to) {
+			// the content is the same but the annotations, artifact type or
+			// platform differ
+			return formatValue(c.From) + ", metadata changed"
+		}
+		return formatValue(c.From) + " -> " + formatValue(c.To)
+	}
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case *ocispec.Descriptor:
+		return fmt.Sprintf("%s (%s)", v.Digest, humanize.ToBytes(v.Size))
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
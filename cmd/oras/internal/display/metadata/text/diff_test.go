@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestDiffHandler_Render(t *testing.T) {
+	layer := &ocispec.Descriptor{Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111", Size: 6}
+	annotatedLayer := *layer
+	annotatedLayer.Annotations = map[string]string{"foo": "bar"}
+	tests := []struct {
+		name string
+		diff *model.Diff
+		want string
+	}{
+		{
+			name: "identical",
+			diff: &model.Diff{
+				From:      model.FromDescriptor("a", ocispec.Descriptor{Digest: "sha256:aa"}),
+				To:        model.FromDescriptor("b", ocispec.Descriptor{Digest: "sha256:aa"}),
+				Identical: true,
+			},
+			want: "--- a@sha256:aa\n+++ b@sha256:aa\nArtifacts are identical\n",
+		},
+		{
+			name: "no changes",
+			diff: &model.Diff{
+				From: model.FromDescriptor("a", ocispec.Descriptor{Digest: "sha256:aa"}),
+				To:   model.FromDescriptor("b", ocispec.Descriptor{Digest: "sha256:bb"}),
+			},
+			want: "--- a@sha256:aa\n+++ b@sha256:bb\nNo differences found\n",
+		},
+		{
+			name: "changes",
+			diff: &model.Diff{
+				From:         model.FromDescriptor("a", ocispec.Descriptor{Digest: "sha256:aa"}),
+				To:           model.FromDescriptor("b", ocispec.Descriptor{Digest: "sha256:bb"}),
+				ArtifactType: &model.Change{Kind: model.ChangeChanged, From: "x", To: "y"},
+				Layers: []model.Change{
+					{Kind: model.ChangeRemoved, Key: "a.txt", From: layer},
+					{Kind: model.ChangeChanged, Key: "b.txt", From: layer, To: &annotatedLayer},
+				},
+				Annotations: []model.Change{
+					{Kind: model.ChangeAdded, Key: "foo", To: "bar"},
+				},
+			},
+			want: `--- a@sha256:aa
++++ b@sha256:bb
+~ Artifact type:  "x" -> "y"
+Layers:
+  - a.txt  sha256:1111111111111111111111111111111111111111111111111111111111111111 (6  B)
+  ~ b.txt  sha256:1111111111111111111111111111111111111111111111111111111111111111 (6  B), metadata changed
+Annotations:
+  + foo  "bar"
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewDiffHandler(output.NewPrinter(&buf, &buf))
+			if err := handler.OnDiffed(tt.diff); err != nil {
+				t.Fatal("OnDiffed() error =", err)
+			}
+			if err := handler.Render(); err != nil {
+				t.Fatal("Render() error =", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// DoctorHandler handles text metadata output for doctor events.
+type DoctorHandler struct {
+	printer *output.Printer
+	doctor  *model.Doctor
+}
+
+// NewDoctorHandler returns a new handler for doctor events.
+func NewDoctorHandler(printer *output.Printer) metadata.DoctorHandler {
+	return &DoctorHandler{
+		printer: printer,
+	}
+}
+
+// OnDiagnosed implements metadata.DoctorHandler.
+func (h *DoctorHandler) OnDiagnosed(doctor *model.Doctor) error {
+	h.doctor = doctor
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *DoctorHandler) Render() error {
+	w := newTableWriter(h.printer)
+	for _, check := range h.doctor.Checks {
+		fmt.Fprintf(w, "[%s]\t%s:\t%s\n", check.Status, check.Name, check.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return h.printer.Printf("\n%d passed, %d warning(s), %d failed\n",
+		h.doctor.Count(model.CheckPass), h.doctor.Count(model.CheckWarn), h.doctor.Count(model.CheckFail))
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestDoctorHandler_Render(t *testing.T) {
+	doctor := model.NewDoctor("localhost:5000")
+	doctor.Add("Version", model.CheckPass, "1.3.0")
+	doctor.Add("Credential helpers", model.CheckFail, "docker-credential-pass not found in PATH")
+	doctor.Add("Clock skew", model.CheckWarn, "2m0s behind localhost:5000")
+
+	var buf bytes.Buffer
+	handler := NewDoctorHandler(output.NewPrinter(&buf, &buf))
+	if err := handler.OnDiagnosed(doctor); err != nil {
+		t.Fatal("OnDiagnosed() error =", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `[pass]  Version:             1.3.0
+[fail]  Credential helpers:  docker-credential-pass not found in PATH
+[warn]  Clock skew:          2m0s behind localhost:5000
+
+1 passed, 1 warning(s), 1 failed
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
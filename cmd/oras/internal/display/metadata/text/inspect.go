@@ -0,0 +1,166 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/descriptor"
+)
+
+// InspectHandler handles text metadata output for inspect events.
+type InspectHandler struct {
+	printer   *output.Printer
+	inspected *model.Inspect
+}
+
+// NewInspectHandler returns a new handler for inspect events.
+func NewInspectHandler(printer *output.Printer) metadata.InspectHandler {
+	return &InspectHandler{
+		printer: printer,
+	}
+}
+
+// OnInspected implements metadata.InspectHandler.
+func (h *InspectHandler) OnInspected(inspected *model.Inspect) error {
+	h.inspected = inspected
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *InspectHandler) Render() error {
+	i := h.inspected
+	w := newTableWriter(h.printer)
+	fmt.Fprintf(w, "Reference:\t%s\n", i.Reference)
+	fmt.Fprintf(w, "Media type:\t%s\n", i.MediaType)
+	if i.ArtifactType != "" {
+		fmt.Fprintf(w, "Artifact type:\t%s\n", i.ArtifactType)
+	}
+	fmt.Fprintf(w, "Digest:\t%s\n", i.Digest)
+	fmt.Fprintf(w, "Size:\t%s (total %s)\n", humanize.ToBytes(i.Size), humanize.ToBytes(i.TotalSize))
+	if i.Subject != nil {
+		fmt.Fprintf(w, "Subject:\t%s\n", i.Subject.Digest)
+	}
+	if c := i.Config; c != nil {
+		fmt.Fprintf(w, "Config:\t%s %s (%s)\n", c.MediaType, c.Digest, humanize.ToBytes(c.Size))
+		if c.Platform != nil {
+			fmt.Fprintf(w, "  Platform:\t%s\n", descriptor.PlatformString(c.Platform))
+		}
+		if c.Created != "" {
+			fmt.Fprintf(w, "  Created:\t%s\n", c.Created)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !i.IsIndex() {
+		if err := h.printLayers(); err != nil {
+			return err
+		}
+	} else if err := h.printPlatforms(); err != nil {
+		return err
+	}
+	if err := h.printAnnotations(); err != nil {
+		return err
+	}
+	return h.printReferrers()
+}
+
+func (h *InspectHandler) printLayers() error {
+	if err := h.printer.Printf("\nLayers (%d):\n", len(h.inspected.Layers)); err != nil {
+		return err
+	}
+	if len(h.inspected.Layers) == 0 {
+		return nil
+	}
+	w := newTableWriter(h.printer)
+	fmt.Fprintln(w, "  TITLE\tMEDIA TYPE\tSIZE")
+	for _, layer := range h.inspected.Layers {
+		title := layer.Title
+		if title == "" {
+			title = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", title, layer.MediaType, humanize.ToBytes(layer.Size))
+	}
+	return w.Flush()
+}
+
+func (h *InspectHandler) printPlatforms() error {
+	if err := h.printer.Printf("\nManifests (%d):\n", len(h.inspected.Manifests)); err != nil {
+		return err
+	}
+	if len(h.inspected.Manifests) == 0 {
+		return nil
+	}
+	w := newTableWriter(h.printer)
+	fmt.Fprintln(w, "  PLATFORM\tDIGEST\tMEDIA TYPE\tSIZE")
+	for _, m := range h.inspected.Manifests {
+		platform := "-"
+		if m.Platform != nil {
+			platform = descriptor.PlatformString(m.Platform)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", platform, m.Digest, m.MediaType, humanize.ToBytes(m.Size))
+	}
+	return w.Flush()
+}
+
+func (h *InspectHandler) printAnnotations() error {
+	annotations := h.inspected.Annotations
+	if len(annotations) == 0 {
+		return nil
+	}
+	if err := h.printer.Println("\nAnnotations:"); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	w := newTableWriter(h.printer)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %s:\t%s\n", k, annotations[k])
+	}
+	return w.Flush()
+}
+
+func (h *InspectHandler) printReferrers() error {
+	referrers := h.inspected.Referrers
+	if err := h.printer.Printf("\nReferrers (%d):\n", referrers.Count); err != nil {
+		return err
+	}
+	w := newTableWriter(h.printer)
+	for _, artifactType := range h.inspected.ReferrerArtifactTypes() {
+		name := artifactType
+		if name == "" {
+			name = "<unknown>"
+		}
+		fmt.Fprintf(w, "  %s\t%d\n", name, referrers.ArtifactTypes[artifactType])
+	}
+	return w.Flush()
+}
+
+func newTableWriter(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+}
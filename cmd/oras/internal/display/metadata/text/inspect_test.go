@@ -0,0 +1,146 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestInspectHandler_Render(t *testing.T) {
+	manifest := []byte(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {"mediaType": "application/vnd.oci.image.config.v1+json", "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", "size": 100},
+  "layers": [
+    {"mediaType": "application/vnd.oci.image.layer.v1.tar", "digest": "sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03", "size": 6, "annotations": {"org.opencontainers.image.title": "a.txt"}},
+    {"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": "sha256:e258d248fda94c63753607f7c4494ee0fcbe92f1a76bfdac795c9d84101eb317", "size": 2048}
+  ],
+  "annotations": {"foo": "bar"}
+}`)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	inspected, err := model.NewInspect("localhost:5000/test", desc, manifest)
+	if err != nil {
+		t.Fatal("NewInspect() error =", err)
+	}
+	if !inspected.IsImageConfig() {
+		t.Fatal("IsImageConfig() = false, want true")
+	}
+	if err := inspected.SetImageConfig([]byte(`{"os":"linux","architecture":"arm64","variant":"v8","created":"2024-01-02T03:04:05Z"}`)); err != nil {
+		t.Fatal("SetImageConfig() error =", err)
+	}
+	inspected.AddReferrer(ocispec.Descriptor{ArtifactType: "application/vnd.sig"})
+	inspected.AddReferrer(ocispec.Descriptor{ArtifactType: "application/vnd.sbom"})
+	inspected.AddReferrer(ocispec.Descriptor{ArtifactType: "application/vnd.sig"})
+
+	var buf bytes.Buffer
+	handler := NewInspectHandler(output.NewPrinter(&buf, &buf))
+	if err := handler.OnInspected(inspected); err != nil {
+		t.Fatal("OnInspected() error =", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `Reference:      localhost:5000/test@` + desc.Digest.String() + `
+Media type:     application/vnd.oci.image.manifest.v1+json
+Artifact type:  application/vnd.oci.image.config.v1+json
+Digest:         ` + desc.Digest.String() + `
+Size:           ` + inspectSize(desc.Size) + ` (total ` + inspectSize(desc.Size+100+6+2048) + `)
+Config:         application/vnd.oci.image.config.v1+json sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a (100  B)
+  Platform:     linux/arm64/v8
+  Created:      2024-01-02T03:04:05Z
+
+Layers (2):
+  TITLE  MEDIA TYPE                                   SIZE
+  a.txt  application/vnd.oci.image.layer.v1.tar       6  B
+  -      application/vnd.oci.image.layer.v1.tar+gzip  2 KB
+
+Annotations:
+  foo:  bar
+
+Referrers (3):
+  application/vnd.sbom  1
+  application/vnd.sig   2
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInspectHandler_Render_index(t *testing.T) {
+	index := []byte(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03", "size": 500, "platform": {"os": "linux", "architecture": "amd64"}}
+  ]
+}`)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromBytes(index),
+		Size:      int64(len(index)),
+	}
+	inspected, err := model.NewInspect("localhost:5000/test", desc, index)
+	if err != nil {
+		t.Fatal("NewInspect() error =", err)
+	}
+	if !inspected.IsIndex() || inspected.IsImageConfig() {
+		t.Fatalf("IsIndex() = %v, IsImageConfig() = %v", inspected.IsIndex(), inspected.IsImageConfig())
+	}
+
+	var buf bytes.Buffer
+	handler := NewInspectHandler(output.NewPrinter(&buf, &buf))
+	if err := handler.OnInspected(inspected); err != nil {
+		t.Fatal("OnInspected() error =", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `Reference:   localhost:5000/test@` + desc.Digest.String() + `
+Media type:  application/vnd.oci.image.index.v1+json
+Digest:      ` + desc.Digest.String() + `
+Size:        ` + inspectSize(desc.Size) + ` (total ` + inspectSize(desc.Size+500) + `)
+
+Manifests (1):
+  PLATFORM     DIGEST                                                                   MEDIA TYPE                                  SIZE
+  linux/amd64  sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03  application/vnd.oci.image.manifest.v1+json  500  B
+
+Referrers (0):
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNewInspect_invalid(t *testing.T) {
+	if _, err := model.NewInspect("localhost:5000/test", ocispec.Descriptor{}, []byte("{")); err == nil {
+		t.Error("NewInspect() error = nil, want error")
+	}
+}
+
+func inspectSize(size int64) string {
+	return humanize.ToBytes(size).String()
+}
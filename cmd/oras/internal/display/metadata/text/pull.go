@@ -22,14 +22,17 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
 )
 
 // PullHandler handles text metadata output for pull events.
 type PullHandler struct {
 	printer      *output.Printer
 	layerSkipped atomic.Bool
+	filesSkipped atomic.Int64
 	target       *option.Target
 	root         ocispec.Descriptor
+	stats        *stats.Summary
 }
 
 // NewPullHandler returns a new handler for Pull events.
@@ -43,6 +46,12 @@ func (ph *PullHandler) OnFilePulled(_ string, _ string, _ ocispec.Descriptor, _
 	return nil
 }
 
+// OnFileSkipped implements metadata.PullHandler.
+func (ph *PullHandler) OnFileSkipped(_ string, _ ocispec.Descriptor, _ string) error {
+	ph.filesSkipped.Add(1)
+	return nil
+}
+
 // OnLayerSkipped implements metadata.PullHandler.
 func (ph *PullHandler) OnLayerSkipped(ocispec.Descriptor) error {
 	ph.layerSkipped.Store(true)
@@ -55,6 +64,12 @@ func (ph *PullHandler) OnPulled(target *option.Target, desc ocispec.Descriptor)
 	ph.root = desc
 }
 
+// OnStats implements metadata.StatsHandler.
+func (ph *PullHandler) OnStats(summary stats.Summary) error {
+	ph.stats = &summary
+	return nil
+}
+
 // Render implements metadata.PullHandler.
 func (ph *PullHandler) Render() error {
 	if ph.layerSkipped.Load() {
@@ -64,5 +79,8 @@ func (ph *PullHandler) Render() error {
 		_ = ph.printer.Println("Pulled", ph.target.GetDisplayReference())
 		_ = ph.printer.Println("Digest:", ph.root.Digest)
 	}
-	return nil
+	if n := ph.filesSkipped.Load(); n > 0 {
+		_ = ph.printer.Printf("Skipped %d file(s) not selected by the filters\n", n)
+	}
+	return printStats(ph.printer, ph.stats)
 }
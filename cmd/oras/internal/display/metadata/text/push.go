@@ -22,6 +22,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/stats"
 )
 
 // PushHandler handles text metadata output for push events.
@@ -29,6 +30,7 @@ type PushHandler struct {
 	printer *output.Printer
 	tagLock sync.Mutex
 	root    ocispec.Descriptor
+	stats   *stats.Summary
 }
 
 // NewPushHandler returns a new handler for push events.
@@ -51,11 +53,20 @@ func (h *PushHandler) OnCopied(opts *option.Target, root ocispec.Descriptor) err
 	return h.printer.Println("Pushed", opts.GetDisplayReference())
 }
 
+// OnStats implements metadata.StatsHandler.
+func (h *PushHandler) OnStats(summary stats.Summary) error {
+	h.stats = &summary
+	return nil
+}
+
 // Render implements PushHandler.
 func (h *PushHandler) Render() error {
 	err := h.printer.Println("ArtifactType:", h.root.ArtifactType)
 	if err != nil {
 		return err
 	}
-	return h.printer.Println("Digest:", h.root.Digest)
+	if err := h.printer.Println("Digest:", h.root.Digest); err != nil {
+		return err
+	}
+	return printStats(h.printer, h.stats)
 }
@@ -0,0 +1,110 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// capabilityNames maps the registry capabilities to their display names.
+var capabilityNames = map[string]string{
+	model.CapabilityReferrersAPI:      "Referrers API",
+	model.CapabilityArtifactType:      "OCI 1.1 artifactType",
+	model.CapabilityMount:             "Cross-repository mount",
+	model.CapabilityChunkedUpload:     "Chunked upload",
+	model.CapabilityManifestDelete:    "Manifest delete",
+	model.CapabilityBlobDelete:        "Blob delete",
+	model.CapabilityTagListPagination: "Tag list pagination",
+	model.CapabilityCatalog:           "Catalog",
+}
+
+// RegistryCheckHandler handles text metadata output for registry check events.
+type RegistryCheckHandler struct {
+	printer *output.Printer
+	check   *model.RegistryCheck
+}
+
+// NewRegistryCheckHandler returns a new handler for registry check events.
+func NewRegistryCheckHandler(printer *output.Printer) metadata.RegistryCheckHandler {
+	return &RegistryCheckHandler{
+		printer: printer,
+	}
+}
+
+// OnRegistryChecked implements metadata.RegistryCheckHandler.
+func (h *RegistryCheckHandler) OnRegistryChecked(check *model.RegistryCheck) error {
+	h.check = check
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *RegistryCheckHandler) Render() error {
+	c := h.check
+	if err := h.printer.Printf("Checked %s using repository %q\n\n", c.Registry, c.Repository); err != nil {
+		return err
+	}
+	w := newTableWriter(h.printer)
+	fmt.Fprintln(w, "CAPABILITY\tSTATUS\tDETAIL")
+	for _, capability := range c.Capabilities {
+		name, ok := capabilityNames[capability.Name]
+		if !ok {
+			name = capability.Name
+		}
+		detail := capability.Detail
+		if detail == "" {
+			detail = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, capability.Status, detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var flags []string
+	if spec := c.Recommended.DistributionSpec; spec != "" {
+		flags = append(flags, "--distribution-spec "+spec)
+	}
+	if spec := c.Recommended.ImageSpec; spec != "" {
+		flags = append(flags, "--image-spec "+spec)
+	}
+	if len(flags) > 0 {
+		if err := h.printer.Println("\nRecommended flags:", strings.Join(flags, " ")); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case len(c.CleanupErrors) > 0:
+		if err := h.printer.Printf("\nFailed to remove some probe content from %q:\n", c.Repository); err != nil {
+			return err
+		}
+		for _, e := range c.CleanupErrors {
+			if err := h.printer.Println(" ", e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case c.CleanedUp:
+		return h.printer.Printf("\nRemoved probe content from %q\n", c.Repository)
+	default:
+		return h.printer.Printf("\nProbe content is left in %q, use --cleanup to remove it\n", c.Repository)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestRegistryCheckHandler_Render(t *testing.T) {
+	check := &model.RegistryCheck{
+		Registry:   "localhost:5000",
+		Repository: "oras-check",
+		Capabilities: []model.Capability{
+			{Name: model.CapabilityReferrersAPI, Status: model.CapabilityUnsupported, Detail: "referrers tag schema is used instead"},
+			{Name: model.CapabilityArtifactType, Status: model.CapabilitySupported},
+			{Name: model.CapabilityCatalog, Status: model.CapabilityUnknown, Detail: "timeout"},
+		},
+		Recommended: model.RecommendedFlags{
+			DistributionSpec: "v1.1-referrers-tag",
+			ImageSpec:        "v1.1",
+		},
+	}
+
+	var buf bytes.Buffer
+	handler := NewRegistryCheckHandler(output.NewPrinter(&buf, &buf))
+	if err := handler.OnRegistryChecked(check); err != nil {
+		t.Fatal("OnRegistryChecked() error =", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	want := `Checked localhost:5000 using repository "oras-check"
+
+CAPABILITY            STATUS       DETAIL
+Referrers API         unsupported  referrers tag schema is used instead
+OCI 1.1 artifactType  supported    -
+Catalog               unknown      timeout
+
+Recommended flags: --distribution-spec v1.1-referrers-tag --image-spec v1.1
+
+Probe content is left in "oras-check", use --cleanup to remove it
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
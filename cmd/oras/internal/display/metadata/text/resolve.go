@@ -44,3 +44,8 @@ func (h *ResolveHandler) OnResolved(desc ocispec.Descriptor) error {
 	}
 	return h.printer.Println(desc.Digest.String())
 }
+
+// Render implements metadata.Renderer.
+func (h *ResolveHandler) Render() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/progress"
+	"oras.land/oras/internal/stats"
+)
+
+// printStats prints the transfer statistics summary.
+func printStats(printer *output.Printer, summary *stats.Summary) error {
+	if summary == nil {
+		return nil
+	}
+	phases := make([]string, 0, len(summary.Phases))
+	for _, phase := range summary.Phases {
+		phases = append(phases, fmt.Sprintf("%s %s", phase.Name, humanize.FormatDuration(phase.Elapsed)))
+	}
+	elapsed := humanize.FormatDuration(summary.Elapsed)
+	if len(phases) > 0 {
+		elapsed += " (" + strings.Join(phases, ", ") + ")"
+	}
+	lines := [][2]string{
+		{"Transferred:", fmt.Sprintf("%s%s", humanize.ToBytes(summary.BytesTransferred), formatCounts(summary.Counts, progress.StateTransmitted))},
+		{"Skipped:", fmt.Sprintf("%s%s", humanize.ToBytes(summary.BytesSkipped), formatCounts(summary.Counts, progress.StateExists, progress.StateSkipped, progress.StateRestored))},
+		{"Mounted:", fmt.Sprintf("%s%s", humanize.ToBytes(summary.BytesMounted), formatCounts(summary.Counts, progress.StateMounted))},
+		{"Throughput:", fmt.Sprintf("%s/s", humanize.ToBytes(int64(summary.Throughput())))},
+		{"Elapsed:", elapsed},
+	}
+	if err := printer.Println("Transfer statistics:"); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if err := printer.Printf("  %-12s %s\n", line[0], line[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatCounts formats the number of nodes in the given states.
+func formatCounts(counts map[progress.State]int, states ...progress.State) string {
+	var items []string
+	for _, state := range states {
+		if count := counts[state]; count > 0 {
+			items = append(items, fmt.Sprintf("%d %s", count, state))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(items, ", ") + ")"
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/progress"
+	"oras.land/oras/internal/stats"
+)
+
+func Test_printStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *stats.Summary
+		want    string
+	}{
+		{
+			name:    "no stats",
+			summary: nil,
+			want:    "",
+		},
+		{
+			name: "stats with phases",
+			summary: &stats.Summary{
+				BytesTransferred: 4096,
+				BytesSkipped:     1024,
+				Counts: map[progress.State]int{
+					progress.StateTransmitted: 2,
+					progress.StateExists:      1,
+					progress.StateSkipped:     3,
+				},
+				Elapsed: 2 * time.Second,
+				Phases: []stats.Phase{
+					{Name: "load", Elapsed: 500 * time.Millisecond},
+					{Name: "upload", Elapsed: 1500 * time.Millisecond},
+				},
+			},
+			want: "Transfer statistics:\n" +
+				"  Transferred: 4 KB (2 transmitted)\n" +
+				"  Skipped:     1 KB (1 exists, 3 skipped)\n" +
+				"  Mounted:     0  B\n" +
+				"  Throughput:  2 KB/s\n" +
+				"  Elapsed:     2s (load 500ms, upload 2s)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			printer := output.NewPrinter(out, os.Stderr)
+			if err := printStats(printer, tt.summary); err != nil {
+				t.Fatalf("printStats() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("printStats() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -53,3 +53,8 @@ func (ah *TagHandler) OnTagging(desc ocispec.Descriptor, _ string) (err error) {
 func (ah *TagHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
 	return ah.printer.Println("Tagged", tag)
 }
+
+// Render implements metadata.Renderer.
+func (ah *TagHandler) Render() error {
+	return nil
+}
@@ -65,6 +65,11 @@ func (DiscardHandler) PostCopy(_ context.Context, _ ocispec.Descriptor) error {
 	return nil
 }
 
+// OnMounted implements OnMounted of CopyHandler.
+func (DiscardHandler) OnMounted(_ context.Context, _ ocispec.Descriptor) error {
+	return nil
+}
+
 // OnNodeDownloading implements PullHandler.
 func (DiscardHandler) OnNodeDownloading(desc ocispec.Descriptor) error {
 	return nil
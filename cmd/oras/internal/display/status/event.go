@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"io"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
+	"oras.land/oras/internal/progress"
+)
+
+// eventManager returns a managerFunc writing newline-delimited JSON progress
+// events to w. Prompts are not used since events carry the state names.
+func eventManager(w io.Writer) managerFunc {
+	return func(map[progress.State]string) (progress.Manager, error) {
+		return sprogress.NewEventManager(w), nil
+	}
+}
+
+// NewJSONProgressPushHandler returns a new handler writing push progress
+// events to w as JSON lines.
+func NewJSONProgressPushHandler(w io.Writer, fetcher content.Fetcher) PushHandler {
+	return &TTYPushHandler{
+		newManager: eventManager(w),
+		fetcher:    fetcher,
+		committed:  &sync.Map{},
+	}
+}
+
+// NewJSONProgressAttachHandler returns a new handler writing attach progress
+// events to w as JSON lines.
+func NewJSONProgressAttachHandler(w io.Writer, fetcher content.Fetcher) AttachHandler {
+	return NewJSONProgressPushHandler(w, fetcher)
+}
+
+// NewJSONProgressPullHandler returns a new handler writing pull progress
+// events to w as JSON lines.
+func NewJSONProgressPullHandler(w io.Writer) PullHandler {
+	return &TTYPullHandler{
+		newManager: eventManager(w),
+	}
+}
+
+// NewJSONProgressCopyHandler returns a new handler writing copy progress
+// events to w as JSON lines.
+func NewJSONProgressCopyHandler(w io.Writer) CopyHandler {
+	return &TTYCopyHandler{
+		newManager: eventManager(w),
+	}
+}
+
+// NewJSONProgressBackupHandler returns a new handler writing backup progress
+// events to w as JSON lines.
+func NewJSONProgressBackupHandler(w io.Writer, fetcher content.Fetcher) BackupHandler {
+	return &TTYBackupHandler{
+		newManager: eventManager(w),
+		committed:  &sync.Map{},
+		fetcher:    fetcher,
+	}
+}
+
+// NewJSONProgressRestoreHandler returns a new handler writing restore progress
+// events to w as JSON lines.
+func NewJSONProgressRestoreHandler(w io.Writer, fetcher content.Fetcher) RestoreHandler {
+	return &TTYRestoreHandler{
+		newManager: eventManager(w),
+		committed:  &sync.Map{},
+		fetcher:    fetcher,
+	}
+}
+
+// NewJSONProgressBlobPushHandler returns a new handler writing blob push
+// progress events to w as JSON lines.
+func NewJSONProgressBlobPushHandler(w io.Writer, desc ocispec.Descriptor) BlobPushHandler {
+	return &TTYBlobPushHandler{
+		newManager: eventManager(w),
+		desc:       desc,
+	}
+}
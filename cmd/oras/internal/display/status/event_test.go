@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
+	"oras.land/oras/internal/progress"
+)
+
+func TestJSONProgressPushHandler(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	blob := []byte("hello world")
+	desc := content.NewDescriptorFromBytes("test", blob)
+
+	ph := NewJSONProgressPushHandler(&buf, memory.New())
+	tracked, stop, err := ph.TrackTarget(memory.New())
+	if err != nil {
+		t.Fatal("TrackTarget() error =", err)
+	}
+	if err := tracked.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	if err := ph.OnCopySkipped(ctx, desc); err != nil {
+		t.Fatal("OnCopySkipped() error =", err)
+	}
+	if err := stop(); err != nil {
+		t.Fatal("stop() error =", err)
+	}
+
+	var states []string
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var e sprogress.Event
+		if err := decoder.Decode(&e); err != nil {
+			t.Fatal("failed to decode event:", err)
+		}
+		if e.Descriptor.Digest != desc.Digest || e.Total != desc.Size {
+			t.Errorf("event %+v is not of %v", e, desc.Digest)
+		}
+		states = append(states, e.State)
+	}
+	want := []string{
+		progress.StateInitialized.String(),
+		progress.StateTransmitted.String(),
+		progress.StateExists.String(),
+	}
+	if len(states) != len(want) {
+		t.Fatalf("got states %v, want %v", states, want)
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("got states %v, want %v", states, want)
+		}
+	}
+}
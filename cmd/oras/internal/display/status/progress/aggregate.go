@@ -0,0 +1,152 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+)
+
+// View is the layout of the progress output.
+type View int
+
+// Supported views.
+const (
+	// ViewDetailed renders a progress bar for each descriptor.
+	ViewDetailed View = iota
+	// ViewCompact renders an aggregate header followed by the descriptors
+	// still in progress. Finished descriptors are collapsed into the header.
+	ViewCompact
+	// ViewAggregate renders the aggregate header only.
+	ViewAggregate
+)
+
+// aggregate is the overall progress of all tracked descriptors.
+type aggregate struct {
+	// done is the number of bytes transmitted, skipped or found existing.
+	done int64
+	// total is the size of all tracked descriptors.
+	total int64
+	// moved is the number of bytes actually transmitted, which is used for
+	// the throughput.
+	moved int64
+	// finished is the number of finished descriptors.
+	finished int
+	// count is the number of tracked descriptors.
+	count int
+}
+
+// renderAggregate renders the aggregate header and, in the compact view, the
+// descriptors in progress.
+// Rows are allocated on demand and left blank once items are collapsed.
+func (m *manager) renderAggregate() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	height, width := m.console.GetHeightWidth()
+	var agg aggregate
+	var active []*status
+	for _, s := range m.status {
+		p := s.progress()
+		agg.count++
+		agg.total += s.descriptor.Size
+		agg.done += p.offset
+		if p.started {
+			agg.moved += p.offset
+		}
+		if p.done {
+			agg.finished++
+		} else {
+			active = append(active, s)
+		}
+	}
+	m.speed.Add(time.Now(), agg.moved)
+
+	lines := []string{m.renderHeader(agg, width)}
+	if m.view == ViewCompact {
+		// render with culling: only the latter statuses are rendered.
+		if n := len(active) - (height-1)/2; n > 0 {
+			active = active[n:]
+		}
+		for _, s := range active {
+			view := s.Render(width)
+			lines = append(lines, view[0], view[1])
+		}
+	}
+	for m.rows < len(lines) {
+		m.console.NewRow()
+		m.rows++
+	}
+	for i := 0; i < m.rows; i++ {
+		var line string
+		if i < len(lines) {
+			line = lines[i]
+		}
+		m.console.OutputTo(uint(m.rows-i), line)
+	}
+}
+
+// renderHeader returns the aggregate header.
+// Format:
+//
+//	mark(1) bar(22) speed(8) Total done/total finished/count files ETA eta
+func (m *manager) renderHeader(agg aggregate, width int) string {
+	complete := agg.count > 0 && agg.finished == agg.count
+	var percent float64
+	if agg.total > 0 {
+		percent = float64(agg.done) / float64(agg.total)
+	} else if complete {
+		percent = 1
+	}
+	var mark string
+	if complete {
+		mark = doneMarkColor.Apply("✓")
+	} else {
+		mark = spinnerColor.Apply(string(m.mark.symbol()))
+	}
+	speed := m.speed.Mean()
+	eta := "-"
+	if complete {
+		eta = zeroDuration
+	} else if speed > 0 {
+		remaining := time.Duration(float64(agg.total-agg.done) / speed * float64(time.Second))
+		eta = humanize.FormatDuration(remaining.Round(time.Second))
+	}
+
+	lenBar := int(percent * barLength)
+	left := fmt.Sprintf("%s [%s%s](%*s/s)", mark,
+		progressColor.Apply(strings.Repeat(" ", lenBar)), strings.Repeat(".", barLength-lenBar),
+		speedLength, humanize.ToBytes(int64(speed)))
+	// mark(1) + space(1) + bar + wrapper(2) + speed + "/s"(2) + wrapper(2)
+	lenLeft := barLength + speedLength + 8
+	right := fmt.Sprintf(" Total %s/%s %d/%d files ETA %s",
+		humanize.ToBytes(agg.done), humanize.ToBytes(agg.total), agg.finished, agg.count, eta)
+	lenRight := utf8.RuneCountInString(right)
+	switch lenMargin := width - lenLeft - lenRight; {
+	case lenMargin >= 0:
+		right += strings.Repeat(" ", lenMargin)
+	case lenRight+lenMargin <= 0:
+		// no room for the totals on narrow consoles
+		right = ""
+	default:
+		right = string([]rune(right)[:lenRight+lenMargin-1]) + "."
+	}
+	return left + right
+}
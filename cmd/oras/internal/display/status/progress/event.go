@@ -0,0 +1,168 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/progress"
+)
+
+// EventStateFailed is the state of an event reporting a failed transmission.
+const EventStateFailed = "failed"
+
+// Event is a progress event of a descriptor, written as a line of JSON.
+type Event struct {
+	// Time is when the event happened.
+	Time time.Time `json:"time"`
+	// State is the name of the progress state, or "failed".
+	State string `json:"state"`
+	// Descriptor is the descriptor being transmitted.
+	Descriptor ocispec.Descriptor `json:"descriptor"`
+	// Offset is the number of bytes transmitted.
+	Offset int64 `json:"offset"`
+	// Total is the size of the descriptor in bytes.
+	Total int64 `json:"total"`
+	// Rate is the mean transmission rate in bytes per second.
+	Rate float64 `json:"rate"`
+	// Error is the error failing the transmission.
+	Error string `json:"error,omitempty"`
+}
+
+// eventManager writes progress events as newline-delimited JSON.
+type eventManager struct {
+	lock    sync.Mutex // locks encoder and closed
+	encoder *json.Encoder
+	closed  bool
+	now     func() time.Time
+}
+
+// NewEventManager returns a progress manager writing newline-delimited JSON
+// events to w.
+func NewEventManager(w io.Writer) progress.Manager {
+	return &eventManager{
+		encoder: json.NewEncoder(w),
+		now:     time.Now,
+	}
+}
+
+// Track starts emitting events of desc.
+func (m *eventManager) Track(desc ocispec.Descriptor) (progress.Tracker, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return nil, errManagerStopped
+	}
+	return &eventTracker{
+		manager:   m,
+		desc:      desc,
+		startTime: m.now(),
+	}, nil
+}
+
+// Close stops emitting events.
+func (m *eventManager) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return errManagerStopped
+	}
+	m.closed = true
+	return nil
+}
+
+// emit writes an event. Events reported after the manager is closed are
+// dropped.
+func (m *eventManager) emit(e Event) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return nil
+	}
+	return m.encoder.Encode(e)
+}
+
+// eventTracker emits the events of a descriptor.
+// Transmitting events are throttled to the frame rate of the TTY output while
+// changes of state are always emitted.
+type eventTracker struct {
+	manager   *eventManager
+	desc      ocispec.Descriptor
+	startTime time.Time
+	lastEmit  time.Time
+	offset    int64
+	lock      sync.Mutex
+}
+
+// Update emits an event of status.
+func (t *eventTracker) Update(status progress.Status) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.manager.now()
+	switch status.State {
+	case progress.StateInitialized:
+		t.startTime = now
+		t.offset = 0
+	case progress.StateTransmitting:
+		if status.Offset >= 0 {
+			t.offset = status.Offset
+		}
+		if now.Sub(t.lastEmit) < bufFlushDuration {
+			return nil
+		}
+	default:
+		if status.Offset >= 0 {
+			t.offset = status.Offset
+		} else {
+			t.offset = t.desc.Size
+		}
+	}
+	t.lastEmit = now
+	return t.manager.emit(t.event(now, status.State.String(), nil))
+}
+
+// Fail emits an event of the failure.
+func (t *eventTracker) Fail(err error) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.manager.emit(t.event(t.manager.now(), EventStateFailed, err))
+}
+
+// Close closes the tracker.
+func (t *eventTracker) Close() error {
+	return nil
+}
+
+func (t *eventTracker) event(now time.Time, state string, err error) Event {
+	e := Event{
+		Time:       now.UTC(),
+		State:      state,
+		Descriptor: t.desc,
+		Offset:     t.offset,
+		Total:      t.desc.Size,
+	}
+	if elapsed := now.Sub(t.startTime).Seconds(); elapsed > 0 {
+		e.Rate = float64(t.offset) / elapsed
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
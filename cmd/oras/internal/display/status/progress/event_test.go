@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/progress"
+)
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []Event {
+	t.Helper()
+	var events []Event
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var e Event
+		if err := decoder.Decode(&e); err != nil {
+			t.Fatal("failed to decode event:", err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func Test_eventManager(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.layer.v1.tar",
+		Digest:    "sha256:9b5fb4ec1ba6bb0ed1b2ff6b1e2a2fb3b83b9d0f1fd31d33f0e4d4b1d31e5d52",
+		Size:      100,
+	}
+	var buf bytes.Buffer
+	m := NewEventManager(&buf).(*eventManager)
+	now := time.Unix(1000, 0)
+	m.now = func() time.Time { return now }
+
+	tracker, err := m.Track(desc)
+	if err != nil {
+		t.Fatal("eventManager.Track() error =", err)
+	}
+	if err := progress.Start(tracker); err != nil {
+		t.Fatal(err)
+	}
+	// transmitting events are throttled
+	now = now.Add(time.Second)
+	for _, offset := range []int64{20, 50} {
+		if err := tracker.Update(progress.Status{State: progress.StateTransmitting, Offset: offset}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now = now.Add(time.Second)
+	if err := progress.Done(tracker); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Fail(errors.New("boom")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal("eventManager.Close() error =", err)
+	}
+
+	events := decodeEvents(t, &buf)
+	want := []struct {
+		state  string
+		offset int64
+		rate   float64
+		err    string
+	}{
+		{progress.StateInitialized.String(), 0, 0, ""},
+		{progress.StateTransmitting.String(), 20, 20, ""},
+		{progress.StateTransmitted.String(), 100, 50, ""},
+		{EventStateFailed, 100, 50, "boom"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %v", len(events), len(want), events)
+	}
+	for i, e := range events {
+		if e.State != want[i].state || e.Offset != want[i].offset || e.Rate != want[i].rate || e.Error != want[i].err {
+			t.Errorf("event[%d] = %+v, want %+v", i, e, want[i])
+		}
+		if e.Total != desc.Size || e.Descriptor.Digest != desc.Digest {
+			t.Errorf("event[%d] is not of %v", i, desc.Digest)
+		}
+	}
+}
+
+func Test_eventManager_closed(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewEventManager(&buf)
+	tracker, err := m.Track(ocispec.Descriptor{Size: 1})
+	if err != nil {
+		t.Fatal("eventManager.Track() error =", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal("eventManager.Close() error =", err)
+	}
+	if err := m.Close(); !errors.Is(err, errManagerStopped) {
+		t.Errorf("eventManager.Close() error = %v, want %v", err, errManagerStopped)
+	}
+	if _, err := m.Track(ocispec.Descriptor{}); !errors.Is(err, errManagerStopped) {
+		t.Errorf("eventManager.Track() error = %v, want %v", err, errManagerStopped)
+	}
+	// events after closing are dropped
+	if err := progress.Done(tracker); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected events: %s", buf.String())
+	}
+}
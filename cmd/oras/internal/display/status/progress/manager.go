@@ -31,6 +31,10 @@ const (
 	bufferSize       = 1
 	framePerSecond   = 5
 	bufFlushDuration = time.Second / framePerSecond
+	// aggregateSpeedWindow is the number of frames the overall throughput is
+	// averaged over, which is longer than the one of a single descriptor for
+	// a steadier ETA.
+	aggregateSpeedWindow = 5 * framePerSecond
 )
 
 var errManagerStopped = errors.New("progress output manager has already been stopped")
@@ -43,23 +47,41 @@ type manager struct {
 	renderDone   chan struct{}
 	renderClosed chan struct{}
 	prompts      map[progress.State]string
+
+	// fields below are used by the aggregate views only
+	view  View
+	rows  int // number of rows allocated in the console
+	mark  spinner
+	speed *speedWindow
 }
 
 // NewManager initialized a new progress manager.
 func NewManager(tty *os.File, prompts map[progress.State]string) (progress.Manager, error) {
+	return NewManagerWithView(tty, prompts, ViewDetailed)
+}
+
+// NewManagerWithView initialized a new progress manager rendering the given
+// view.
+func NewManagerWithView(tty *os.File, prompts map[progress.State]string, view View) (progress.Manager, error) {
 	c, err := console.NewConsole(tty)
 	if err != nil {
 		return nil, err
 	}
-	return newManager(c, prompts), nil
+	return newManagerWithView(c, prompts, view), nil
 }
 
 func newManager(c console.Console, prompts map[progress.State]string) progress.Manager {
+	return newManagerWithView(c, prompts, ViewDetailed)
+}
+
+func newManagerWithView(c console.Console, prompts map[progress.State]string, view View) progress.Manager {
 	m := &manager{
 		console:      c,
 		renderDone:   make(chan struct{}),
 		renderClosed: make(chan struct{}),
 		prompts:      prompts,
+		view:         view,
+		speed:        newSpeedWindow(aggregateSpeedWindow),
 	}
 	m.start()
 	return m
@@ -85,6 +107,10 @@ func (m *manager) start() {
 }
 
 func (m *manager) render() {
+	if m.view != ViewDetailed {
+		m.renderAggregate()
+		return
+	}
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
@@ -116,8 +142,10 @@ func (m *manager) Track(desc ocispec.Descriptor) (progress.Tracker, error) {
 	s := newStatus(desc)
 	m.lock.Lock()
 	m.status = append(m.status, s)
-	m.console.NewRow()
-	m.console.NewRow()
+	if m.view == ViewDetailed {
+		m.console.NewRow()
+		m.console.NewRow()
+	}
 	m.lock.Unlock()
 	return m.newTracker(s), nil
 }
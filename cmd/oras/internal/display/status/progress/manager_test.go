@@ -17,7 +17,11 @@ package progress
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/display/status/console"
@@ -105,3 +109,101 @@ func Test_manager(t *testing.T) {
 		}
 	}
 }
+
+func Test_manager_aggregateViews(t *testing.T) {
+	finished := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.layer.v1.tar",
+		Size:      1024,
+		Digest:    "sha256:c775e7b757ede630cd0aa1113bd102661ab38829ca52a6422ab782862f268646",
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": "done.bin",
+		},
+	}
+	inProgress := ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.layer.v1.tar",
+		Size:      3072,
+		Digest:    "sha256:f8f0fcd1b2d9dd0a8a0a0d1cf6f7b8d1cc5d7b6b5a3f2c8e0e6c38ed3b1f0a4c",
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": "pending.bin",
+		},
+	}
+	escRegexp := regexp.MustCompile("\x1b\\[[0-9]+m")
+	tests := []struct {
+		name       string
+		view       View
+		wantHeader string
+		wantItems  []string
+	}{
+		{
+			name:       "compact",
+			view:       ViewCompact,
+			wantHeader: "Total 2.5 KB/4 KB 1/2 files ETA -",
+			wantItems:  []string{"Uploading pending", "  └─ " + inProgress.Digest.String()},
+		},
+		{
+			name:       "aggregate",
+			view:       ViewAggregate,
+			wantHeader: "Total 2.5 KB/4 KB 1/2 files ETA -",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockConsole(80, 24)
+			done := newStatus(finished)
+			done.done = true
+			pending := newStatus(inProgress)
+			pending.text = "Uploading"
+			pending.startTime = time.Now()
+			pending.offset = 1536
+			m := &manager{
+				console: c,
+				view:    tt.view,
+				speed:   newSpeedWindow(aggregateSpeedWindow),
+				status:  []*status{done, pending},
+			}
+			m.render()
+
+			// finished items are collapsed into the header
+			if len(c.view) != 1+len(tt.wantItems) {
+				t.Fatalf("console view = %q, want %d rows", c.view, 1+len(tt.wantItems))
+			}
+			if header := escRegexp.ReplaceAllString(c.view[0], ""); !strings.Contains(header, tt.wantHeader) {
+				t.Errorf("console view[0] = %q, want containing %q", header, tt.wantHeader)
+			}
+			for i, want := range tt.wantItems {
+				if got := escRegexp.ReplaceAllString(c.view[i+1], ""); !strings.Contains(got, want) {
+					t.Errorf("console view[%d] = %q, want containing %q", i+1, got, want)
+				}
+			}
+
+			// rows of collapsed items are cleared
+			pending.done = true
+			m.render()
+			if header := escRegexp.ReplaceAllString(c.view[0], ""); !strings.Contains(header, "Total 4 KB/4 KB 2/2 files ETA 0s") {
+				t.Errorf("console view[0] = %q, want all done", header)
+			}
+			for i := 1; i < len(c.view); i++ {
+				if c.view[i] != "" {
+					t.Errorf("console view[%d] = %q, want empty", i, c.view[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_manager_renderHeader_narrow(t *testing.T) {
+	m := &manager{
+		speed: newSpeedWindow(aggregateSpeedWindow),
+	}
+	agg := aggregate{done: 1536, total: 4096, finished: 1, count: 2}
+	escRegexp := regexp.MustCompile("\x1b\\[[0-9]+m")
+	lenLeft := barLength + speedLength + 8
+	for _, width := range []int{-1, 0, 1, 10, lenLeft, lenLeft + 1, lenLeft + 2, lenLeft + 10} {
+		t.Run(strconv.Itoa(width), func(t *testing.T) {
+			header := escRegexp.ReplaceAllString(m.renderHeader(agg, width), "")
+			if got := utf8.RuneCountInString(header); got > max(width, lenLeft) {
+				t.Errorf("renderHeader() = %q, want at most %d runes", header, max(width, lenLeft))
+			}
+		})
+	}
+}
@@ -147,6 +147,33 @@ func (s *status) Render(width int) [2]string {
 	}
 }
 
+// transmission is a snapshot of the progress of a status.
+type transmission struct {
+	// offset is the number of bytes done.
+	offset int64
+	// started is true if the content is being or has been transmitted, as
+	// opposed to being skipped or found existing.
+	started bool
+	done    bool
+}
+
+// progress returns a snapshot of the progress of the status.
+func (s *status) progress() transmission {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	t := transmission{
+		started: !s.startTime.IsZero(),
+		done:    s.done,
+	}
+	if s.done {
+		t.offset = s.descriptor.Size
+	} else if s.offset > 0 {
+		t.offset = s.offset
+	}
+	return t
+}
+
 // calculateSpeed calculates the speed of the progress and update last status.
 // caller must hold the lock.
 func (s *status) calculateSpeed() humanize.Bytes {
@@ -33,19 +33,31 @@ type Reader struct {
 
 // NewReader returns a new reader with tracked progress.
 func NewReader(r io.Reader, descriptor ocispec.Descriptor, actionPrompt string, donePrompt string, tty *os.File) (*Reader, error) {
+	return NewReaderWithView(r, descriptor, actionPrompt, donePrompt, tty, sprogress.ViewDetailed)
+}
+
+// NewReaderWithView returns a new reader with tracked progress rendered in the
+// given view.
+func NewReaderWithView(r io.Reader, descriptor ocispec.Descriptor, actionPrompt string, donePrompt string, tty *os.File, view sprogress.View) (*Reader, error) {
 	prompt := map[progress.State]string{
 		progress.StateInitialized:  actionPrompt,
 		progress.StateTransmitting: actionPrompt,
 		progress.StateTransmitted:  donePrompt,
 	}
 
-	manager, err := sprogress.NewManager(tty, prompt)
+	manager, err := sprogress.NewManagerWithView(tty, prompt, view)
 	if err != nil {
 		return nil, err
 	}
 	return newReader(r, descriptor, manager)
 }
 
+// NewManagedReader returns a new reader with progress tracked by the given
+// manager.
+func NewManagedReader(r io.Reader, descriptor ocispec.Descriptor, manager progress.Manager) (*Reader, error) {
+	return newReader(r, descriptor, manager)
+}
+
 func newReader(r io.Reader, descriptor ocispec.Descriptor, manager progress.Manager) (*Reader, error) {
 	tracker, err := manager.Track(descriptor)
 	if err != nil {
@@ -25,24 +25,50 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	"oras.land/oras/internal/progress"
 )
 
+// managerFunc creates a progress manager showing the given prompts.
+type managerFunc func(prompts map[progress.State]string) (progress.Manager, error)
+
+// ttyManager returns a managerFunc rendering the progress view to tty.
+func ttyManager(tty *os.File, view sprogress.View) managerFunc {
+	return func(prompts map[progress.State]string) (progress.Manager, error) {
+		return sprogress.NewManagerWithView(tty, prompts, view)
+	}
+}
+
+// trackTarget returns gt tracked by a manager created by newManager.
+func trackTarget(gt oras.GraphTarget, prompts map[progress.State]string, newManager managerFunc) (track.GraphTarget, error) {
+	manager, err := newManager(prompts)
+	if err != nil {
+		return nil, err
+	}
+	return track.NewManagedTarget(gt, manager), nil
+}
+
 // TTYPushHandler handles TTY status output for push command.
 type TTYPushHandler struct {
-	tty       *os.File
-	tracked   track.GraphTarget
-	committed *sync.Map
-	fetcher   content.Fetcher
+	newManager managerFunc
+	tracked    track.GraphTarget
+	committed  *sync.Map
+	fetcher    content.Fetcher
 }
 
 // NewTTYPushHandler returns a new handler for push status events.
 func NewTTYPushHandler(tty *os.File, fetcher content.Fetcher) PushHandler {
+	return NewTTYPushHandlerWithView(tty, sprogress.ViewDetailed, fetcher)
+}
+
+// NewTTYPushHandlerWithView returns a new handler for push status events rendering
+// the given progress view.
+func NewTTYPushHandlerWithView(tty *os.File, view sprogress.View, fetcher content.Fetcher) PushHandler {
 	return &TTYPushHandler{
-		tty:       tty,
-		fetcher:   fetcher,
-		committed: &sync.Map{},
+		newManager: ttyManager(tty, view),
+		fetcher:    fetcher,
+		committed:  &sync.Map{},
 	}
 }
 
@@ -65,7 +91,7 @@ func (ph *TTYPushHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, St
 		progress.StateExists:       PushPromptExists,
 		progress.StateSkipped:      PushPromptSkipped,
 	}
-	tracked, err := track.NewTarget(gt, prompt, ph.tty)
+	tracked, err := trackTarget(gt, prompt, ph.newManager)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -101,19 +127,31 @@ func (ph *TTYPushHandler) PostCopy(ctx context.Context, desc ocispec.Descriptor)
 
 // NewTTYAttachHandler returns a new handler for attach status events.
 func NewTTYAttachHandler(tty *os.File, fetcher content.Fetcher) AttachHandler {
-	return NewTTYPushHandler(tty, fetcher)
+	return NewTTYAttachHandlerWithView(tty, sprogress.ViewDetailed, fetcher)
+}
+
+// NewTTYAttachHandlerWithView returns a new handler for attach status events
+// rendering progress bars in the given view.
+func NewTTYAttachHandlerWithView(tty *os.File, view sprogress.View, fetcher content.Fetcher) AttachHandler {
+	return NewTTYPushHandlerWithView(tty, view, fetcher)
 }
 
 // TTYPullHandler handles TTY status output for pull events.
 type TTYPullHandler struct {
-	tty     *os.File
-	tracked track.GraphTarget
+	newManager managerFunc
+	tracked    track.GraphTarget
 }
 
 // NewTTYPullHandler returns a new handler for Pull status events.
 func NewTTYPullHandler(tty *os.File) PullHandler {
+	return NewTTYPullHandlerWithView(tty, sprogress.ViewDetailed)
+}
+
+// NewTTYPullHandlerWithView returns a new handler for Pull status events rendering
+// the given progress view.
+func NewTTYPullHandlerWithView(tty *os.File, view sprogress.View) PullHandler {
 	return &TTYPullHandler{
-		tty: tty,
+		newManager: ttyManager(tty, view),
 	}
 }
 
@@ -151,7 +189,7 @@ func (ph *TTYPullHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, St
 		progress.StateSkipped:      PullPromptSkipped,
 		progress.StateRestored:     PullPromptRestored,
 	}
-	tracked, err := track.NewTarget(gt, prompt, ph.tty)
+	tracked, err := trackTarget(gt, prompt, ph.newManager)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -161,15 +199,21 @@ func (ph *TTYPullHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, St
 
 // TTYCopyHandler handles tty status output for copy events.
 type TTYCopyHandler struct {
-	tty       *os.File
-	committed sync.Map
-	tracked   track.GraphTarget
+	newManager managerFunc
+	committed  sync.Map
+	tracked    track.GraphTarget
 }
 
 // NewTTYCopyHandler returns a new handler for copy command.
 func NewTTYCopyHandler(tty *os.File) CopyHandler {
+	return NewTTYCopyHandlerWithView(tty, sprogress.ViewDetailed)
+}
+
+// NewTTYCopyHandlerWithView returns a new handler for copy command rendering
+// the given progress view.
+func NewTTYCopyHandlerWithView(tty *os.File, view sprogress.View) CopyHandler {
 	return &TTYCopyHandler{
-		tty: tty,
+		newManager: ttyManager(tty, view),
 	}
 }
 
@@ -184,7 +228,7 @@ func (ch *TTYCopyHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarget,
 		progress.StateMounted:      copyPromptMounted,
 	}
 	var err error
-	ch.tracked, err = track.NewTarget(gt, prompt, ch.tty)
+	ch.tracked, err = trackTarget(gt, prompt, ch.newManager)
 	if err != nil {
 		return nil, err
 	}
@@ -230,18 +274,24 @@ func (ch *TTYCopyHandler) OnMounted(_ context.Context, desc ocispec.Descriptor)
 
 // TTYBackupHandler handles tty status output for backup events.
 type TTYBackupHandler struct {
-	tty       *os.File
-	committed *sync.Map
-	tracked   track.GraphTarget
-	fetcher   content.Fetcher
+	newManager managerFunc
+	committed  *sync.Map
+	tracked    track.GraphTarget
+	fetcher    content.Fetcher
 }
 
 // NewTTYBackupHandler returns a new handler for backup command.
 func NewTTYBackupHandler(tty *os.File, fetcher content.Fetcher) BackupHandler {
+	return NewTTYBackupHandlerWithView(tty, sprogress.ViewDetailed, fetcher)
+}
+
+// NewTTYBackupHandlerWithView returns a new handler for backup command rendering
+// the given progress view.
+func NewTTYBackupHandlerWithView(tty *os.File, view sprogress.View, fetcher content.Fetcher) BackupHandler {
 	return &TTYBackupHandler{
-		tty:       tty,
-		committed: &sync.Map{},
-		fetcher:   fetcher,
+		newManager: ttyManager(tty, view),
+		committed:  &sync.Map{},
+		fetcher:    fetcher,
 	}
 }
 
@@ -256,7 +306,7 @@ func (bh *TTYBackupHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarget
 	}
 
 	var err error
-	bh.tracked, err = track.NewTarget(gt, prompts, bh.tty)
+	bh.tracked, err = trackTarget(gt, prompts, bh.newManager)
 	if err != nil {
 		return nil, err
 	}
@@ -296,18 +346,24 @@ func (bh *TTYBackupHandler) PostCopy(ctx context.Context, desc ocispec.Descripto
 
 // TTYRestoreHandler handles tty status output for restore events.
 type TTYRestoreHandler struct {
-	tty       *os.File
-	committed *sync.Map
-	tracked   track.GraphTarget
-	fetcher   content.Fetcher
+	newManager managerFunc
+	committed  *sync.Map
+	tracked    track.GraphTarget
+	fetcher    content.Fetcher
 }
 
 // NewTTYRestoreHandler returns a new handler for restore command.
 func NewTTYRestoreHandler(tty *os.File, fetcher content.Fetcher) RestoreHandler {
+	return NewTTYRestoreHandlerWithView(tty, sprogress.ViewDetailed, fetcher)
+}
+
+// NewTTYRestoreHandlerWithView returns a new handler for restore command rendering
+// the given progress view.
+func NewTTYRestoreHandlerWithView(tty *os.File, view sprogress.View, fetcher content.Fetcher) RestoreHandler {
 	return &TTYRestoreHandler{
-		tty:       tty,
-		committed: &sync.Map{},
-		fetcher:   fetcher,
+		newManager: ttyManager(tty, view),
+		committed:  &sync.Map{},
+		fetcher:    fetcher,
 	}
 }
 
@@ -322,7 +378,7 @@ func (rh *TTYRestoreHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarge
 	}
 
 	var err error
-	rh.tracked, err = track.NewTarget(gt, prompts, rh.tty)
+	rh.tracked, err = trackTarget(gt, prompts, rh.newManager)
 	if err != nil {
 		return nil, err
 	}
@@ -362,16 +418,22 @@ func (rh *TTYRestoreHandler) PostCopy(ctx context.Context, desc ocispec.Descript
 
 // TTYBlobPushHandler handles tty status output for blob push events.
 type TTYBlobPushHandler struct {
-	desc    ocispec.Descriptor
-	tty     *os.File
-	tracked track.GraphTarget
+	desc       ocispec.Descriptor
+	newManager managerFunc
+	tracked    track.GraphTarget
 }
 
 // NewTTYBlobPushHandler returns a new handler for blob push command.
 func NewTTYBlobPushHandler(tty *os.File, desc ocispec.Descriptor) BlobPushHandler {
+	return NewTTYBlobPushHandlerWithView(tty, sprogress.ViewDetailed, desc)
+}
+
+// NewTTYBlobPushHandlerWithView returns a new handler for blob push command
+// rendering progress bars in the given view.
+func NewTTYBlobPushHandlerWithView(tty *os.File, view sprogress.View, desc ocispec.Descriptor) BlobPushHandler {
 	return &TTYBlobPushHandler{
-		tty:  tty,
-		desc: desc,
+		newManager: ttyManager(tty, view),
+		desc:       desc,
 	}
 }
 
@@ -383,7 +445,7 @@ func (bph *TTYBlobPushHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTar
 		progress.StateTransmitted:  PushPromptUploaded,
 		progress.StateExists:       PushPromptExists,
 	}
-	tracked, err := track.NewTarget(gt, prompt, bph.tty)
+	tracked, err := trackTarget(gt, prompt, bph.newManager)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,191 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"strings"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+	onet "oras.land/oras/internal/net"
+)
+
+// Code is the class of an error, which automation can branch on.
+type Code string
+
+// Error codes.
+const (
+	// CodeUnknown is the code of errors not falling into other classes.
+	CodeUnknown Code = "UNKNOWN"
+	// CodeInvalidInput is the code of errors caused by invalid arguments or
+	// flags.
+	CodeInvalidInput Code = "INVALID_INPUT"
+	// CodeNotFound is the code of errors caused by missing artifacts or
+	// repositories.
+	CodeNotFound Code = "NOT_FOUND"
+	// CodeUnauthorized is the code of errors caused by missing or invalid
+	// credentials.
+	CodeUnauthorized Code = "UNAUTHORIZED"
+	// CodeDenied is the code of errors caused by insufficient permissions.
+	CodeDenied Code = "DENIED"
+	// CodeNetwork is the code of errors caused by network failures.
+	CodeNetwork Code = "NETWORK"
+)
+
+// Exit codes of the error classes.
+const (
+	ExitCodeUnknown      = 1
+	ExitCodeInvalidInput = 2
+	ExitCodeNotFound     = 3
+	ExitCodeUnauthorized = 4
+	ExitCodeDenied       = 5
+	ExitCodeNetwork      = 6
+)
+
+// ExitCode returns the process exit code of the error class.
+func (c Code) ExitCode() int {
+	switch c {
+	case CodeInvalidInput:
+		return ExitCodeInvalidInput
+	case CodeNotFound:
+		return ExitCodeNotFound
+	case CodeUnauthorized:
+		return ExitCodeUnauthorized
+	case CodeDenied:
+		return ExitCodeDenied
+	case CodeNetwork:
+		return ExitCodeNetwork
+	default:
+		return ExitCodeUnknown
+	}
+}
+
+// ExitCode returns the process exit code of err. It returns 0 if err is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return Classify(err).ExitCode()
+}
+
+// invalidInputError marks an error as caused by invalid input.
+type invalidInputError struct {
+	err error
+}
+
+// Error implements the error interface.
+func (e *invalidInputError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *invalidInputError) Unwrap() error {
+	return e.err
+}
+
+// InvalidInput marks err as caused by invalid input without changing its
+// message. It returns nil if err is nil.
+func InvalidInput(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &invalidInputError{err: err}
+}
+
+// Classify returns the class of err.
+func Classify(err error) Code {
+	if errResp, ok := asErrorResponse(err); ok {
+		if code := classifyErrorResponse(errResp); code != CodeUnknown {
+			return code
+		}
+	}
+	var inputErr *invalidInputError
+	var formatErr UnsupportedFormatTypeError
+	switch {
+	case errors.Is(err, auth.ErrBasicCredentialNotFound):
+		return CodeUnauthorized
+	case errors.As(err, &inputErr),
+		errors.As(err, &formatErr),
+		errors.Is(err, errdef.ErrInvalidReference),
+		errors.Is(err, errdef.ErrInvalidDigest),
+		isUsageError(err):
+		return CodeInvalidInput
+	case errors.Is(err, errdef.ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, onet.ErrOffline):
+		return CodeNetwork
+	}
+	// file system errors are not network errors even though syscall.Errno
+	// implements net.Error
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return CodeUnknown
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return CodeNetwork
+	}
+	return CodeUnknown
+}
+
+// isUsageError returns true if err is an error of command usage.
+func isUsageError(err error) bool {
+	var oErr *Error
+	if !errors.As(err, &oErr) {
+		return false
+	}
+	return oErr.Usage != "" || oErr.OperationType == OperationTypeParseArtifactReference
+}
+
+// asErrorResponse finds the registry error response in the chain of err.
+func asErrorResponse(err error) (*errcode.ErrorResponse, bool) {
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp, true
+	}
+	return nil, false
+}
+
+// classifyErrorResponse classifies a registry error response by its HTTP
+// status code and the registry error codes.
+func classifyErrorResponse(errResp *errcode.ErrorResponse) Code {
+	switch errResp.StatusCode {
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeDenied
+	case http.StatusNotFound:
+		return CodeNotFound
+	}
+	for _, e := range errResp.Errors {
+		switch code := strings.ToUpper(e.Code); {
+		case code == errcode.ErrorCodeUnauthorized:
+			return CodeUnauthorized
+		case code == errcode.ErrorCodeDenied:
+			return CodeDenied
+		case strings.HasSuffix(code, "_UNKNOWN"):
+			return CodeNotFound
+		case strings.HasSuffix(code, "_INVALID"):
+			return CodeInvalidInput
+		}
+	}
+	return CodeUnknown
+}
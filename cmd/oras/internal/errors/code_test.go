@@ -0,0 +1,114 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+	onet "oras.land/oras/internal/net"
+)
+
+func newErrResp(status int, codes ...string) *errcode.ErrorResponse {
+	resp := &errcode.ErrorResponse{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Host: "localhost:5000", Path: "/v2/test/manifests/v1"},
+		StatusCode: status,
+	}
+	for _, code := range codes {
+		resp.Errors = append(resp.Errors, errcode.Error{Code: code, Message: "test"})
+	}
+	return resp
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"unknown", errors.New("boom"), CodeUnknown},
+		{"registry unauthorized", newErrResp(http.StatusUnauthorized, errcode.ErrorCodeUnauthorized), CodeUnauthorized},
+		{"registry denied", newErrResp(http.StatusForbidden, errcode.ErrorCodeDenied), CodeDenied},
+		{"registry not found", newErrResp(http.StatusNotFound), CodeNotFound},
+		{"registry error code unknown", newErrResp(http.StatusBadRequest, errcode.ErrorCodeManifestUnknown), CodeNotFound},
+		{"registry error code invalid", newErrResp(http.StatusBadRequest, errcode.ErrorCodeManifestInvalid), CodeInvalidInput},
+		{"registry error code denied", newErrResp(http.StatusBadRequest, "denied"), CodeDenied},
+		{"registry server error", newErrResp(http.StatusInternalServerError), CodeUnknown},
+		{"reported registry error", &Error{Err: ReportErrResp(newErrResp(http.StatusForbidden, errcode.ErrorCodeDenied))}, CodeDenied},
+		{"credential not found", fmt.Errorf("login: %w", auth.ErrBasicCredentialNotFound), CodeUnauthorized},
+		{"not found", fmt.Errorf("hello:v1: %w", errdef.ErrNotFound), CodeNotFound},
+		{"file not found", &os.PathError{Op: "open", Path: "missing", Err: os.ErrNotExist}, CodeUnknown},
+		{"file error", &os.PathError{Op: "read", Path: "dir", Err: syscall.EISDIR}, CodeUnknown},
+		{"invalid input", InvalidInput(errors.New("bad flag")), CodeInvalidInput},
+		{"invalid reference", fmt.Errorf("x: %w", errdef.ErrInvalidReference), CodeInvalidInput},
+		{"unsupported format", UnsupportedFormatTypeError("xml"), CodeInvalidInput},
+		{"usage error", &Error{Err: errors.New("missing args"), Usage: "oras tag"}, CodeInvalidInput},
+		{"network", &url.Error{Op: "Get", URL: "https://localhost:5000/v2/", Err: &net.OpError{Op: "dial", Err: errors.New("refused")}}, CodeNetwork},
+		{"offline", fmt.Errorf("fetch: %w", onet.ErrOffline), CodeNetwork},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{errors.New("boom"), ExitCodeUnknown},
+		{InvalidInput(errors.New("bad flag")), ExitCodeInvalidInput},
+		{errdef.ErrNotFound, ExitCodeNotFound},
+		{auth.ErrBasicCredentialNotFound, ExitCodeUnauthorized},
+		{newErrResp(http.StatusForbidden), ExitCodeDenied},
+		{onet.ErrOffline, ExitCodeNetwork},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	if err := InvalidInput(nil); err != nil {
+		t.Errorf("InvalidInput(nil) = %v, want nil", err)
+	}
+	inner := &Error{Err: errors.New("bad"), Recommendation: "fix it"}
+	err := InvalidInput(inner)
+	if err.Error() != inner.Error() {
+		t.Errorf("InvalidInput() message = %q, want %q", err.Error(), inner.Error())
+	}
+	var oErr *Error
+	if !errors.As(err, &oErr) || oErr != inner {
+		t.Errorf("InvalidInput() does not wrap %v", inner)
+	}
+}
@@ -18,6 +18,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -25,6 +26,8 @@ import (
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/errcode"
+	onet "oras.land/oras/internal/net"
+	"oras.land/oras/internal/trace"
 )
 
 // OperationType stands for certain type of operations.
@@ -53,6 +56,9 @@ type Error struct {
 	Err            error
 	Usage          string
 	Recommendation string
+	// Reference is the artifact reference on which the error occurs. It is
+	// reported in the JSON error output only.
+	Reference string
 }
 
 // Unwrap implements the errors.Wrapper interface.
@@ -93,11 +99,21 @@ type Modifier interface {
 
 // Command returns an error-handled cobra command.
 func Command(cmd *cobra.Command, handler Modifier) *cobra.Command {
+	if preRunE := cmd.PreRunE; preRunE != nil {
+		cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+			if err := preRunE(cmd, args); err != nil {
+				return preRunError(err)
+			}
+			return nil
+		}
+	}
 	runE := cmd.RunE
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		err := runE(cmd, args)
 		if err != nil {
 			err, _ = handler.ModifyError(cmd, err)
+			err = modifyOfflineError(err)
+			trace.LogCommandError(cmd.Context(), err)
 			return err
 		}
 		return nil
@@ -105,8 +121,33 @@ func Command(cmd *cobra.Command, handler Modifier) *cobra.Command {
 	return cmd
 }
 
+// preRunError marks err returned on parsing arguments and flags as invalid
+// input, unless it is classified by its cause, or it is a failure to read or
+// create a file given by a flag.
+func preRunError(err error) error {
+	var pathErr *fs.PathError
+	if Classify(err) != CodeUnknown || errors.As(err, &pathErr) {
+		return err
+	}
+	return InvalidInput(err)
+}
+
+// modifyOfflineError recommends how to get out of offline mode if err is
+// caused by a network access in offline mode.
+func modifyOfflineError(err error) error {
+	var oErr *Error
+	if !errors.Is(err, onet.ErrOffline) || errors.As(err, &oErr) {
+		return err
+	}
+	return &Error{
+		Err:            err,
+		Recommendation: `The content is not fully available in the local content cache. Please fetch it once with network access, or disable offline mode by removing the "--offline" flag and unsetting ORAS_OFFLINE`,
+	}
+}
+
 // ReportErrResp returns the inner error message from errResp.Errors.
 // If errResp.Errors is empty, it returns the original errResp.
+// The returned error always unwraps to errResp.
 func ReportErrResp(errResp *errcode.ErrorResponse) error {
 	if len(errResp.Errors) == 0 {
 		// Example error string:
@@ -115,7 +156,23 @@ func ReportErrResp(errResp *errcode.ErrorResponse) error {
 	}
 	// Example error string:
 	// unauthorized: authentication required
-	return errResp.Errors
+	return &errorResponse{errResp}
+}
+
+// errorResponse reports the error message of the inner errors of the wrapped
+// error response.
+type errorResponse struct {
+	*errcode.ErrorResponse
+}
+
+// Error implements the error interface.
+func (e *errorResponse) Error() string {
+	return e.Errors.Error()
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *errorResponse) Unwrap() error {
+	return e.ErrorResponse
 }
 
 // UnwrapCopyError extracts the underlying error from an oras.CopyError.
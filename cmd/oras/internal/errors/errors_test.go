@@ -17,12 +17,14 @@ package errors
 
 import (
 	"fmt"
+	"io/fs"
 	"net/url"
 	"testing"
 
 	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/registry/remote/errcode"
+	onet "oras.land/oras/internal/net"
 )
 
 func TestCheckMutuallyExclusiveFlags(t *testing.T) {
@@ -190,3 +192,60 @@ func TestUnwrapCopyError(t *testing.T) {
 		})
 	}
 }
+
+func Test_modifyOfflineError(t *testing.T) {
+	regularErr := fmt.Errorf("regular error")
+	offlineErr := &url.Error{Op: "Get", URL: "https://localhost:5000/v2/", Err: onet.ErrOffline}
+	oErr := &Error{Err: fmt.Errorf("wrapped: %w", onet.ErrOffline)}
+
+	if got := modifyOfflineError(regularErr); got != regularErr {
+		t.Errorf("modifyOfflineError() = %v, want %v", got, regularErr)
+	}
+	if got := modifyOfflineError(oErr); got != oErr {
+		t.Errorf("modifyOfflineError() = %v, want %v", got, oErr)
+	}
+	got, ok := modifyOfflineError(offlineErr).(*Error)
+	if !ok {
+		t.Fatalf("modifyOfflineError() = %v, want *Error", got)
+	}
+	if got.Err != offlineErr || got.Recommendation == "" {
+		t.Errorf("modifyOfflineError() = %+v, want wrapped error with recommendation", got)
+	}
+}
+
+func Test_preRunError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"invalid flag", fmt.Errorf("invalid progress type: %q", "bars"), CodeInvalidInput},
+		{"usage error", &Error{Err: fmt.Errorf("missing args"), Usage: "oras tag"}, CodeInvalidInput},
+		{
+			name: "missing template file",
+			err: InvalidInput(&Error{
+				Err:            fmt.Errorf("failed to read template file: %w", &fs.PathError{Op: "open", Path: "tpl", Err: fs.ErrNotExist}),
+				Recommendation: "Please check the path of the template file",
+			}),
+			want: CodeInvalidInput,
+		},
+		{
+			name: "progress file directory not found",
+			err:  fmt.Errorf("failed to create the progress file: %w", &fs.PathError{Op: "open", Path: "missing/progress.json", Err: fs.ErrNotExist}),
+			want: CodeUnknown,
+		},
+		{
+			name: "progress file not created",
+			err:  fmt.Errorf("failed to create the progress file: %w", &fs.PathError{Op: "open", Path: "progress.json", Err: fs.ErrPermission}),
+			want: CodeUnknown,
+		},
+		{"offline", fmt.Errorf("failed: %w", onet.ErrOffline), CodeNetwork},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(preRunError(tt.err)); got != tt.want {
+				t.Errorf("Classify(preRunError()) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
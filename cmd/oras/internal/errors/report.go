@@ -0,0 +1,90 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// Report is the machine-readable form of an error.
+type Report struct {
+	// Code is the class of the error.
+	Code Code `json:"code"`
+	// ExitCode is the process exit code.
+	ExitCode int `json:"exitCode"`
+	// Message is the error message without usage and recommendation.
+	Message string `json:"message"`
+	// RegistryErrors are the errors returned by the registry.
+	RegistryErrors []errcode.Error `json:"registryErrors,omitempty"`
+	// HTTPStatus is the HTTP status code of the registry response.
+	HTTPStatus int `json:"httpStatus,omitempty"`
+	// Reference is the artifact reference on which the error occurs.
+	Reference string `json:"reference,omitempty"`
+	// Usage is the usage of the command, if the command is misused.
+	Usage string `json:"usage,omitempty"`
+	// Recommendation is the suggestion to resolve the error.
+	Recommendation string `json:"recommendation,omitempty"`
+}
+
+// NewReport returns the machine-readable form of err.
+func NewReport(err error) Report {
+	code := Classify(err)
+	report := Report{
+		Code:     code,
+		ExitCode: code.ExitCode(),
+		Message:  err.Error(),
+	}
+	var oErr *Error
+	if errors.As(err, &oErr) {
+		// trim the usage and recommendation appended to the message
+		report.Message = strings.TrimSuffix(report.Message, strings.TrimPrefix(oErr.Error(), oErr.Err.Error()))
+		report.Usage = oErr.Usage
+		report.Recommendation = oErr.Recommendation
+		report.Reference = oErr.Reference
+	}
+	if errResp, ok := asErrorResponse(err); ok {
+		report.RegistryErrors = errResp.Errors
+		report.HTTPStatus = errResp.StatusCode
+	}
+	return report
+}
+
+// Print prints err of the executed cmd to the error output of cmd in the
+// same form as cobra does, or as JSON if jsonOutput is true.
+func Print(cmd *cobra.Command, err error, jsonOutput bool) error {
+	if jsonOutput {
+		return PrintJSON(cmd.ErrOrStderr(), err)
+	}
+	cmd.PrintErrln(cmd.ErrPrefix(), err.Error())
+	if cmd.CalledAs() == "" {
+		// the command is not found
+		cmd.PrintErrf("Run '%v --help' for usage.\n", cmd.CommandPath())
+	}
+	return nil
+}
+
+// PrintJSON prints the report of err to w as a line of JSON.
+func PrintJSON(w io.Writer, err error) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(NewReport(err))
+}
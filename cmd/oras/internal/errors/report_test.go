@@ -0,0 +1,103 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+func TestNewReport(t *testing.T) {
+	errResp := newErrResp(http.StatusNotFound, errcode.ErrorCodeManifestUnknown)
+	err := &Error{
+		Err:            ReportErrResp(errResp),
+		Recommendation: "check the tag",
+		Reference:      "localhost:5000/test:v1",
+	}
+	got := NewReport(fmt.Errorf("failed to fetch: %w", err))
+	want := Report{
+		Code:           CodeNotFound,
+		ExitCode:       ExitCodeNotFound,
+		Message:        "failed to fetch: manifest unknown: test",
+		RegistryErrors: errResp.Errors,
+		HTTPStatus:     http.StatusNotFound,
+		Reference:      "localhost:5000/test:v1",
+		Recommendation: "check the tag",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewReport() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewReport_usage(t *testing.T) {
+	err := &Error{
+		Err:            errors.New("missing args"),
+		Usage:          "oras tag <name> <tag>",
+		Recommendation: "specify the tags",
+	}
+	got := NewReport(InvalidInput(err))
+	want := Report{
+		Code:           CodeInvalidInput,
+		ExitCode:       ExitCodeInvalidInput,
+		Message:        "missing args",
+		Usage:          "oras tag <name> <tag>",
+		Recommendation: "specify the tags",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewReport() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	err := errors.New("boom")
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{Use: "test"}
+		cmd.SetErr(&buf)
+		if err := Print(cmd, err, false); err != nil {
+			t.Fatal("Print() error =", err)
+		}
+		want := "Error: boom\nRun 'test --help' for usage.\n"
+		if got := buf.String(); got != want {
+			t.Errorf("Print() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{Use: "test"}
+		cmd.SetErr(&buf)
+		if err := Print(cmd, err, true); err != nil {
+			t.Fatal("Print() error =", err)
+		}
+		var got Report
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if got.Code != CodeUnknown || got.ExitCode != ExitCodeUnknown || got.Message != "boom" {
+			t.Errorf("Print() = %+v", got)
+		}
+	})
+}
@@ -88,6 +88,10 @@ func (target *BinaryTarget) ModifyError(cmd *cobra.Command, err error) (error, b
 	}
 
 	err, _ = target.modifyError(cmd, err)
+	var oErr *oerrors.Error
+	if errors.As(err, &oErr) {
+		oErr.Reference = errTarget.RawReference
+	}
 	// Example: Error from source registry for "localhost:5000/test:v1":
 	// Example: Error from destination oci-layout for "oci-dir:v1":
 	cmd.SetErrPrefix(fmt.Sprintf("Error from %s %s for %q:", copyErr.Origin, errTarget.Type, errTarget.RawReference))
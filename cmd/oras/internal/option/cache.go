@@ -16,20 +16,120 @@ limitations under the License.
 package option
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"time"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/internal/cache"
+	"oras.land/oras/internal/config"
+	onet "oras.land/oras/internal/net"
 )
 
+const (
+	// cacheDirFlag is the flag for the cache root directory.
+	cacheDirFlag = "cache-dir"
+	// offlineFlag is the flag for the offline mode.
+	offlineFlag = "offline"
+	// cacheEnv is the environment variable for the cache root directory.
+	cacheEnv = "ORAS_CACHE"
+	// offlineEnv is the environment variable for the offline mode.
+	offlineEnv = "ORAS_OFFLINE"
+)
+
+// Cache option struct.
 type Cache struct {
-	Root string
+	Root         string
+	ResolveTTL   time.Duration
+	ReferrersTTL time.Duration
+	Offline      bool
+
+	dir    string
+	notice io.Writer
+	// applyRemoteFlags is set for commands reading remote content through
+	// the cache.
+	applyRemoteFlags bool
+}
+
+// EnableRemoteCacheFlags enables the offline mode and the TTL flags for
+// commands reading remote content through the cache.
+func (opts *Cache) EnableRemoteCacheFlags() {
+	opts.applyRemoteFlags = true
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Cache) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&opts.dir, cacheDirFlag, "", "[Experimental] `path` of the local content cache, overrides "+cacheEnv+" and the cacheDir setting of the config file")
+	if !opts.applyRemoteFlags {
+		return
+	}
+	fs.DurationVar(&opts.ResolveTTL, "cache-resolve-ttl", 0, "[Experimental] reuse tag resolutions recorded in the local content cache for `duration`, e.g. 10m")
+	fs.DurationVar(&opts.ReferrersTTL, "cache-referrers-ttl", 0, "[Experimental] reuse referrers listings recorded in the local content cache for `duration`, e.g. 10m")
+	fs.BoolVar(&opts.Offline, offlineFlag, false, "[Experimental] serve content only from the local content cache and refuse any network access, can also be set via "+offlineEnv+"=1")
+}
+
+// Parse falls back to the environment variable for the offline mode, and
+// marks the command context as offline if enabled.
+func (opts *Cache) Parse(cmd *cobra.Command) error {
+	opts.notice = cmd.ErrOrStderr()
+	if !opts.applyRemoteFlags {
+		return nil
+	}
+	if !cmd.Flags().Changed(offlineFlag) {
+		if value := os.Getenv(offlineEnv); value != "" {
+			offline, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for %s: %w", value, offlineEnv, err)
+			}
+			opts.Offline = offline
+		}
+	}
+	if opts.Offline {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		cmd.SetContext(onet.WithOffline(ctx))
+	}
+	return nil
+}
+
+// CacheRoot resolves the cache root directory from the --cache-dir flag, the
+// ORAS_CACHE environment variable and the config file in order.
+// An empty root is returned if caching is not enabled.
+func (opts *Cache) CacheRoot() (string, error) {
+	if opts.dir != "" {
+		return opts.dir, nil
+	}
+	if root := os.Getenv(cacheEnv); root != "" {
+		return root, nil
+	}
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return "", err
+	}
+	return cfg.CacheDir, nil
 }
 
 // CachedTarget gets the target storage with caching if cache root is specified.
 func (opts *Cache) CachedTarget(src oras.ReadOnlyTarget) (oras.ReadOnlyTarget, error) {
-	opts.Root = os.Getenv("ORAS_CACHE")
+	if repo, ok := src.(*remote.Repository); ok {
+		return opts.CachedGraphTarget(repo)
+	}
+	var err error
+	if opts.Root, err = opts.CacheRoot(); err != nil {
+		return nil, err
+	}
 	if opts.Root != "" {
 		ociStore, err := oci.New(opts.Root)
 		if err != nil {
@@ -39,3 +139,67 @@ func (opts *Cache) CachedTarget(src oras.ReadOnlyTarget) (oras.ReadOnlyTarget, e
 	}
 	return src, nil
 }
+
+// CachedGraphTarget gets the graph target with caching if cache root is
+// specified and src is a remote repository. Tag resolutions are recorded for
+// offline use, and reused along with referrers listings if their TTLs are
+// set. In offline mode, src is never accessed.
+func (opts *Cache) CachedGraphTarget(src oras.ReadOnlyGraphTarget) (oras.ReadOnlyGraphTarget, error) {
+	repo, ok := src.(*remote.Repository)
+	if !ok {
+		// content in non-remote targets is already local
+		return src, nil
+	}
+	var err error
+	if opts.Root, err = opts.CacheRoot(); err != nil {
+		return nil, err
+	}
+	if opts.Root == "" {
+		if opts.Offline {
+			return nil, &oerrors.Error{
+				Err:            errors.New("offline mode requires a local content cache"),
+				Recommendation: fmt.Sprintf(`Please specify the cache directory via the "--%s" flag, the %s environment variable or the "cacheDir" setting of the config file`, cacheDirFlag, cacheEnv),
+			}
+		}
+		return src, nil
+	}
+	ociStore, err := oci.New(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	return cache.NewGraph(src, ociStore, cache.GraphOptions{
+		Metadata:     cache.NewMetadata(opts.Root, repositoryName(repo)),
+		ResolveTTL:   opts.ResolveTTL,
+		ReferrersTTL: opts.ReferrersTTL,
+		Offline:      opts.Offline,
+		OnOfflineTag: opts.printOfflineTag,
+	}), nil
+}
+
+// InvalidateReferrers removes the cached referrers listing of subject in the
+// repository of target. It is a no-op if caching is not enabled.
+func (opts *Cache) InvalidateReferrers(target oras.ReadOnlyTarget, subject ocispec.Descriptor) error {
+	repo, ok := cache.Source(target).(*remote.Repository)
+	if !ok {
+		return nil
+	}
+	root, err := opts.CacheRoot()
+	if err != nil || root == "" {
+		return err
+	}
+	return cache.NewMetadata(root, repositoryName(repo)).DeleteReferrers(subject.Digest)
+}
+
+// printOfflineTag notifies the use of a recorded tag resolution, which may be
+// outdated, in offline mode.
+func (opts *Cache) printOfflineTag(record cache.TagRecord) {
+	if opts.notice == nil {
+		return
+	}
+	age := time.Since(record.ResolvedAt).Round(time.Second)
+	_, _ = fmt.Fprintf(opts.notice, "Offline: using %q resolved to %s %s ago\n", record.Reference, record.Descriptor.Digest, age)
+}
+
+func repositoryName(repo *remote.Repository) string {
+	return repo.Reference.Registry + "/" + repo.Reference.Repository
+}
@@ -16,13 +16,21 @@ limitations under the License.
 package option
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/internal/cache"
+	"oras.land/oras/internal/config"
+	onet "oras.land/oras/internal/net"
 )
 
 var mockTarget oras.ReadOnlyTarget = memory.New()
@@ -49,6 +57,7 @@ func TestCache_CachedTarget(t *testing.T) {
 
 func TestCache_CachedTarget_emptyRoot(t *testing.T) {
 	t.Setenv("ORAS_CACHE", "")
+	t.Setenv(config.EnvPath, filepath.Join(t.TempDir(), config.FileName))
 	opts := Cache{}
 
 	got, err := opts.CachedTarget(mockTarget)
@@ -59,3 +68,158 @@ func TestCache_CachedTarget_emptyRoot(t *testing.T) {
 		t.Fatalf("Cache.CachedTarget() got %v, want %v", got, mockTarget)
 	}
 }
+
+func TestCache_CacheRoot(t *testing.T) {
+	configDir := t.TempDir()
+	configPath := filepath.Join(configDir, config.FileName)
+	if err := os.WriteFile(configPath, []byte(`{"cacheDir":"cache"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvPath, configPath)
+
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want string
+	}{
+		{
+			name: "config file",
+			want: filepath.Join(configDir, "cache"),
+		},
+		{
+			name: "environment variable overrides config file",
+			env:  "/env/cache",
+			want: "/env/cache",
+		},
+		{
+			name: "flag overrides environment variable",
+			args: []string{"--cache-dir", "/flag/cache"},
+			env:  "/env/cache",
+			want: "/flag/cache",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ORAS_CACHE", tt.env)
+			var opts Cache
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			opts.ApplyFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			got, err := opts.CacheRoot()
+			if err != nil {
+				t.Fatal("Cache.CacheRoot() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("Cache.CacheRoot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCache_CachedGraphTarget(t *testing.T) {
+	t.Setenv("ORAS_CACHE", t.TempDir())
+	opts := Cache{ResolveTTL: time.Minute}
+
+	// non-remote targets are not cached
+	src := memory.New()
+	got, err := opts.CachedGraphTarget(src)
+	if err != nil {
+		t.Fatal("Cache.CachedGraphTarget() error =", err)
+	}
+	if got != oras.ReadOnlyGraphTarget(src) {
+		t.Errorf("Cache.CachedGraphTarget() = %v, want %v", got, src)
+	}
+
+	repo, err := remote.NewRepository("localhost:5000/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = opts.CachedGraphTarget(repo)
+	if err != nil {
+		t.Fatal("Cache.CachedGraphTarget() error =", err)
+	}
+	if cache.Source(got) != oras.ReadOnlyTarget(repo) {
+		t.Errorf("Cache.CachedGraphTarget() is not a cached target of %v", repo)
+	}
+}
+
+func TestCache_Parse_offline(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      string
+		want     bool
+		wantErr  bool
+		disabled bool
+	}{
+		{name: "default"},
+		{name: "flag", args: []string{"--offline"}, want: true},
+		{name: "environment variable", env: "1", want: true},
+		{name: "flag overrides environment variable", args: []string{"--offline=false"}, env: "1"},
+		{name: "invalid environment variable", env: "maybe", wantErr: true},
+		{name: "disabled", env: "1", disabled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(offlineEnv, tt.env)
+			var opts Cache
+			if !tt.disabled {
+				opts.EnableRemoteCacheFlags()
+			}
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Cache.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if opts.Offline != tt.want {
+				t.Errorf("Cache.Offline = %v, want %v", opts.Offline, tt.want)
+			}
+			if got := cmd.Context() != nil && onet.IsOffline(cmd.Context()); got != tt.want {
+				t.Errorf("command context offline = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCache_ApplyFlags(t *testing.T) {
+	remoteFlags := []string{offlineFlag, "cache-resolve-ttl", "cache-referrers-ttl"}
+	for _, enabled := range []bool{false, true} {
+		var opts Cache
+		if enabled {
+			opts.EnableRemoteCacheFlags()
+		}
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		opts.ApplyFlags(fs)
+		if fs.Lookup(cacheDirFlag) == nil {
+			t.Errorf("flag --%s is not registered", cacheDirFlag)
+		}
+		for _, name := range remoteFlags {
+			if registered := fs.Lookup(name) != nil; registered != enabled {
+				t.Errorf("flag --%s registered = %v, want %v", name, registered, enabled)
+			}
+		}
+	}
+}
+
+func TestCache_CachedGraphTarget_offlineWithoutCache(t *testing.T) {
+	t.Setenv("ORAS_CACHE", "")
+	t.Setenv(config.EnvPath, filepath.Join(t.TempDir(), config.FileName))
+	opts := Cache{Offline: true}
+	repo, err := remote.NewRepository("localhost:5000/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := opts.CachedGraphTarget(repo); err == nil {
+		t.Error("Cache.CachedGraphTarget() error = nil, want error")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/chunk"
+)
+
+const chunkSizeFlag = "chunk-size"
+
+// Chunk option struct.
+type Chunk struct {
+	ChunkSize string
+
+	options *chunk.Options
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Chunk) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&opts.ChunkSize, chunkSizeFlag, "", "[Experimental] upload blobs larger than `size` in chunks of the size, with an optional K, M or G suffix, resuming failed chunks if the registry supports chunked uploads")
+}
+
+// Parse parses the chunk size.
+func (opts *Chunk) Parse(_ *cobra.Command) error {
+	if opts.ChunkSize == "" {
+		return nil
+	}
+	size, err := ParseSize(opts.ChunkSize)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid value %q for flag --%s: %w", opts.ChunkSize, chunkSizeFlag, err),
+			Recommendation: fmt.Sprintf("Please specify a positive number of bytes, e.g. --%s 64M", chunkSizeFlag),
+		}
+	}
+	opts.options = &chunk.Options{
+		ChunkSize: size,
+	}
+	return nil
+}
+
+// WithChunkedUpload returns a context carrying the chunk size so that large
+// blobs pushed with the context are uploaded in chunks.
+func (opts *Chunk) WithChunkedUpload(ctx context.Context) context.Context {
+	if opts.options == nil {
+		return ctx
+	}
+	return chunk.WithOptions(ctx, opts.options)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/internal/chunk"
+)
+
+func TestChunk_WithChunkedUpload(t *testing.T) {
+	ctx := context.Background()
+	opts := Chunk{}
+	if err := opts.Parse(&cobra.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := chunk.OptionsFromContext(opts.WithChunkedUpload(ctx)); got != nil {
+		t.Errorf("expected no options, got %v", got)
+	}
+
+	opts = Chunk{ChunkSize: "16M"}
+	if err := opts.Parse(&cobra.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	want := chunk.Options{ChunkSize: 16 << 20}
+	if got := chunk.OptionsFromContext(opts.WithChunkedUpload(ctx)); got == nil || *got != want {
+		t.Errorf("unexpected options: %+v, want %+v", got, want)
+	}
+
+	opts = Chunk{ChunkSize: "0"}
+	if err := opts.Parse(&cobra.Command{}); err == nil {
+		t.Error("Chunk.Parse() expected error for non-positive chunk size")
+	}
+}
@@ -16,24 +16,75 @@ limitations under the License.
 package option
 
 import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/trace"
 )
 
+// logLevels are the supported values of the --log-level flag.
+var logLevels = map[string]logrus.Level{
+	"debug": logrus.DebugLevel,
+	"info":  logrus.InfoLevel,
+	"warn":  logrus.WarnLevel,
+	"error": logrus.ErrorLevel,
+}
+
 // Common option struct.
 type Common struct {
-	Printer *output.Printer
-	Debug   bool
+	Printer   *output.Printer
+	Debug     bool
+	LogFormat string
+	LogLevel  logrus.Level
+
+	logLevel string
 }
 
 // ApplyFlags applies flags to a command flag set.
 func (opts *Common) ApplyFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&opts.Debug, "debug", "d", false, "output debug logs (implies --no-tty)")
+	fs.StringVar(&opts.LogFormat, "log-format", trace.LogFormatText, "[Experimental] log format, options: text, json")
+	fs.StringVar(&opts.logLevel, "log-level", "", "[Experimental] minimum level of logs to output, options: debug, info, warn, error. Level debug is the same as --debug")
 }
 
 // Parse gets target options from user input.
 func (opts *Common) Parse(cmd *cobra.Command) error {
 	opts.Printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "debug", "log-level"); err != nil {
+		return err
+	}
+	switch opts.LogFormat {
+	case trace.LogFormatText, trace.LogFormatJSON:
+	default:
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid log format %q", opts.LogFormat),
+			Recommendation: fmt.Sprintf("Please specify a log format among %q and %q", trace.LogFormatText, trace.LogFormatJSON),
+		}
+	}
+	opts.LogLevel = logrus.WarnLevel
+	if opts.Debug {
+		opts.LogLevel = logrus.DebugLevel
+	} else if opts.logLevel != "" {
+		level, ok := logLevels[opts.logLevel]
+		if !ok {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid log level %q", opts.logLevel),
+				Recommendation: "Please specify a log level among debug, info, warn and error",
+			}
+		}
+		opts.LogLevel = level
+		// debug level implies --debug
+		opts.Debug = level == logrus.DebugLevel
+	}
 	return nil
 }
+
+// traceRequests returns true if HTTP requests should be logged.
+// Requests are always logged in JSON so that failures can be indexed.
+func (opts *Common) traceRequests() bool {
+	return opts.Debug || opts.LogFormat == trace.LogFormatJSON
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func TestCommon_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantLevel logrus.Level
+		wantDebug bool
+		wantErr   bool
+	}{
+		{"default", nil, logrus.WarnLevel, false, false},
+		{"debug", []string{"--debug"}, logrus.DebugLevel, true, false},
+		{"info level", []string{"--log-level", "info"}, logrus.InfoLevel, false, false},
+		{"debug level", []string{"--log-level", "debug"}, logrus.DebugLevel, true, false},
+		{"json format", []string{"--log-format", "json", "--log-level", "error"}, logrus.ErrorLevel, false, false},
+		{"invalid level", []string{"--log-level", "trace"}, 0, false, true},
+		{"invalid format", []string{"--log-format", "xml"}, 0, false, true},
+		{"debug and level", []string{"--debug", "--log-level", "info"}, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts Common
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Common.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.LogLevel != tt.wantLevel {
+				t.Errorf("Common.LogLevel = %v, want %v", opts.LogLevel, tt.wantLevel)
+			}
+			if opts.Debug != tt.wantDebug {
+				t.Errorf("Common.Debug = %v, want %v", opts.Debug, tt.wantDebug)
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ package option
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"text/tabwriter"
 
@@ -51,6 +53,10 @@ var (
 		Name:  "json",
 		Usage: "Print in JSON format",
 	}
+	FormatTypeYAML = &FormatType{
+		Name:  "yaml",
+		Usage: "Print in YAML format",
+	}
 	FormatTypeGoTemplate = &FormatType{
 		Name:      "go-template",
 		Usage:     "Print output using the given Go template",
@@ -76,6 +82,7 @@ type Format struct {
 	FormatFlag   string
 	Type         string
 	Template     string
+	templateFile string
 	allowedTypes []*FormatType
 }
 
@@ -96,6 +103,7 @@ func (opts *Format) ApplyFlags(fs *pflag.FlagSet) {
 	// apply flags
 	fs.StringVar(&opts.FormatFlag, "format", opts.FormatFlag, buf.String())
 	fs.StringVar(&opts.Template, "template", "", "[Experimental] template string used to format output")
+	fs.StringVar(&opts.templateFile, "template-file", "", "[Experimental] `path` of the template file used to format output")
 }
 
 // Parse parses the input format flag.
@@ -104,6 +112,9 @@ func (opts *Format) Parse(cmd *cobra.Command) error {
 	if opts.FormatFlag == FormatTypeTable.Name {
 		_, _ = fmt.Fprint(cmd.ErrOrStderr(), "Format \"table\" is deprecated and will be removed in a future release.\n")
 	}
+	if err := opts.readTemplateFile(); err != nil {
+		return err
+	}
 	if err := opts.parseFlag(); err != nil {
 		return err
 	}
@@ -157,3 +168,33 @@ func (opts *Format) parseFlag() error {
 	}
 	return nil
 }
+
+// readTemplateFile reads the template from the template file, if specified.
+func (opts *Format) readTemplateFile() error {
+	if opts.templateFile == "" {
+		return nil
+	}
+	if opts.Template != "" || strings.HasPrefix(opts.FormatFlag, FormatTypeGoTemplate.Name+"=") {
+		return errors.New("--template-file cannot be used with an inline template")
+	}
+	if opts.FormatFlag != FormatTypeGoTemplate.Name {
+		return fmt.Errorf("--template-file must be used with --format %s", FormatTypeGoTemplate.Name)
+	}
+	content, err := os.ReadFile(opts.templateFile)
+	if err != nil {
+		err = &oerrors.Error{
+			Err:            fmt.Errorf("failed to read template file: %w", err),
+			Recommendation: "Please check the path of the template file",
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			// a missing template file is a wrong flag value
+			return oerrors.InvalidInput(err)
+		}
+		return err
+	}
+	if len(content) == 0 {
+		return fmt.Errorf("template file %q is empty", opts.templateFile)
+	}
+	opts.Template = string(content)
+	return nil
+}
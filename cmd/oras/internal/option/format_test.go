@@ -0,0 +1,102 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+func TestFormat_Parse_templateFile(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "output.tmpl")
+	if err := os.WriteFile(path, []byte("{{.digest}}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyPath := filepath.Join(tempDir, "empty.tmpl")
+	if err := os.WriteFile(emptyPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		formatFlag   string
+		template     string
+		templateFile string
+		wantTemplate string
+		wantErr      bool
+	}{
+		{"template file", FormatTypeGoTemplate.Name, "", path, "{{.digest}}\n", false},
+		{"without template file", FormatTypeGoTemplate.Name + "={{.size}}", "", "", "{{.size}}", false},
+		{"with template", FormatTypeGoTemplate.Name, "{{.size}}", path, "", true},
+		{"with inline template", FormatTypeGoTemplate.Name + "={{.size}}", "", path, "", true},
+		{"with other format", FormatTypeJSON.Name, "", path, "", true},
+		{"missing file", FormatTypeGoTemplate.Name, "", filepath.Join(tempDir, "missing"), "", true},
+		{"empty file", FormatTypeGoTemplate.Name, "", emptyPath, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Format{}
+			opts.SetTypes(FormatTypeText, FormatTypeJSON, FormatTypeGoTemplate)
+			opts.FormatFlag = tt.formatFlag
+			opts.Template = tt.template
+			opts.templateFile = tt.templateFile
+			err := opts.Parse(&cobra.Command{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Format.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if opts.Type != FormatTypeGoTemplate.Name {
+					t.Errorf("Format.Type = %q, want %q", opts.Type, FormatTypeGoTemplate.Name)
+				}
+				if opts.Template != tt.wantTemplate {
+					t.Errorf("Format.Template = %q, want %q", opts.Template, tt.wantTemplate)
+				}
+			}
+		})
+	}
+}
+
+func TestFormat_Parse_templateFile_errorCode(t *testing.T) {
+	tempDir := t.TempDir()
+	tests := []struct {
+		name         string
+		templateFile string
+		want         oerrors.Code
+	}{
+		{"missing file", filepath.Join(tempDir, "missing"), oerrors.CodeInvalidInput},
+		{"unreadable file", tempDir, oerrors.CodeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Format{}
+			opts.SetTypes(FormatTypeText, FormatTypeGoTemplate)
+			opts.FormatFlag = FormatTypeGoTemplate.Name
+			opts.templateFile = tt.templateFile
+			err := opts.Parse(&cobra.Command{})
+			if err == nil {
+				t.Fatal("Format.Parse() error = nil, want error")
+			}
+			if got := oerrors.Classify(err); got != tt.want {
+				t.Errorf("Classify(Format.Parse()) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/ratelimit"
+)
+
+const (
+	limitRateFlag         = "limit-rate"
+	limitUploadRateFlag   = "limit-upload-rate"
+	limitDownloadRateFlag = "limit-download-rate"
+)
+
+// RateLimit option struct.
+type RateLimit struct {
+	LimitRate         string
+	LimitUploadRate   string
+	LimitDownloadRate string
+
+	limits *ratelimit.Limits
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *RateLimit) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&opts.LimitRate, limitRateFlag, "", "[Experimental] limit the total bandwidth of all transfers in `bytes/s`, with an optional K, M or G suffix, e.g. 10M")
+	fs.StringVar(&opts.LimitUploadRate, limitUploadRateFlag, "", "[Experimental] limit the bandwidth of all uploads in `bytes/s`, with an optional K, M or G suffix")
+	fs.StringVar(&opts.LimitDownloadRate, limitDownloadRateFlag, "", "[Experimental] limit the bandwidth of all downloads in `bytes/s`, with an optional K, M or G suffix")
+}
+
+// Parse parses the rate limits.
+func (opts *RateLimit) Parse(_ *cobra.Command) error {
+	var limits ratelimit.Limits
+	for _, limit := range []struct {
+		flag    string
+		value   string
+		limiter **ratelimit.Limiter
+	}{
+		{limitRateFlag, opts.LimitRate, &limits.Total},
+		{limitUploadRateFlag, opts.LimitUploadRate, &limits.Upload},
+		{limitDownloadRateFlag, opts.LimitDownloadRate, &limits.Download},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		rate, err := ParseSize(limit.value)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid value %q for flag --%s: %w", limit.value, limit.flag, err),
+				Recommendation: fmt.Sprintf("Please specify a positive number of bytes per second, e.g. --%s 512K", limit.flag),
+			}
+		}
+		*limit.limiter = ratelimit.NewLimiter(rate)
+	}
+	if limits != (ratelimit.Limits{}) {
+		opts.limits = &limits
+	}
+	return nil
+}
+
+// WithRateLimit returns a context carrying the rate limits so that all
+// transfers made with the context share the same bandwidth.
+func (opts *RateLimit) WithRateLimit(ctx context.Context) context.Context {
+	if opts.limits == nil {
+		return ctx
+	}
+	return ratelimit.WithLimits(ctx, opts.limits)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/internal/ratelimit"
+)
+
+func TestRateLimit_WithRateLimit(t *testing.T) {
+	ctx := context.Background()
+	opts := RateLimit{}
+	if err := opts.Parse(&cobra.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := ratelimit.LimitsFromContext(opts.WithRateLimit(ctx)); got != nil {
+		t.Errorf("expected no limits, got %v", got)
+	}
+
+	opts = RateLimit{LimitRate: "1M", LimitUploadRate: "512K"}
+	if err := opts.Parse(&cobra.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	got := ratelimit.LimitsFromContext(opts.WithRateLimit(ctx))
+	if got == nil || got.Total == nil || got.Upload == nil || got.Download != nil {
+		t.Errorf("unexpected limits: %+v", got)
+	}
+
+	opts = RateLimit{LimitDownloadRate: "fast"}
+	if err := opts.Parse(&cobra.Command{}); err == nil {
+		t.Error("RateLimit.Parse() expected error for invalid rate")
+	}
+}
@@ -38,9 +38,12 @@ import (
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras-go/v2/registry/remote/retry"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/chunk"
 	"oras.land/oras/internal/credential"
 	"oras.land/oras/internal/crypto"
 	onet "oras.land/oras/internal/net"
+	"oras.land/oras/internal/ratelimit"
+	"oras.land/oras/internal/segment"
 	"oras.land/oras/internal/trace"
 	"oras.land/oras/internal/version"
 )
@@ -261,15 +264,26 @@ func (remo *Remote) authClient(registry string, debug bool) (client *auth.Client
 		return nil, err
 	}
 	baseTransport.DialContext = dialContext
+	chunkTransport := chunk.NewTransport(retry.NewTransport(ratelimit.NewTransport(baseTransport)))
+	segmentTransport := segment.NewTransport(chunkTransport)
 	client = &auth.Client{
 		Client: &http.Client{
 			// http.RoundTripper with a retry using the DefaultPolicy
 			// see: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/retry#Policy
-			Transport: retry.NewTransport(baseTransport),
+			// with each request recorded as a span when tracing is enabled and
+			// the bodies throttled by the rate limits in the request context,
+			// large blobs downloaded in segments and uploaded in chunks with the
+			// options in the request context, refusing all requests in offline
+			// mode
+			Transport: onet.NewOfflineTransport(trace.NewSpanTransport(segmentTransport)),
 		},
 		Cache:  auth.NewCache(),
 		Header: remo.headers,
 	}
+	// chunks are uploaded and segments downloaded through the auth client to
+	// refresh expired tokens
+	chunkTransport.Client = client
+	segmentTransport.Client = client
 	client.SetUserAgent("oras/" + version.GetVersion())
 	if debug {
 		client.Client.Transport = trace.NewTransport(client.Client.Transport)
@@ -281,16 +295,28 @@ func (remo *Remote) authClient(registry string, debug bool) (client *auth.Client
 			return cred, nil
 		}
 	} else {
-		var err error
-		remo.store, err = credential.NewStore(remo.Configs...)
+		store, err := remo.CredentialStore()
 		if err != nil {
 			return nil, err
 		}
-		client.Credential = credentials.Credential(remo.store)
+		client.Credential = credentials.Credential(store)
 	}
 	return
 }
 
+// CredentialStore returns the credential store backed by the registry config
+// files, which is initialized on first use.
+func (remo *Remote) CredentialStore() (credentials.Store, error) {
+	if remo.store == nil {
+		store, err := credential.NewStore(remo.Configs...)
+		if err != nil {
+			return nil, err
+		}
+		remo.store = store
+	}
+	return remo.store, nil
+}
+
 // ConfigPath returns the config path of the credential store.
 func (remo *Remote) ConfigPath() (string, error) {
 	if remo.store == nil {
@@ -350,7 +376,7 @@ func (remo *Remote) NewRegistry(registry string, common Common, logger logrus.Fi
 	registry = reg.Reference.Registry
 	reg.PlainHTTP = remo.isPlainHttp(registry)
 	reg.HandleWarning = remo.handleWarning(registry, logger)
-	if reg.Client, err = remo.authClient(registry, common.Debug); err != nil {
+	if reg.Client, err = remo.authClient(registry, common.traceRequests()); err != nil {
 		return nil, err
 	}
 	return
@@ -368,7 +394,7 @@ func (remo *Remote) NewRepository(reference string, common Common, logger logrus
 	registry := repo.Reference.Registry
 	repo.PlainHTTP = remo.isPlainHttp(registry)
 	repo.HandleWarning = remo.handleWarning(registry, logger)
-	if repo.Client, err = remo.authClient(registry, common.Debug); err != nil {
+	if repo.Client, err = remo.authClient(registry, common.traceRequests()); err != nil {
 		return nil, err
 	}
 	repo.SkipReferrersGC = true
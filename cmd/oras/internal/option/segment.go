@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/segment"
+)
+
+const (
+	segmentsFlag         = "segments"
+	segmentThresholdFlag = "segment-threshold"
+)
+
+// Segment option struct.
+type Segment struct {
+	Segments         int
+	SegmentThreshold string
+
+	options *segment.Options
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Segment) ApplyFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&opts.Segments, segmentsFlag, 1, "[Experimental] number of concurrent range requests to download a single large blob with, if the registry accepts range requests")
+	fs.StringVar(&opts.SegmentThreshold, segmentThresholdFlag, "64M", "[Experimental] minimum `size` of a blob to be downloaded in segments, with an optional K, M or G suffix")
+}
+
+// Parse parses the segmented download options.
+func (opts *Segment) Parse(_ *cobra.Command) error {
+	if opts.Segments < 1 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid value %d for flag --%s: must be positive", opts.Segments, segmentsFlag),
+			Recommendation: fmt.Sprintf("Please specify a number of segments greater than 1 to enable segmented downloads, e.g. --%s 4", segmentsFlag),
+		}
+	}
+	if opts.Segments == 1 {
+		return nil
+	}
+	threshold, err := ParseSize(opts.SegmentThreshold)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid value %q for flag --%s: %w", opts.SegmentThreshold, segmentThresholdFlag, err),
+			Recommendation: fmt.Sprintf("Please specify a positive number of bytes, e.g. --%s 256M", segmentThresholdFlag),
+		}
+	}
+	opts.options = &segment.Options{
+		Segments:  opts.Segments,
+		Threshold: threshold,
+	}
+	return nil
+}
+
+// WithSegments returns a context carrying the segmented download options so
+// that large blobs fetched with the context are downloaded in segments.
+func (opts *Segment) WithSegments(ctx context.Context) context.Context {
+	if opts.options == nil {
+		return ctx
+	}
+	return segment.WithOptions(ctx, opts.options)
+}
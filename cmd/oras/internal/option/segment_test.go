@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/internal/segment"
+)
+
+func TestSegment_WithSegments(t *testing.T) {
+	ctx := context.Background()
+	opts := Segment{Segments: 1, SegmentThreshold: "64M"}
+	if err := opts.Parse(&cobra.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := segment.OptionsFromContext(opts.WithSegments(ctx)); got != nil {
+		t.Errorf("expected no options, got %v", got)
+	}
+
+	opts = Segment{Segments: 4, SegmentThreshold: "1G"}
+	if err := opts.Parse(&cobra.Command{}); err != nil {
+		t.Fatal(err)
+	}
+	want := segment.Options{Segments: 4, Threshold: 1 << 30}
+	if got := segment.OptionsFromContext(opts.WithSegments(ctx)); got == nil || *got != want {
+		t.Errorf("unexpected options: %+v, want %+v", got, want)
+	}
+
+	opts = Segment{Segments: 0, SegmentThreshold: "64M"}
+	if err := opts.Parse(&cobra.Command{}); err == nil {
+		t.Error("Segment.Parse() expected error for non-positive segments")
+	}
+
+	opts = Segment{Segments: 4, SegmentThreshold: "large"}
+	if err := opts.Parse(&cobra.Command{}); err == nil {
+		t.Error("Segment.Parse() expected error for invalid threshold")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits are the supported suffixes of a size or a rate, in the same fashion
+// as curl.
+var sizeUnits = map[string]float64{
+	"":  1,
+	"K": 1 << 10,
+	"M": 1 << 20,
+	"G": 1 << 30,
+	"T": 1 << 40,
+}
+
+// ParseSize parses a positive size in bytes, or a rate in bytes per second,
+// with an optional K, M, G or T binary unit suffix.
+func ParseSize(value string) (int64, error) {
+	number := strings.TrimSpace(value)
+	unit := ""
+	if n := len(number); n > 0 {
+		if suffix := strings.ToUpper(number[n-1:]); sizeUnits[suffix] != 0 {
+			number, unit = number[:n-1], suffix
+		}
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("not a number")
+	}
+	size := int64(f * sizeUnits[unit])
+	if size <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return size, nil
+}
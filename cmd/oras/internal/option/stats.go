@@ -0,0 +1,40 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"github.com/spf13/pflag"
+	"oras.land/oras/internal/stats"
+)
+
+// Stats option struct.
+type Stats struct {
+	PrintStats bool
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Stats) ApplyFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&opts.PrintStats, "stats", false, "[Experimental] print a summary of transfer statistics when the operation completes")
+}
+
+// NewCollector returns a transfer statistics collector if statistics are
+// requested, otherwise nil.
+func (opts *Stats) NewCollector() *stats.Collector {
+	if !opts.PrintStats {
+		return nil
+	}
+	return stats.NewCollector()
+}
@@ -207,7 +207,7 @@ func (target *Target) NewReadonlyTarget(ctx context.Context, common Common, logg
 		info, err := os.Stat(target.Path)
 		if err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
-				return nil, fmt.Errorf("invalid argument %q: failed to find path %q: %w", target.RawReference, target.Path, err)
+				return nil, oerrors.InvalidInput(fmt.Errorf("invalid argument %q: failed to find path %q: %w", target.RawReference, target.Path, err))
 			}
 			return nil, err
 		}
@@ -277,7 +277,8 @@ func (target *Target) ModifyError(cmd *cobra.Command, err error) (error, bool) {
 
 	cmd.SetErrPrefix(oerrors.RegistryErrorPrefix)
 	ret := &oerrors.Error{
-		Err: oerrors.ReportErrResp(errResp),
+		Err:       oerrors.ReportErrResp(errResp),
+		Reference: target.RawReference,
 	}
 
 	if ref.Registry == "docker.io" && errResp.StatusCode == http.StatusUnauthorized {
@@ -16,33 +16,74 @@ limitations under the License.
 package option
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/term"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 )
 
 const NoTTYFlag = "no-tty"
 
+// Progress output types.
+const (
+	// ProgressTypeAuto shows a progress bar for each blob when STDERR is a
+	// terminal.
+	ProgressTypeAuto = "auto"
+	// ProgressTypeCompact shows the overall progress followed by the blobs in
+	// progress when STDERR is a terminal.
+	ProgressTypeCompact = "compact"
+	// ProgressTypeAggregate shows the overall progress only when STDERR is a
+	// terminal.
+	ProgressTypeAggregate = "aggregate"
+	// ProgressTypeJSON writes progress events as newline-delimited JSON.
+	ProgressTypeJSON = "json"
+)
+
 // Terminal option struct.
 type Terminal struct {
 	TTY *os.File
+	// ProgressEvents receives newline-delimited JSON progress events if
+	// --progress=json is used, and is nil otherwise.
+	ProgressEvents io.Writer
 
-	noTTY       bool
-	ttyEnforced bool
+	noTTY        bool
+	ttyEnforced  bool
+	progress     string
+	progressFile string
+	// file is the opened --progress-file, if any.
+	file *os.File
+	// noProgressFlags is set for commands without transfers to report.
+	noProgressFlags bool
+}
+
+// DisableProgressFlags disables the progress flags for commands without
+// transfers to report.
+func (opts *Terminal) DisableProgressFlags() {
+	opts.noProgressFlags = true
 }
 
 // ApplyFlags applies flags to a command flag set.
 func (opts *Terminal) ApplyFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&opts.noTTY, NoTTYFlag, "", false, "[Preview] disable progress bars")
+	if opts.noProgressFlags {
+		return
+	}
+	fs.StringVar(&opts.progress, "progress", ProgressTypeAuto, fmt.Sprintf("[Preview] progress output type, options: %q, %q, %q, %q", ProgressTypeAuto, ProgressTypeCompact, ProgressTypeAggregate, ProgressTypeJSON))
+	fs.StringVar(&opts.progressFile, "progress-file", "", "[Preview] write progress events to the file instead of STDERR, used with --progress=json")
 }
 
 // Parse parses the input notty flag.
 func (opts *Terminal) Parse(cmd *cobra.Command) error {
+	if err := opts.parseProgress(cmd); err != nil {
+		return err
+	}
 	opts.ttyEnforced = cmd.Flags().Changed(NoTTYFlag) && !opts.noTTY
 	// use STDERR as TTY output since STDOUT is reserved for pipeable output
-	if !opts.noTTY {
+	if !opts.noTTY && opts.ProgressEvents == nil {
 		f := os.Stderr
 		if term.IsTerminal(int(f.Fd())) {
 			opts.TTY = f
@@ -62,3 +103,55 @@ func (opts *Terminal) DisableTTY(debugEnabled, toSTDOUT bool) {
 		opts.TTY = nil
 	}
 }
+
+// Close closes the progress file opened by Parse, if any.
+func (opts *Terminal) Close() error {
+	if opts.file == nil {
+		return nil
+	}
+	err := opts.file.Close()
+	opts.file = nil
+	return err
+}
+
+// ProgressType returns the progress output type.
+func (opts *Terminal) ProgressType() string {
+	if opts.progress == "" {
+		return ProgressTypeAuto
+	}
+	return opts.progress
+}
+
+// parseProgress parses the progress output type and opens the destination of
+// progress events.
+func (opts *Terminal) parseProgress(cmd *cobra.Command) error {
+	switch opts.progress {
+	case "", ProgressTypeAuto, ProgressTypeCompact, ProgressTypeAggregate:
+		if opts.progressFile != "" {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("--progress-file cannot be used with --progress=%s", opts.ProgressType()),
+				Recommendation: fmt.Sprintf("Use --progress=%s to write progress events to a file", ProgressTypeJSON),
+			}
+		}
+		return nil
+	case ProgressTypeJSON:
+		if opts.progressFile == "" {
+			opts.ProgressEvents = cmd.ErrOrStderr()
+			return nil
+		}
+		// events are written to the file without buffering, and the file is
+		// closed by Close when the command finishes.
+		f, err := os.Create(opts.progressFile)
+		if err != nil {
+			return fmt.Errorf("failed to create the progress file: %w", err)
+		}
+		opts.file = f
+		opts.ProgressEvents = f
+		return nil
+	default:
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid progress type: %q", opts.progress),
+			Recommendation: fmt.Sprintf("Available progress types: %q, %q, %q, %q", ProgressTypeAuto, ProgressTypeCompact, ProgressTypeAggregate, ProgressTypeJSON),
+		}
+	}
+}
@@ -18,7 +18,10 @@ limitations under the License.
 package option
 
 import (
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -88,3 +91,98 @@ func TestTerminal_DisableTTY(t *testing.T) {
 		})
 	}
 }
+
+func TestTerminal_Parse_progress(t *testing.T) {
+	progressFile := filepath.Join(t.TempDir(), "progress.json")
+	tests := []struct {
+		name       string
+		args       []string
+		wantEvents bool
+		wantErr    bool
+	}{
+		{"default", nil, false, false},
+		{"auto", []string{"--progress", ProgressTypeAuto}, false, false},
+		{"compact", []string{"--progress", ProgressTypeCompact}, false, false},
+		{"aggregate", []string{"--progress", ProgressTypeAggregate}, false, false},
+		{"json to stderr", []string{"--progress", ProgressTypeJSON}, true, false},
+		{"json to file", []string{"--progress", ProgressTypeJSON, "--progress-file", progressFile}, true, false},
+		{"file without json", []string{"--progress-file", progressFile}, false, true},
+		{"invalid type", []string{"--progress", "bars"}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts Terminal
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Terminal.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := opts.ProgressEvents != nil; got != tt.wantEvents {
+				t.Errorf("Terminal.ProgressEvents set = %v, want %v", got, tt.wantEvents)
+			}
+			if tt.wantEvents && opts.TTY != nil {
+				t.Error("Terminal.TTY should not be set with progress events")
+			}
+			if err := opts.Close(); err != nil {
+				t.Errorf("Terminal.Close() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTerminal_Close(t *testing.T) {
+	progressFile := filepath.Join(t.TempDir(), "progress.json")
+	var opts Terminal
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Parse([]string{"--progress", ProgressTypeJSON, "--progress-file", progressFile}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatalf("Terminal.Parse() error = %v", err)
+	}
+	if _, err := io.WriteString(opts.ProgressEvents, "{}\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Close(); err != nil {
+		t.Fatalf("Terminal.Close() error = %v", err)
+	}
+	if _, err := io.WriteString(opts.ProgressEvents, "{}\n"); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("write after Terminal.Close() error = %v, want %v", err, os.ErrClosed)
+	}
+	if err := opts.Close(); err != nil {
+		t.Errorf("second Terminal.Close() error = %v", err)
+	}
+	got, err := os.ReadFile(progressFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "{}\n" {
+		t.Errorf("progress file = %q, want %q", got, "{}\n")
+	}
+}
+
+func TestTerminal_DisableProgressFlags(t *testing.T) {
+	var opts Terminal
+	opts.DisableProgressFlags()
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	for _, name := range []string{"progress", "progress-file"} {
+		if cmd.Flags().Lookup(name) != nil {
+			t.Errorf("flag --%s is registered", name)
+		}
+	}
+	if cmd.Flags().Lookup(NoTTYFlag) == nil {
+		t.Errorf("flag --%s is not registered", NoTTYFlag)
+	}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatalf("Terminal.Parse() error = %v", err)
+	}
+	if opts.ProgressEvents != nil {
+		t.Error("Terminal.ProgressEvents should not be set")
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"oras.land/oras/internal/trace"
+	"oras.land/oras/internal/version"
+)
+
+const (
+	traceFileFlag     = "trace-file"
+	traceEndpointFlag = "trace-endpoint"
+
+	// traceFileEnv is the environment variable for the trace file path.
+	traceFileEnv = "ORAS_TRACE_FILE"
+	// traceEndpointEnv is the environment variable for the OTLP/HTTP endpoint.
+	traceEndpointEnv = "ORAS_TRACE_ENDPOINT"
+)
+
+// Trace option struct.
+type Trace struct {
+	TraceFile     string
+	TraceEndpoint string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Trace) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&opts.TraceFile, traceFileFlag, "", "[Experimental] write tracing spans in OTLP-JSON format to the file at `path`, can also be set via "+traceFileEnv)
+	fs.StringVar(&opts.TraceEndpoint, traceEndpointFlag, "", "[Experimental] send tracing spans to the OTLP/HTTP collector at `url`, can also be set via "+traceEndpointEnv)
+}
+
+// Parse falls back to environment variables for the flags not set.
+func (opts *Trace) Parse(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed(traceFileFlag) {
+		opts.TraceFile = os.Getenv(traceFileEnv)
+	}
+	if !cmd.Flags().Changed(traceEndpointFlag) {
+		opts.TraceEndpoint = os.Getenv(traceEndpointEnv)
+	}
+	return nil
+}
+
+// NewTracer returns a tracer exporting to the configured destinations, or nil
+// if tracing is not enabled.
+func (opts *Trace) NewTracer(cmd *cobra.Command) (*trace.Tracer, error) {
+	var exporters []trace.Exporter
+	if opts.TraceFile != "" {
+		exporters = append(exporters, trace.NewFileExporter(opts.TraceFile))
+	}
+	if opts.TraceEndpoint != "" {
+		exporter, err := trace.NewHTTPExporter(opts.TraceEndpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, exporter)
+	}
+	if len(exporters) == 0 {
+		return nil, nil
+	}
+	resource := []trace.Attribute{
+		trace.String("service.name", "oras"),
+		trace.String("service.version", version.GetVersion()),
+		trace.String("process.command", cmd.CommandPath()),
+	}
+	return trace.NewTracer(resource, exporters...), nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestTrace_Parse(t *testing.T) {
+	t.Setenv(traceFileEnv, "env.json")
+	t.Setenv(traceEndpointEnv, "http://localhost:4318")
+	opts := Trace{}
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Set(traceFileFlag, "flag.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatal("Trace.Parse() error =", err)
+	}
+	if opts.TraceFile != "flag.json" {
+		t.Errorf("Trace.TraceFile = %q, want %q", opts.TraceFile, "flag.json")
+	}
+	if opts.TraceEndpoint != "http://localhost:4318" {
+		t.Errorf("Trace.TraceEndpoint = %q, want %q", opts.TraceEndpoint, "http://localhost:4318")
+	}
+}
+
+func TestTrace_NewTracer(t *testing.T) {
+	cmd := &cobra.Command{Use: "oras"}
+	opts := Trace{}
+	tracer, err := opts.NewTracer(cmd)
+	if err != nil || tracer != nil {
+		t.Fatalf("Trace.NewTracer() = %v, %v, want nil tracer when disabled", tracer, err)
+	}
+
+	opts.TraceFile = "trace.json"
+	if tracer, err = opts.NewTracer(cmd); err != nil || tracer == nil {
+		t.Fatalf("Trace.NewTracer() = %v, %v, want a tracer", tracer, err)
+	}
+
+	opts.TraceEndpoint = "localhost:4318"
+	if _, err = opts.NewTracer(cmd); err == nil {
+		t.Fatal("Trace.NewTracer() expected error for invalid endpoint")
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/descriptor"
+)
+
+// funcMap returns the ORAS specific template functions.
+// Template data is decoded from JSON, so the functions accept the decoded
+// values, i.e. float64 for numbers and map[string]any for objects.
+//
+//   - humanSize SIZE: formats a size in bytes, e.g. "1.5 MB".
+//   - shortDigest DIGEST|DESCRIPTOR: returns the first 12 characters of a
+//     sha256 digest, or the full digest for other algorithms.
+//   - platform PLATFORM|DESCRIPTOR: formats a platform as os/arch[/variant].
+//   - annotation DESCRIPTOR KEY [DEFAULT]: returns the annotation value of KEY,
+//     or DEFAULT if not annotated.
+//   - created DESCRIPTOR: parses the "org.opencontainers.image.created"
+//     annotation as time. Zero time is returned if not annotated.
+func funcMap() template.FuncMap {
+	return template.FuncMap{
+		"humanSize":   humanSize,
+		"shortDigest": shortDigest,
+		"platform":    platform,
+		"annotation":  annotation,
+		"created":     created,
+	}
+}
+
+// humanSize formats size in bytes in a human-readable format.
+func humanSize(size any) (string, error) {
+	var bytes int64
+	switch v := size.(type) {
+	case float64:
+		bytes = int64(v)
+	case int:
+		bytes = int64(v)
+	case int64:
+		bytes = v
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return "", err
+		}
+		bytes = n
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("humanSize: invalid size %q", v)
+		}
+		bytes = n
+	default:
+		return "", fmt.Errorf("humanSize: unsupported type %T", size)
+	}
+	b := humanize.ToBytes(bytes)
+	return fmt.Sprintf("%g %s", b.Size, b.Unit), nil
+}
+
+// shortDigest returns the short form of a digest or the digest of a
+// descriptor.
+func shortDigest(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return descriptor.ShortDigest(ocispec.Descriptor{Digest: digest.Digest(v)}), nil
+	case map[string]any:
+		dgst, _ := v["digest"].(string)
+		if dgst == "" {
+			return "", fmt.Errorf("shortDigest: no digest found")
+		}
+		return descriptor.ShortDigest(ocispec.Descriptor{Digest: digest.Digest(dgst)}), nil
+	default:
+		return "", fmt.Errorf("shortDigest: unsupported type %T", v)
+	}
+}
+
+// platform formats a platform, or the platform of a descriptor, as
+// os/arch[/variant]. An empty string is returned if no platform is found.
+func platform(v any) (string, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		if v == nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("platform: unsupported type %T", v)
+	}
+	if p, ok := m["platform"].(map[string]any); ok {
+		m = p
+	}
+	var p ocispec.Platform
+	p.OS, _ = m["os"].(string)
+	p.Architecture, _ = m["architecture"].(string)
+	if p.OS == "" && p.Architecture == "" {
+		return "", nil
+	}
+	p.Variant, _ = m["variant"].(string)
+	return descriptor.PlatformString(&p), nil
+}
+
+// annotation returns the annotation value of key from an object with
+// annotations, or an annotation map. defaultValue is returned if the key is
+// not annotated.
+func annotation(v any, key string, defaultValue ...string) (string, error) {
+	if len(defaultValue) > 1 {
+		return "", fmt.Errorf("annotation: at most one default value is allowed")
+	}
+	if m, ok := v.(map[string]any); ok {
+		annotations := m
+		if a, ok := m["annotations"].(map[string]any); ok {
+			annotations = a
+		}
+		if value, ok := annotations[key].(string); ok {
+			return value, nil
+		}
+	} else if v != nil {
+		return "", fmt.Errorf("annotation: unsupported type %T", v)
+	}
+	if len(defaultValue) == 1 {
+		return defaultValue[0], nil
+	}
+	return "", nil
+}
+
+// created parses the creation time annotation of an object.
+func created(v any) (time.Time, error) {
+	value, err := annotation(v, ocispec.AnnotationCreated)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("created: invalid time %q: %w", value, err)
+	}
+	return t, nil
+}
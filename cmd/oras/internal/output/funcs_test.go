@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseAndWrite_funcs(t *testing.T) {
+	object := map[string]any{
+		"digest": "sha256:9b5fb4ec1ba6bb0ed1b2ff6b1e2a2fb3b83b9d0f1fd31d33f0e4d4b1d31e5d52",
+		"size":   1536,
+		"platform": map[string]any{
+			"os":           "linux",
+			"architecture": "arm64",
+			"variant":      "v8",
+		},
+		"annotations": map[string]any{
+			"org.opencontainers.image.title":   "hello.txt",
+			"org.opencontainers.image.created": "2024-03-01T08:09:10Z",
+		},
+		"manifests": []any{
+			map[string]any{
+				"digest": "sha512:abc",
+				"platform": map[string]any{
+					"os":           "windows",
+					"architecture": "amd64",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"humanSize", "{{humanSize .size}}", "1.5 KB", false},
+		{"humanSize small", "{{humanSize 12}}", "12 B", false},
+		{"humanSize invalid", `{{humanSize "x"}}`, "", true},
+		{"shortDigest of digest", "{{shortDigest .digest}}", "9b5fb4ec1ba6", false},
+		{"shortDigest of descriptor", "{{shortDigest .}}", "9b5fb4ec1ba6", false},
+		{"shortDigest of other algorithm", "{{range .manifests}}{{shortDigest .}}{{end}}", "sha512:abc", false},
+		{"shortDigest without digest", "{{shortDigest .platform}}", "", true},
+		{"platform of descriptor", "{{platform .}}", "linux/arm64/v8", false},
+		{"platform", "{{range .manifests}}{{platform .platform}}{{end}}", "windows/amd64", false},
+		{"platform missing", "{{platform .annotations}}", "", false},
+		{"annotation", `{{annotation . "org.opencontainers.image.title"}}`, "hello.txt", false},
+		{"annotation with default", `{{annotation . "missing" "none"}}`, "none", false},
+		{"annotation of map", `{{annotation .annotations "org.opencontainers.image.title" "none"}}`, "hello.txt", false},
+		{"annotation of missing map", `{{annotation .missing "key" "none"}}`, "none", false},
+		{"annotation with extra default", `{{annotation . "key" "a" "b"}}`, "", true},
+		{"created", `{{(created .).Year}}`, "2024", false},
+		{"created with sprig", `{{created . | date "2006-01-02"}}`, "2024-03-01", false},
+		{"created missing", `{{(created .platform).IsZero}}`, "true", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := ParseAndWrite(&buf, object, tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAndWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && buf.String() != tt.want {
+				t.Errorf("ParseAndWrite() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAndWrite_invalidCreated(t *testing.T) {
+	object := map[string]any{
+		"annotations": map[string]any{
+			"org.opencontainers.image.created": "yesterday",
+		},
+	}
+	var buf bytes.Buffer
+	if err := ParseAndWrite(&buf, object, "{{created .}}"); err == nil {
+		t.Error("ParseAndWrite() error = nil, want error")
+	}
+}
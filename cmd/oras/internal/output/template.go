@@ -22,9 +22,11 @@ import (
 	"github.com/Masterminds/sprig/v3"
 )
 
+// ParseAndWrite executes the template on object and writes the result to out.
+// Sprig functions and ORAS specific functions are available in the template.
 func ParseAndWrite(out io.Writer, object any, templateStr string) error {
 	// parse template
-	t, err := template.New("format output").Funcs(sprig.FuncMap()).Parse(templateStr)
+	t, err := template.New("format output").Funcs(sprig.FuncMap()).Funcs(funcMap()).Parse(templateStr)
 	if err != nil {
 		return err
 	}
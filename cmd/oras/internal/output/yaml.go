@@ -0,0 +1,55 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrintYAML prints the object to the writer in YAML format.
+// The object is converted with its json tags so that the YAML output has the
+// same keys, in the same order, as the JSON output.
+func PrintYAML(out io.Writer, object any) error {
+	content, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	// JSON is valid YAML, and decoding it into a node keeps the key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetStyle clears the flow and quoting styles inherited from JSON so that
+// the node is encoded in block style. Scalars which would be misread without
+// quotes are still quoted by the encoder.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
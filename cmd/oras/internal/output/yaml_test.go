@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_PrintYAML(t *testing.T) {
+	type file struct {
+		Path        string            `json:"path"`
+		Size        int64             `json:"size"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+	type model struct {
+		Reference string   `json:"reference"`
+		Files     []file   `json:"files"`
+		Tags      []string `json:"tags"`
+		Empty     string   `json:"empty,omitempty"`
+	}
+	given := model{
+		Reference: "localhost:5000/test@sha256:9a2f",
+		Files: []file{
+			{Path: "hi.txt", Size: 3, Annotations: map[string]string{"b": "true", "a": "1"}},
+		},
+		Tags: []string{"v1", "123"},
+	}
+	expected := `reference: localhost:5000/test@sha256:9a2f
+files:
+  - path: hi.txt
+    size: 3
+    annotations:
+      a: "1"
+      b: "true"
+tags:
+  - v1
+  - "123"
+`
+	builder := &strings.Builder{}
+	if err := PrintYAML(builder, given); err != nil {
+		t.Fatal("PrintYAML() error =", err)
+	}
+	if actual := builder.String(); actual != expected {
+		t.Errorf("PrintYAML() = %q, want %q", actual, expected)
+	}
+}
@@ -20,17 +20,27 @@ import (
 	"os"
 	"os/signal"
 
+	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/cmd/oras/root"
 )
 
-func run() error {
+func run() (*cobra.Command, error) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
-	return root.New().ExecuteContext(ctx)
+	return root.New().ExecuteContextC(ctx)
 }
 
 func main() {
-	if err := run(); err != nil {
-		os.Exit(1)
+	if cmd, err := run(); err != nil {
+		_ = oerrors.Print(cmd, err, jsonOutput(cmd))
+		os.Exit(oerrors.ExitCode(err))
 	}
 }
+
+// jsonOutput returns true if cmd is requested to output JSON.
+func jsonOutput(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup("format")
+	return f != nil && f.Value.String() == option.FormatTypeJSON.Name
+}
@@ -32,16 +32,19 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/ratelimit"
 	"oras.land/oras/internal/registryutil"
 )
 
 type attachOptions struct {
+	option.Cache
 	option.Common
 	option.Packer
 	option.Target
 	option.Format
 	option.Platform
 	option.Terminal
+	option.RateLimit
 
 	artifactType string
 	concurrency  int
@@ -114,6 +117,7 @@ Example - Attach file to the manifest tagged 'example.com:v1' in an OCI image la
 			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = opts.verbose
 			return runAttach(cmd, &opts)
 		},
@@ -126,13 +130,14 @@ Example - Attach file to the manifest tagged 'example.com:v1' in an OCI image la
 	_ = cmd.MarkFlagRequired("artifact-type")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.EnableDistributionSpecFlag()
-	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
 	if len(opts.FileRefs) == 0 && len(opts.Annotations[option.AnnotationManifest]) == 0 {
 		return &oerrors.Error{
 			Err:            errors.New(`neither file nor annotation provided in the command`),
@@ -157,11 +162,15 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
 	fetchOpts := oras.DefaultResolveOptions
 	fetchOpts.TargetPlatform = opts.Platform.Platform
-	subject, err := oras.Resolve(ctx, dst, opts.Reference, fetchOpts)
+	subjectSrc, err := opts.CachedGraphTarget(dst)
+	if err != nil {
+		return err
+	}
+	subject, err := oras.Resolve(ctx, subjectSrc, opts.Reference, fetchOpts)
 	if err != nil {
 		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
 	}
-	statusHandler, metadataHandler, err := display.NewAttachHandler(opts.Printer, opts.Format, opts.TTY, store)
+	statusHandler, metadataHandler, err := display.NewAttachHandler(opts.Printer, opts.Format, opts.Terminal, store)
 	if err != nil {
 		return err
 	}
@@ -171,6 +180,10 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	}
 
 	// prepare push
+	if opts.Target.Type == option.TargetTypeOCILayout {
+		// pushes to OCI layouts are not throttled by the transport
+		dst = ratelimit.NewTarget(dst, ratelimit.UploadReader)
+	}
 	dst, stopTrack, err := statusHandler.TrackTarget(dst)
 	if err != nil {
 		return err
@@ -214,6 +227,10 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	if err != nil {
 		return err
 	}
+	// the cached referrers listing of the subject is now outdated
+	if err := opts.InvalidateReferrers(subjectSrc, subject); err != nil {
+		logger.Debugf("failed to invalidate cached referrers of %s: %v", subject.Digest, err)
+	}
 	metadataHandler.OnAttached(&opts.Target, root, subject)
 	err = metadataHandler.Render()
 	if err != nil {
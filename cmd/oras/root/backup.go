@@ -58,9 +58,13 @@ const (
 var errTagListNotSupported = errors.New("the target does not support tag listing")
 
 type backupOptions struct {
+	option.Cache
 	option.Common
 	option.Remote
+	option.Format
 	option.Terminal
+	option.RateLimit
+	option.Stats
 
 	// flags
 	output           string
@@ -110,6 +114,9 @@ Example - Back up from a registry using plain HTTP (no TLS):
 
 Example - Set custom concurrency level:
   oras backup --output hello --concurrency 6 localhost:5000/hello:v1
+
+Example - [Experimental] Back up and output the result in JSON format:
+  oras backup --output hello.tar --format json localhost:5000/hello:v1
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifacts to back up"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -135,6 +142,7 @@ Example - Set custom concurrency level:
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = true // always print verbose output
 			return runBackup(cmd, &opts)
 		},
@@ -148,6 +156,8 @@ Example - Set custom concurrency level:
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
 	opts.EnableDistributionSpecFlag()
 	// apply flags
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -157,7 +167,9 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 		return errors.New("the output path cannot be empty")
 	}
 	startTime := time.Now() // start timing the backup process
+	collector := opts.NewCollector()
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
 
 	var dstRoot string
 	switch opts.outputFormat {
@@ -200,14 +212,21 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to prepare repository %s for backup: %w", opts.repository, err)
 	}
+	src, err := opts.CachedGraphTarget(srcRepo)
+	if err != nil {
+		return err
+	}
 	dstOCI, err := oci.New(dstRoot)
 	if err != nil {
 		return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
 	}
-	statusHandler, metadataHandler := display.NewBackupHandler(opts.Printer, opts.TTY, opts.repository, dstOCI)
+	statusHandler, metadataHandler, err := display.NewBackupHandler(opts.Printer, opts.Format, opts.Terminal, opts.repository, dstOCI)
+	if err != nil {
+		return err
+	}
 
 	// Resolve tags to back up
-	tags, roots, err := resolveTags(ctx, srcRepo, opts.tags)
+	tags, roots, err := resolveTags(ctx, src, opts.tags)
 	if err != nil {
 		return err
 	}
@@ -227,6 +246,7 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 	copyGraphOpts.PreCopy = statusHandler.PreCopy
 	copyGraphOpts.PostCopy = statusHandler.PostCopy
 	copyGraphOpts.OnCopySkipped = statusHandler.OnCopySkipped
+	collector.TrackCopyGraph(&copyGraphOpts)
 	extCopyGraphOpts := oras.ExtendedCopyGraphOptions{
 		CopyGraphOptions: copyGraphOpts,
 		FindPredecessors: func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
@@ -234,6 +254,7 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 		},
 	}
 
+	endPull := collector.StartPhase("pull")
 	for i, tag := range tags {
 		referrerCount, err := func() (referrerCount int, retErr error) {
 			trackedDst, err := statusHandler.StartTracking(dstOCI)
@@ -248,9 +269,9 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 			}()
 
 			if opts.includeReferrers {
-				return backupTagWithReferrers(ctx, srcRepo, trackedDst, tag, roots[i], extCopyGraphOpts)
+				return backupTagWithReferrers(ctx, src, trackedDst, tag, roots[i], extCopyGraphOpts)
 			}
-			return 0, backupTag(ctx, srcRepo, trackedDst, tag, roots[i], copyGraphOpts)
+			return 0, backupTag(ctx, src, trackedDst, tag, roots[i], copyGraphOpts)
 		}()
 		if err != nil {
 			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, opts.repository, dstRoot, oerrors.UnwrapCopyError(err))
@@ -260,11 +281,24 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 		}
 	}
 
-	if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
+	endPull()
+
+	endExport := collector.StartPhase("export")
+	err = finalizeBackupOutput(dstRoot, opts, logger, metadataHandler)
+	endExport()
+	if err != nil {
 		return err
 	}
 	duration := time.Since(startTime)
-	return metadataHandler.OnBackupCompleted(len(tags), opts.output, duration)
+	if err := metadataHandler.OnBackupCompleted(len(tags), opts.output, duration); err != nil {
+		return err
+	}
+	if collector != nil {
+		if err := metadataHandler.OnStats(collector.Summary()); err != nil {
+			return err
+		}
+	}
+	return metadataHandler.Render()
 }
 
 // backupTag copies the artifact identified by the tag from src to dst.
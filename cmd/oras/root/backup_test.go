@@ -37,6 +37,7 @@ import (
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/internal/stats"
 )
 
 func TestParseArtifactReferences(t *testing.T) {
@@ -753,6 +754,10 @@ func (m *mockBackupHandler) OnBackupCompleted(tagsCount int, path string, durati
 	return nil
 }
 
+func (m *mockBackupHandler) OnStats(summary stats.Summary) error {
+	return nil
+}
+
 func (m *mockBackupHandler) Render() error {
 	return nil
 }
@@ -0,0 +1,272 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/bench"
+)
+
+type benchOptions struct {
+	option.Common
+	option.Format
+	option.Remote
+
+	repository  string
+	rawSizes    []string
+	sizes       []int64
+	count       int
+	concurrency []int
+}
+
+func benchCmd() *cobra.Command {
+	var opts benchOptions
+	cmd := &cobra.Command{
+		Use:   "bench [flags] <registry>/<repository>",
+		Short: "[Preview] Measure the throughput and latency of a registry",
+		Long: `[Preview] Measure the throughput and latency of a registry
+
+Pushes synthetic blobs of each size to the repository and pulls them back, at
+each concurrency level, using the same HTTP client stack as other commands.
+Reports the push and pull throughput, the latency percentiles of each request
+method and the overhead of fetching auth tokens. The pushed blobs are deleted
+afterwards, which requires the registry to support blob deletion.
+
+** This command is in preview and under development. **
+
+Example - Benchmark a repository with the default sizes and concurrency levels:
+  oras bench localhost:5000/bench
+
+Example - Benchmark pushing and pulling 16 blobs of 1 MiB and of 64 MiB each:
+  oras bench --size 1M,64M --count 16 localhost:5000/bench
+
+Example - Benchmark with 1, 4 and 8 concurrent requests:
+  oras bench --concurrency 1,4,8 localhost:5000/bench
+
+Example - [Experimental] Benchmark and output the result in JSON format:
+  oras bench --format json localhost:5000/bench
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to benchmark"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.repository = args[0]
+			if err := opts.parseBenchFlags(); err != nil {
+				return err
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBench(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.rawSizes, "size", []string{"1M", "16M"}, "`sizes` of the blobs to push and pull, with an optional K, M or G suffix")
+	cmd.Flags().IntVar(&opts.count, "count", 8, "`number` of blobs of each size to push and pull at each concurrency level")
+	cmd.Flags().IntSliceVar(&opts.concurrency, "concurrency", []int{1, 4}, "`levels` of concurrent requests to benchmark")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+// parseBenchFlags parses the sizes and validates the count and the
+// concurrency levels.
+func (opts *benchOptions) parseBenchFlags() error {
+	opts.sizes = make([]int64, 0, len(opts.rawSizes))
+	for _, raw := range opts.rawSizes {
+		size, err := option.ParseSize(raw)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid value %q for flag --size: %w", raw, err),
+				Recommendation: "Please specify positive sizes in bytes, e.g. --size 512K,4M",
+			}
+		}
+		opts.sizes = append(opts.sizes, size)
+	}
+	if opts.count <= 0 {
+		return fmt.Errorf("invalid value %d for flag --count: must be positive", opts.count)
+	}
+	for _, level := range opts.concurrency {
+		if level <= 0 {
+			return fmt.Errorf("invalid value %d for flag --concurrency: must be positive", level)
+		}
+	}
+	return nil
+}
+
+func runBench(cmd *cobra.Command, opts *benchOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewBenchHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	repo, err := opts.NewRepository(opts.repository, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	recorder, err := recordRequests(repo)
+	if err != nil {
+		return err
+	}
+
+	result := model.NewBench(repo.Reference.Registry + "/" + repo.Reference.Repository)
+	var pushed []ocispec.Descriptor
+	runErr := func() error {
+		seed := time.Now().UnixNano()
+		for _, size := range opts.sizes {
+			for _, concurrency := range opts.concurrency {
+				blobs := make([]bench.Blob, opts.count)
+				for i := range blobs {
+					if blobs[i], err = bench.NewBlob(seed, size); err != nil {
+						return err
+					}
+					seed++
+				}
+				// record blobs for cleanup even if some pushes fail
+				for _, blob := range blobs {
+					pushed = append(pushed, blob.Descriptor)
+				}
+				if err := benchRun(ctx, repo, recorder, blobs, concurrency, result); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}()
+	recorder.Take()
+	result.CleanupErrors = cleanupBlobs(context.WithoutCancel(ctx), repo, pushed)
+	if runErr != nil {
+		return runErr
+	}
+	if err := handler.OnBenchmarked(result); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// recordRequests wraps the transport of the repository client with a
+// recorder.
+func recordRequests(repo *remote.Repository) (*bench.Recorder, error) {
+	client, ok := repo.Client.(*auth.Client)
+	if !ok || client.Client == nil {
+		return nil, errors.New("unable to record requests of the registry client")
+	}
+	recorder := bench.NewRecorder(client.Client.Transport)
+	client.Client.Transport = recorder
+	return recorder, nil
+}
+
+// benchRun pushes and then pulls the blobs with the given concurrency, and
+// adds the throughput of each phase and the latencies of the requests to
+// result.
+func benchRun(ctx context.Context, repo *remote.Repository, recorder *bench.Recorder, blobs []bench.Blob, concurrency int, result *model.Bench) error {
+	run := model.BenchRun{
+		Size:        blobs[0].Descriptor.Size,
+		Count:       len(blobs),
+		Concurrency: concurrency,
+		Latencies:   []model.Latency{},
+	}
+	total := run.Size * int64(run.Count)
+
+	start := time.Now()
+	err := forEachBlob(ctx, blobs, concurrency, func(ctx context.Context, blob bench.Blob) error {
+		exists, err := repo.Exists(ctx, blob.Descriptor)
+		if err != nil || exists {
+			return err
+		}
+		return repo.Blobs().Push(ctx, blob.Descriptor, blob.Reader())
+	})
+	if err != nil {
+		return err
+	}
+	run.Push = model.NewThroughput(total, time.Since(start))
+
+	start = time.Now()
+	err = forEachBlob(ctx, blobs, concurrency, func(ctx context.Context, blob bench.Blob) error {
+		rc, err := repo.Blobs().Fetch(ctx, blob.Descriptor)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		vr := content.NewVerifyReader(rc, blob.Descriptor)
+		if _, err := io.Copy(io.Discard, vr); err != nil {
+			return err
+		}
+		return vr.Verify()
+	})
+	if err != nil {
+		return err
+	}
+	run.Pull = model.NewThroughput(total, time.Since(start))
+
+	samples := recorder.Take()
+	kinds := make([]string, 0, len(samples))
+	for kind := range samples {
+		if kind != bench.KindToken {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		s := bench.Summarize(samples[kind])
+		run.Latencies = append(run.Latencies, model.NewLatency(kind, s.Count, s.P50, s.P90, s.P99, s.Max))
+	}
+	tokens := bench.Summarize(samples[bench.KindToken])
+	result.AddTokenFetches(tokens.Count, tokens.Total)
+	result.Runs = append(result.Runs, run)
+	return nil
+}
+
+// forEachBlob calls fn on each blob with at most concurrency calls at a time.
+func forEachBlob(ctx context.Context, blobs []bench.Blob, concurrency int, fn func(context.Context, bench.Blob) error) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for _, blob := range blobs {
+		eg.Go(func() error {
+			return fn(egCtx, blob)
+		})
+	}
+	return eg.Wait()
+}
+
+// cleanupBlobs deletes the blobs and returns the errors as messages. Blobs
+// not found, such as those failed to be pushed, are ignored.
+func cleanupBlobs(ctx context.Context, repo *remote.Repository, blobs []ocispec.Descriptor) []string {
+	var errs []string
+	for _, desc := range blobs {
+		if err := repo.Blobs().Delete(ctx, desc); err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			errs = append(errs, fmt.Sprintf("%s: %v", desc.Digest, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,62 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_benchOptions_parseBenchFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      benchOptions
+		wantSizes []int64
+		wantErr   bool
+	}{
+		{
+			name:      "sizes with units",
+			opts:      benchOptions{rawSizes: []string{"512", "4K", "1.5M"}, count: 1, concurrency: []int{1, 8}},
+			wantSizes: []int64{512, 4 << 10, 3 << 19},
+		},
+		{
+			name:    "invalid size",
+			opts:    benchOptions{rawSizes: []string{"1X"}, count: 1, concurrency: []int{1}},
+			wantErr: true,
+		},
+		{
+			name:    "zero count",
+			opts:    benchOptions{rawSizes: []string{"1K"}, count: 0, concurrency: []int{1}},
+			wantErr: true,
+		},
+		{
+			name:    "negative concurrency",
+			opts:    benchOptions{rawSizes: []string{"1K"}, count: 1, concurrency: []int{4, -1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.parseBenchFlags()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBenchFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.opts.sizes, tt.wantSizes) {
+				t.Errorf("parseBenchFlags() sizes = %v, want %v", tt.opts.sizes, tt.wantSizes)
+			}
+		})
+	}
+}
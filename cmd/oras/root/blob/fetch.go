@@ -28,10 +28,13 @@ import (
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/progress"
+	"oras.land/oras/internal/resume"
 )
 
 type fetchBlobOptions struct {
@@ -41,6 +44,8 @@ type fetchBlobOptions struct {
 	option.Pretty
 	option.Target
 	option.Terminal
+	option.RateLimit
+	option.Segment
 
 	outputPath string
 }
@@ -52,12 +57,20 @@ func fetchCmd() *cobra.Command {
 		Short: "Fetch a blob from a registry or an OCI image layout",
 		Long: `Fetch a blob from a registry or an OCI image layout
 
+An interrupted download of a blob from a registry into a file is kept as
+'<file>.<digest prefix>.partial', and resumed by the next fetch of the same
+blob into the file with range requests if the registry supports them. The file
+is written once the blob is completely downloaded and verified.
+
 Example - Fetch a blob from registry and save it to a local file:
   oras blob fetch --output blob.tar.gz localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
 Example - Fetch a blob from registry and print the raw blob content:
   oras blob fetch --output - localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
+Example - [Experimental] Fetch a large blob with 8 concurrent range requests and save it to a local file:
+  oras blob fetch --segments 8 --output blob.tar.gz localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
 Example - Fetch and print the descriptor of a blob:
   oras blob fetch --descriptor localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
@@ -88,18 +101,22 @@ Example - Fetch and print a blob from OCI image layout archive file 'layout.tar'
 		},
 		Aliases: []string{"get"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			return fetchBlob(cmd, &opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "output file `path`, use - for stdout")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
 
 func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
+	ctx = opts.WithSegments(ctx)
 	var target oras.ReadOnlyTarget
 	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
@@ -110,13 +127,20 @@ func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 		return err
 	}
 
-	if repo, ok := target.(*remote.Repository); ok {
+	repo, isRemote := target.(*remote.Repository)
+	if isRemote {
 		target = repo.Blobs()
 	}
 	src, err := opts.CachedTarget(target)
 	if err != nil {
 		return err
 	}
+	if isRemote && opts.outputPath != "" && opts.outputPath != "-" {
+		outputPath := opts.outputPath
+		src = resume.NewTarget(src, func(ocispec.Descriptor) string {
+			return outputPath
+		})
+	}
 	desc, err := opts.doFetch(ctx, src)
 	if err != nil {
 		return err
@@ -152,8 +176,12 @@ func (opts *fetchBlobOptions) doFetch(ctx context.Context, src oras.ReadOnlyTarg
 	vr := content.NewVerifyReader(rc, desc)
 
 	// outputs blob content if "--output -" is used
-	writer := os.Stdout
-	if opts.outputPath != "-" {
+	var writer io.Writer = os.Stdout
+	if rt, ok := src.(*resume.Target); ok && rt.Destination(desc) != "" {
+		// the blob content is moved into place by the resume target once
+		// completely read
+		writer = io.Discard
+	} else if opts.outputPath != "-" {
 		// save blob content into the local file if the output path is provided
 		file, err := os.Create(opts.outputPath)
 		if err != nil {
@@ -167,14 +195,19 @@ func (opts *fetchBlobOptions) doFetch(ctx context.Context, src oras.ReadOnlyTarg
 		writer = file
 	}
 
-	if opts.TTY == nil {
+	if opts.TTY == nil && opts.ProgressEvents == nil {
 		// none TTY output
 		if _, err = io.Copy(writer, vr); err != nil {
 			return ocispec.Descriptor{}, err
 		}
 	} else {
-		// TTY output
-		trackedReader, err := track.NewReader(vr, desc, "Downloading", "Downloaded ", opts.TTY)
+		// TTY output or progress events
+		var trackedReader *track.Reader
+		if opts.ProgressEvents != nil {
+			trackedReader, err = track.NewManagedReader(vr, desc, sprogress.NewEventManager(opts.ProgressEvents))
+		} else {
+			trackedReader, err = track.NewReaderWithView(vr, desc, "Downloading", "Downloaded ", opts.TTY, display.ProgressView(opts.Terminal))
+		}
 		if err != nil {
 			return ocispec.Descriptor{}, err
 		}
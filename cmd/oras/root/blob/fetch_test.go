@@ -20,11 +20,14 @@ package blob
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/internal/resume"
 	"oras.land/oras/internal/testutils"
 )
 
@@ -65,3 +68,39 @@ func Test_fetchBlobOptions_doFetch(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_fetchBlobOptions_doFetch_resumable(t *testing.T) {
+	src := memory.New()
+	content := []byte("test")
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	ctx := context.Background()
+	if err := src.Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, desc, "blob"); err != nil {
+		t.Fatal(err)
+	}
+	var opts fetchBlobOptions
+	opts.Reference = "blob"
+	opts.outputPath = filepath.Join(t.TempDir(), "test")
+	target := resume.NewTarget(src, func(ocispec.Descriptor) string {
+		return opts.outputPath
+	})
+	if _, err := opts.doFetch(ctx, target); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(opts.outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("fetched content = %q, want %q", got, content)
+	}
+	if _, err := os.Stat(resume.PartialPath(opts.outputPath, desc.Digest)); !os.IsNotExist(err) {
+		t.Errorf("partial file is left: %v", err)
+	}
+}
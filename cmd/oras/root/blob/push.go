@@ -39,6 +39,8 @@ type pushBlobOptions struct {
 	option.Pretty
 	option.Target
 	option.Terminal
+	option.RateLimit
+	option.Chunk
 
 	fileRef   string
 	mediaType string
@@ -75,6 +77,9 @@ Example - Push blob 'hi.txt' and output the prettified descriptor:
 Example - Push blob without TLS:
   oras blob push --insecure localhost:5000/hello hi.txt
 
+Example - [Experimental] Push blob 'model.bin' in chunks of 64 MiB:
+  oras blob push --chunk-size 64M localhost:5000/hello model.bin
+
 Example - Push blob 'hi.txt' into an OCI image layout folder 'layout-dir':
   oras blob push --oci-layout layout-dir hi.txt
 `,
@@ -97,6 +102,7 @@ Example - Push blob 'hi.txt' into an OCI image layout folder 'layout-dir':
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = opts.verbose && !opts.OutputDescriptor
 			return pushBlob(cmd, &opts)
 		},
@@ -112,6 +118,8 @@ Example - Push blob 'hi.txt' into an OCI image layout folder 'layout-dir':
 
 func pushBlob(cmd *cobra.Command, opts *pushBlobOptions) (err error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
+	ctx = opts.WithChunkedUpload(ctx)
 
 	target, err := opts.NewTarget(opts.Common, logger)
 	if err != nil {
@@ -125,7 +133,7 @@ func pushBlob(cmd *cobra.Command, opts *pushBlobOptions) (err error) {
 	}
 	defer func() { _ = rc.Close() }()
 
-	statusHandler, metadataHandler := display.NewBlobPushHandler(opts.Printer, opts.OutputDescriptor, opts.Pretty.Pretty, desc, opts.TTY)
+	statusHandler, metadataHandler := display.NewBlobPushHandler(opts.Printer, opts.OutputDescriptor, opts.Pretty.Pretty, desc, opts.Terminal)
 	if err := doPush(ctx, statusHandler, target, desc, rc); err != nil {
 		return err
 	}
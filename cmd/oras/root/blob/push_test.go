@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"oras.land/oras/cmd/oras/internal/display/status"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -57,3 +58,29 @@ func Test_pushBlobOptions_doPush(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_pushBlobOptions_doPush_aggregateView(t *testing.T) {
+	// prepare
+	pty, device, err := testutils.NewPty()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = device.Close() }()
+	src := memory.New()
+	content := []byte("test")
+	r := bytes.NewReader(content)
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	// test
+	err = doPush(context.Background(), status.NewTTYBlobPushHandlerWithView(device, sprogress.ViewAggregate, desc), src, desc, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// validate
+	if err = testutils.MatchPty(pty, device, "Total", "1/1 files"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type clearOptions struct {
+	option.Common
+	option.Cache
+	option.Confirmation
+}
+
+func clearCmd() *cobra.Command {
+	var opts clearOptions
+	cmd := &cobra.Command{
+		Use:   "clear [flags]",
+		Short: "[Experimental] Remove all blobs from the local content cache",
+		Long: `[Experimental] Remove all blobs from the local content cache
+
+Example - Remove all blobs from the cache configured by ORAS_CACHE:
+  oras cache clear
+
+Example - Remove all blobs from the cache in the directory 'cache-dir' without prompting:
+  oras cache clear --cache-dir cache-dir --force
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return clearCache(&opts)
+		},
+	}
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func clearCache(opts *clearOptions) error {
+	root, err := cacheRoot(&opts.Cache)
+	if err != nil {
+		return err
+	}
+	prompt := fmt.Sprintf("Are you sure you want to remove all cached blobs in %q?", root)
+	confirmed, err := opts.AskForConfirmation(os.Stdin, prompt)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	if err := cache.Clear(root); err != nil {
+		return err
+	}
+	return opts.Printer.Println("Cleared", root)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache [command]",
+		Short: "[Experimental] Manage the local content cache",
+	}
+
+	cmd.AddCommand(
+		infoCmd(),
+		listCmd(),
+		pruneCmd(),
+		clearCmd(),
+	)
+	return cmd
+}
+
+// cacheRoot returns the root directory of the configured cache.
+func cacheRoot(opts *option.Cache) (string, error) {
+	root, err := opts.CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	if root == "" {
+		return "", &oerrors.Error{
+			Err:            errors.New("no cache directory is configured"),
+			Recommendation: `Please specify the cache directory via the "--cache-dir" flag, the ORAS_CACHE environment variable or the "cacheDir" setting of the config file`,
+		}
+	}
+	return root, nil
+}
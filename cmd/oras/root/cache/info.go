@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type infoOptions struct {
+	option.Common
+	option.Cache
+}
+
+func infoCmd() *cobra.Command {
+	var opts infoOptions
+	cmd := &cobra.Command{
+		Use:   "info [flags]",
+		Short: "[Experimental] Show the location, size and blob count of the local content cache",
+		Long: `[Experimental] Show the location, size and blob count of the local content cache
+
+The cache directory is resolved from the "--cache-dir" flag, the ORAS_CACHE
+environment variable and the "cacheDir" setting of the config file in order.
+
+Example - Show the information of the cache configured by ORAS_CACHE:
+  oras cache info
+
+Example - Show the information of the cache in the directory 'cache-dir':
+  oras cache info --cache-dir cache-dir
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showInfo(&opts)
+		},
+	}
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func showInfo(opts *infoOptions) error {
+	root, err := cacheRoot(&opts.Cache)
+	if err != nil {
+		return err
+	}
+	usage, err := cache.GetUsage(root)
+	if err != nil {
+		return err
+	}
+	if err := opts.Printer.Println("Location:", root); err != nil {
+		return err
+	}
+	if err := opts.Printer.Println("Size:    ", humanize.ToBytes(usage.Size)); err != nil {
+		return err
+	}
+	return opts.Printer.Println("Blobs:   ", usage.BlobCount)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type listOptions struct {
+	option.Common
+	option.Cache
+}
+
+func listCmd() *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:     "ls [flags]",
+		Aliases: []string{"list"},
+		Short:   "[Experimental] List the blobs in the local content cache",
+		Long: `[Experimental] List the blobs in the local content cache, least recently used first
+
+Example - List the blobs in the cache configured by ORAS_CACHE:
+  oras cache ls
+
+Example - List the blobs in the cache in the directory 'cache-dir':
+  oras cache ls --cache-dir cache-dir
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listCache(&opts)
+		},
+	}
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func listCache(opts *listOptions) error {
+	root, err := cacheRoot(&opts.Cache)
+	if err != nil {
+		return err
+	}
+	entries, err := cache.List(root)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(opts.Printer, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "DIGEST\tSIZE\tLAST ACCESSED"); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", e.Digest, humanize.ToBytes(e.Size), e.LastAccessed.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
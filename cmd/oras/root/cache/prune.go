@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+)
+
+type pruneOptions struct {
+	option.Common
+	option.Cache
+
+	maxSize   string
+	olderThan time.Duration
+	dryRun    bool
+
+	maxSizeBytes int64
+}
+
+func pruneCmd() *cobra.Command {
+	var opts pruneOptions
+	cmd := &cobra.Command{
+		Use:   "prune [flags]",
+		Short: "[Experimental] Evict least recently used blobs from the local content cache",
+		Long: `[Experimental] Evict least recently used blobs from the local content cache
+
+Blobs are evicted in least recently used order until the cache fits in the
+size given by "--max-size", and blobs not used within the duration given by
+"--older-than" are evicted regardless of the cache size.
+
+Example - Shrink the cache to at most 10 GiB:
+  oras cache prune --max-size 10G
+
+Example - Evict blobs not used in the last week:
+  oras cache prune --older-than 168h
+
+Example - Show the blobs to be evicted without removing them:
+  oras cache prune --max-size 500M --dry-run
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("max-size") && !cmd.Flags().Changed("older-than") {
+				return &oerrors.Error{
+					Err:            errors.New("no eviction policy is specified"),
+					Recommendation: `Please specify at least one of "--max-size" and "--older-than"`,
+				}
+			}
+			if err := opts.parsePolicy(); err != nil {
+				return err
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pruneCache(&opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.maxSize, "max-size", "", "evict least recently used blobs until the cache is no larger than `size`, with an optional K, M, G or T suffix")
+	cmd.Flags().DurationVar(&opts.olderThan, "older-than", 0, "evict blobs not used within `duration`, e.g. 72h")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "show the blobs to be evicted without removing them")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+// parsePolicy parses the eviction policy given by --max-size and
+// --older-than.
+func (opts *pruneOptions) parsePolicy() error {
+	if opts.maxSize != "" {
+		var err error
+		if opts.maxSizeBytes, err = option.ParseSize(opts.maxSize); err != nil {
+			return fmt.Errorf("invalid value %q for --max-size: %w", opts.maxSize, err)
+		}
+	}
+	if opts.olderThan < 0 {
+		return fmt.Errorf("invalid value %q for --older-than: duration must not be negative", opts.olderThan)
+	}
+	return nil
+}
+
+func pruneCache(opts *pruneOptions) error {
+	root, err := cacheRoot(&opts.Cache)
+	if err != nil {
+		return err
+	}
+	evicted, err := cache.Prune(root, cache.PruneOptions{
+		MaxSize:   opts.maxSizeBytes,
+		OlderThan: opts.olderThan,
+		DryRun:    opts.dryRun,
+	})
+	verb := "Evicted"
+	if opts.dryRun {
+		verb = "Would evict"
+	}
+	var freed int64
+	for _, e := range evicted {
+		freed += e.Size
+		if printErr := opts.Printer.Println(verb, e.Digest); printErr != nil {
+			return printErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return opts.Printer.Printf("%s %d blobs, %s in total\n", verb, len(evicted), humanize.ToBytes(freed))
+}
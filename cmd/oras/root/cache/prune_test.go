@@ -0,0 +1,57 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_pruneOptions_parsePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxSize   string
+		olderThan time.Duration
+		want      int64
+		wantErr   bool
+	}{
+		{name: "bytes", maxSize: "1024", want: 1024},
+		{name: "kilobytes", maxSize: "10K", want: 10 << 10},
+		{name: "fractional megabytes", maxSize: "1.5m", want: 3 << 19},
+		{name: "gigabytes", maxSize: "2G", want: 2 << 30},
+		{name: "terabytes", maxSize: "1T", want: 1 << 40},
+		{name: "age only", olderThan: time.Hour},
+		{name: "zero size", maxSize: "0", wantErr: true},
+		{name: "negative size", maxSize: "-1G", wantErr: true},
+		{name: "not a number", maxSize: "ten", wantErr: true},
+		{name: "negative age", olderThan: -time.Hour, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &pruneOptions{
+				maxSize:   tt.maxSize,
+				olderThan: tt.olderThan,
+			}
+			err := opts.parsePolicy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if opts.maxSizeBytes != tt.want {
+				t.Errorf("parsePolicy() maxSizeBytes = %v, want %v", opts.maxSizeBytes, tt.want)
+			}
+		})
+	}
+}
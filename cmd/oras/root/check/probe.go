@@ -0,0 +1,482 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+const (
+	// probeArtifactType is the artifact type of the probe manifests.
+	probeArtifactType = "application/vnd.oras.check.probe.v1"
+	// probeTagPrefix is the prefix of the tags created for probing.
+	probeTagPrefix = "oras-check-"
+	// minChunkSize is the chunk size used if the registry does not require a
+	// minimum.
+	minChunkSize = 16
+)
+
+// prober probes registry capabilities by pushing content to a scratch
+// repository and records the content to remove on cleanup.
+type prober struct {
+	repo *remote.Repository
+	// mountRepo is the repository that blobs are mounted into.
+	mountRepo *remote.Repository
+	// packVersion is the manifest version accepted by the registry.
+	packVersion oras.PackManifestVersion
+
+	manifests []ocispec.Descriptor
+	blobs     []probedBlob
+}
+
+// probedBlob is a blob pushed to a repository for probing.
+type probedBlob struct {
+	repo *remote.Repository
+	desc ocispec.Descriptor
+}
+
+func newProber(repo, mountRepo *remote.Repository) *prober {
+	return &prober{
+		repo:        repo,
+		mountRepo:   mountRepo,
+		packVersion: oras.PackManifestVersion1_1,
+	}
+}
+
+// probeArtifactType pushes the base manifest with an artifact type and falls
+// back to an image manifest without artifact type if rejected. The error is
+// returned only if no manifest can be pushed.
+func (p *prober) probeArtifactType(ctx context.Context) (ocispec.Descriptor, model.Capability, error) {
+	capability := model.Capability{Name: model.CapabilityArtifactType}
+	layer, err := p.pushBlob(ctx, p.repo)
+	if err != nil {
+		return ocispec.Descriptor{}, capability, err
+	}
+	base, err := p.pushManifest(ctx, nil, layer)
+	if err == nil {
+		capability.Status = model.CapabilitySupported
+		return base, capability, nil
+	}
+	var errResp *errcode.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.StatusCode != http.StatusBadRequest {
+		return ocispec.Descriptor{}, capability, err
+	}
+
+	p.packVersion = oras.PackManifestVersion1_0
+	if base, err = p.pushManifest(ctx, nil, layer); err != nil {
+		return ocispec.Descriptor{}, capability, err
+	}
+	capability.Status = model.CapabilityUnsupported
+	capability.Detail = "manifest with artifactType is rejected"
+	return base, capability, nil
+}
+
+// probeReferrers pushes a referrer of base and lists the referrers of base via
+// the referrers API.
+func (p *prober) probeReferrers(ctx context.Context, base ocispec.Descriptor) model.Capability {
+	capability := model.Capability{Name: model.CapabilityReferrersAPI}
+	referrer, err := p.pushManifest(ctx, &base)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	resp, err := p.do(ctx, http.MethodGet, p.url(p.repo, "referrers/"+base.Digest.String()), nil, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var index ocispec.Index
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 4*1024*1024)).Decode(&index); err != nil {
+			return unknown(capability, fmt.Errorf("invalid referrers response: %w", err))
+		}
+		capability.Status = model.CapabilitySupported
+		for _, m := range index.Manifests {
+			if m.Digest == referrer.Digest {
+				return capability
+			}
+		}
+		capability.Detail = "pushed referrer is not listed"
+		return capability
+	case http.StatusNotFound:
+		capability.Status = model.CapabilityUnsupported
+		capability.Detail = "referrers tag schema is used instead"
+		return capability
+	default:
+		return unexpectedStatus(capability, resp)
+	}
+}
+
+// probeMount mounts a blob of the scratch repository into the mount
+// repository.
+func (p *prober) probeMount(ctx context.Context) model.Capability {
+	capability := model.Capability{Name: model.CapabilityMount}
+	blob, err := p.pushBlob(ctx, p.repo)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	query := url.Values{}
+	query.Set("mount", blob.Digest.String())
+	query.Set("from", p.repo.Reference.Repository)
+	uploadURL := p.url(p.mountRepo, "blobs/uploads/") + "?" + query.Encode()
+	resp, err := p.do(ctx, http.MethodPost, uploadURL, nil, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		p.blobs = append(p.blobs, probedBlob{repo: p.mountRepo, desc: blob})
+		capability.Status = model.CapabilitySupported
+		return capability
+	case http.StatusAccepted:
+		// the registry started an upload instead, cancel it
+		if location, err := resolveLocation(resp); err == nil {
+			if resp, err := p.do(ctx, http.MethodDelete, location, nil, nil); err == nil {
+				resp.Body.Close()
+			}
+		}
+		capability.Status = model.CapabilityUnsupported
+		capability.Detail = "an upload is started instead of mounting"
+		return capability
+	default:
+		return unexpectedStatus(capability, resp)
+	}
+}
+
+// probeChunkedUpload uploads a blob in two chunks.
+func (p *prober) probeChunkedUpload(ctx context.Context) model.Capability {
+	capability := model.Capability{Name: model.CapabilityChunkedUpload}
+	resp, err := p.do(ctx, http.MethodPost, p.url(p.repo, "blobs/uploads/"), nil, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return unexpectedStatus(capability, resp)
+	}
+	chunkSize := minChunkSize
+	if n, err := strconv.Atoi(resp.Header.Get("OCI-Chunk-Min-Length")); err == nil && n > chunkSize {
+		chunkSize = n
+	}
+	location, err := resolveLocation(resp)
+	if err != nil {
+		return unknown(capability, err)
+	}
+
+	data, err := randomBytes(2 * chunkSize)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	for offset := 0; offset < len(data); offset += chunkSize {
+		chunk := data[offset : offset+chunkSize]
+		resp, err := p.do(ctx, http.MethodPatch, location, chunk, http.Header{
+			"Content-Type":  []string{"application/octet-stream"},
+			"Content-Range": []string{fmt.Sprintf("%d-%d", offset, offset+len(chunk)-1)},
+		})
+		if err != nil {
+			return unknown(capability, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			return unsupportedStatus(capability, resp)
+		}
+		if location, err = resolveLocation(resp); err != nil {
+			return unknown(capability, err)
+		}
+	}
+
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, data)
+	completeURL, err := url.Parse(location)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	query := completeURL.Query()
+	query.Set("digest", desc.Digest.String())
+	completeURL.RawQuery = query.Encode()
+	resp, err = p.do(ctx, http.MethodPut, completeURL.String(), nil, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return unsupportedStatus(capability, resp)
+	}
+	p.blobs = append(p.blobs, probedBlob{repo: p.repo, desc: desc})
+	capability.Status = model.CapabilitySupported
+	if chunkSize > minChunkSize {
+		capability.Detail = fmt.Sprintf("minimum chunk size is %d bytes", chunkSize)
+	}
+	return capability
+}
+
+// probeManifestDelete pushes a manifest and deletes it.
+func (p *prober) probeManifestDelete(ctx context.Context) model.Capability {
+	capability := model.Capability{Name: model.CapabilityManifestDelete}
+	desc, err := p.pushManifest(ctx, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	if err := p.repo.Delete(ctx, desc); err != nil {
+		return deleteFailed(capability, err)
+	}
+	p.manifests = p.manifests[:len(p.manifests)-1]
+	capability.Status = model.CapabilitySupported
+	return capability
+}
+
+// probeBlobDelete pushes a blob and deletes it.
+func (p *prober) probeBlobDelete(ctx context.Context) model.Capability {
+	capability := model.Capability{Name: model.CapabilityBlobDelete}
+	desc, err := p.pushBlob(ctx, p.repo)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	if err := p.repo.Blobs().Delete(ctx, desc); err != nil {
+		return deleteFailed(capability, err)
+	}
+	p.blobs = p.blobs[:len(p.blobs)-1]
+	capability.Status = model.CapabilitySupported
+	return capability
+}
+
+// probeTagListPagination tags base twice and lists the tags one per page.
+func (p *prober) probeTagListPagination(ctx context.Context, base ocispec.Descriptor) model.Capability {
+	capability := model.Capability{Name: model.CapabilityTagListPagination}
+	for i := 1; i <= 2; i++ {
+		if err := p.repo.Tag(ctx, base, probeTagPrefix+strconv.Itoa(i)); err != nil {
+			return unknown(capability, err)
+		}
+	}
+	resp, err := p.do(ctx, http.MethodGet, p.url(p.repo, "tags/list?n=1"), nil, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedStatus(capability, resp)
+	}
+	var page struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4*1024*1024)).Decode(&page); err != nil {
+		return unknown(capability, fmt.Errorf("invalid tag list response: %w", err))
+	}
+	switch {
+	case len(page.Tags) > 1:
+		capability.Status = model.CapabilityUnsupported
+		capability.Detail = "parameter n is ignored"
+	case resp.Header.Get("Link") == "":
+		capability.Status = model.CapabilityUnsupported
+		capability.Detail = "no Link header to the next page"
+	default:
+		capability.Status = model.CapabilitySupported
+	}
+	return capability
+}
+
+// probeCatalog lists the repositories of the registry.
+func (p *prober) probeCatalog(ctx context.Context) model.Capability {
+	capability := model.Capability{Name: model.CapabilityCatalog}
+	resp, err := p.do(ctx, http.MethodGet, baseURL(p.repo)+"_catalog?n=1", nil, nil)
+	if err != nil {
+		return unknown(capability, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return unsupportedStatus(capability, resp)
+	}
+	capability.Status = model.CapabilitySupported
+	return capability
+}
+
+// cleanup removes the probe content. Missing content is ignored.
+func (p *prober) cleanup(ctx context.Context) []string {
+	var errs []string
+	record := func(err error) {
+		if err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			errs = append(errs, err.Error())
+		}
+	}
+	for i := len(p.manifests) - 1; i >= 0; i-- {
+		desc := p.manifests[i]
+		record(p.repo.Delete(ctx, desc))
+		// referrers index created by the referrers tag schema
+		tag := strings.Replace(desc.Digest.String(), ":", "-", 1)
+		if index, err := p.repo.Resolve(ctx, tag); err == nil {
+			record(p.repo.Delete(ctx, index))
+		}
+	}
+	deleted := make(map[string]bool)
+	for _, blob := range p.blobs {
+		key := blob.repo.Reference.Repository + "@" + blob.desc.Digest.String()
+		if deleted[key] {
+			continue
+		}
+		deleted[key] = true
+		record(blob.repo.Blobs().Delete(ctx, blob.desc))
+	}
+	return errs
+}
+
+// pushBlob pushes a random blob to repo.
+func (p *prober) pushBlob(ctx context.Context, repo *remote.Repository) (ocispec.Descriptor, error) {
+	data, err := randomBytes(32)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, data)
+	if err := repo.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	p.blobs = append(p.blobs, probedBlob{repo: repo, desc: desc})
+	return desc, nil
+}
+
+// pushManifest packs and pushes a manifest with the given subject and layers.
+func (p *prober) pushManifest(ctx context.Context, subject *ocispec.Descriptor, layers ...ocispec.Descriptor) (ocispec.Descriptor, error) {
+	nonce, err := randomBytes(8)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := oras.PackManifest(ctx, p.repo, p.packVersion, probeArtifactType, oras.PackManifestOptions{
+		Subject: subject,
+		Layers:  layers,
+		ManifestAnnotations: map[string]string{
+			// makes the manifest unique across runs
+			"land.oras.check.nonce": digest.FromBytes(nonce).Encoded()[:16],
+		},
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	p.manifests = append(p.manifests, desc)
+	if p.packVersion == oras.PackManifestVersion1_1 {
+		p.blobs = append(p.blobs, probedBlob{repo: p.repo, desc: ocispec.DescriptorEmptyJSON})
+	}
+	return desc, nil
+}
+
+// url returns the URL of path under the repository.
+func (p *prober) url(repo *remote.Repository, path string) string {
+	return baseURL(repo) + repo.Reference.Repository + "/" + path
+}
+
+// baseURL returns the URL of the API base of the registry of repo.
+func baseURL(repo *remote.Repository) string {
+	scheme := "https"
+	if repo.PlainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/", scheme, repo.Reference.Host())
+}
+
+// do sends a request with the authenticated client of the scratch repository.
+func (p *prober) do(ctx context.Context, method, url string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	client := p.repo.Client
+	if client == nil {
+		client = auth.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// resolveLocation resolves the Location header of resp against its request.
+func resolveLocation(resp *http.Response) (string, error) {
+	location, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf("%s %q: %w", resp.Request.Method, resp.Request.URL, err)
+	}
+	return location.String(), nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func unknown(capability model.Capability, err error) model.Capability {
+	capability.Status = model.CapabilityUnknown
+	capability.Detail = err.Error()
+	return capability
+}
+
+// unexpectedStatus reports capability as unknown due to the status of resp.
+func unexpectedStatus(capability model.Capability, resp *http.Response) model.Capability {
+	return unknown(capability, fmt.Errorf("%s %q: unexpected status %s", resp.Request.Method, resp.Request.URL.Path, resp.Status))
+}
+
+// unsupportedStatus reports capability as unsupported due to the status of
+// resp, unless the request is not authorized.
+func unsupportedStatus(capability model.Capability, resp *http.Response) model.Capability {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unexpectedStatus(capability, resp)
+	}
+	capability.Status = model.CapabilityUnsupported
+	capability.Detail = fmt.Sprintf("%s %q returned %s", resp.Request.Method, resp.Request.URL.Path, resp.Status)
+	return capability
+}
+
+// deleteFailed reports capability as unsupported if the registry does not
+// allow deletion, or unknown otherwise.
+func deleteFailed(capability model.Capability, err error) model.Capability {
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) && (errResp.StatusCode == http.StatusMethodNotAllowed || hasErrorCode(errResp, errcode.ErrorCodeUnsupported)) {
+		capability.Status = model.CapabilityUnsupported
+		capability.Detail = "deletion is disabled"
+		return capability
+	}
+	return unknown(capability, err)
+}
+
+func hasErrorCode(errResp *errcode.ErrorResponse, code string) bool {
+	for _, e := range errResp.Errors {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
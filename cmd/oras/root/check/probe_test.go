@@ -0,0 +1,395 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+// fakeRegistry is an in-memory registry with switchable capabilities.
+type fakeRegistry struct {
+	noReferrers  bool
+	noMount      bool
+	noChunked    bool
+	noDelete     bool
+	noPagination bool
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      map[string]map[string]string
+	uploads   map[string][]byte
+	uploadID  int
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		tags:      map[string]map[string]string{},
+		uploads:   map[string][]byte{},
+	}
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	if path == "_catalog" {
+		_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {}})
+		return
+	}
+	for _, route := range []struct {
+		sep    string
+		handle func(http.ResponseWriter, *http.Request, string, string)
+	}{
+		{"/blobs/uploads/", r.serveUpload},
+		{"/blobs/", r.serveBlob},
+		{"/manifests/", r.serveManifest},
+		{"/referrers/", r.serveReferrers},
+		{"/tags/list", r.serveTags},
+	} {
+		if i := strings.LastIndex(path, route.sep); i > 0 {
+			route.handle(w, req, path[:i], path[i+len(route.sep):])
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (r *fakeRegistry) serveUpload(w http.ResponseWriter, req *http.Request, repo, id string) {
+	switch req.Method {
+	case http.MethodPost:
+		if mount := req.URL.Query().Get("mount"); mount != "" && !r.noMount {
+			from := req.URL.Query().Get("from")
+			if data, ok := r.blobs[from+"@"+mount]; ok {
+				r.blobs[repo+"@"+mount] = data
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		r.uploadID++
+		id := fmt.Sprint(r.uploadID)
+		r.uploads[id] = nil
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/"+id)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPatch:
+		if r.noChunked {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		data, _ := io.ReadAll(req.Body)
+		r.uploads[id] = append(r.uploads[id], data...)
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/"+id)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		data = append(r.uploads[id], data...)
+		delete(r.uploads, id)
+		dgst := req.URL.Query().Get("digest")
+		if digest.FromBytes(data).String() != dgst {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[repo+"@"+dgst] = data
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		delete(r.uploads, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (r *fakeRegistry) serveBlob(w http.ResponseWriter, req *http.Request, repo, dgst string) {
+	data, ok := r.blobs[repo+"@"+dgst]
+	switch {
+	case !ok:
+		w.WriteHeader(http.StatusNotFound)
+	case req.Method == http.MethodDelete:
+		if r.noDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		delete(r.blobs, repo+"@"+dgst)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.Header().Set("Docker-Content-Digest", dgst)
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	}
+}
+
+func (r *fakeRegistry) serveManifest(w http.ResponseWriter, req *http.Request, repo, ref string) {
+	if req.Method == http.MethodPut {
+		data, _ := io.ReadAll(req.Body)
+		dgst := digest.FromBytes(data).String()
+		r.manifests[repo+"@"+dgst] = data
+		if ref != dgst {
+			if r.tags[repo] == nil {
+				r.tags[repo] = map[string]string{}
+			}
+			r.tags[repo][ref] = dgst
+		}
+		if !r.noReferrers && strings.Contains(string(data), `"subject"`) {
+			w.Header().Set("OCI-Subject", "true")
+		}
+		w.Header().Set("Docker-Content-Digest", dgst)
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	dgst := ref
+	if tagged, ok := r.tags[repo][ref]; ok {
+		dgst = tagged
+	}
+	data, ok := r.manifests[repo+"@"+dgst]
+	switch {
+	case !ok:
+		w.WriteHeader(http.StatusNotFound)
+	case req.Method == http.MethodDelete:
+		if r.noDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		delete(r.manifests, repo+"@"+dgst)
+		for tag, tagged := range r.tags[repo] {
+			if tagged == dgst {
+				delete(r.tags[repo], tag)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		var m struct {
+			MediaType string `json:"mediaType"`
+		}
+		_ = json.Unmarshal(data, &m)
+		w.Header().Set("Content-Type", m.MediaType)
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.Header().Set("Docker-Content-Digest", dgst)
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	}
+}
+
+func (r *fakeRegistry) serveReferrers(w http.ResponseWriter, _ *http.Request, repo, dgst string) {
+	if r.noReferrers {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	index := ocispec.Index{Manifests: []ocispec.Descriptor{}}
+	for key, data := range r.manifests {
+		var m ocispec.Manifest
+		if !strings.HasPrefix(key, repo+"@") || json.Unmarshal(data, &m) != nil {
+			continue
+		}
+		if m.Subject != nil && m.Subject.Digest.String() == dgst {
+			index.Manifests = append(index.Manifests, ocispec.Descriptor{
+				MediaType: m.MediaType,
+				Digest:    digest.FromBytes(data),
+				Size:      int64(len(data)),
+			})
+		}
+	}
+	w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+	_ = json.NewEncoder(w).Encode(index)
+}
+
+func (r *fakeRegistry) serveTags(w http.ResponseWriter, req *http.Request, repo, _ string) {
+	var tags []string
+	for tag := range r.tags[repo] {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	if n := req.URL.Query().Get("n"); n == "1" && len(tags) > 1 && !r.noPagination {
+		tags = tags[:1]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=1&last=%s>; rel="next"`, repo, tags[0]))
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"name": repo, "tags": tags})
+}
+
+func newTestProber(t *testing.T, reg *fakeRegistry) *prober {
+	t.Helper()
+	ts := httptest.NewServer(reg)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRepo := func(name string) *remote.Repository {
+		repo, err := remote.NewRepository(u.Host + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo.PlainHTTP = true
+		return repo
+	}
+	return newProber(newRepo("scratch"), newRepo("scratch-mount"))
+}
+
+func probeAll(t *testing.T, p *prober) map[string]model.Capability {
+	t.Helper()
+	ctx := context.Background()
+	base, capability, err := p.probeArtifactType(ctx)
+	if err != nil {
+		t.Fatal("probeArtifactType() error =", err)
+	}
+	results := map[string]model.Capability{}
+	for _, c := range []model.Capability{
+		capability,
+		p.probeReferrers(ctx, base),
+		p.probeMount(ctx),
+		p.probeChunkedUpload(ctx),
+		p.probeManifestDelete(ctx),
+		p.probeBlobDelete(ctx),
+		p.probeTagListPagination(ctx, base),
+		p.probeCatalog(ctx),
+	} {
+		results[c.Name] = c
+	}
+	return results
+}
+
+func TestProber_supported(t *testing.T) {
+	reg := newFakeRegistry()
+	p := newTestProber(t, reg)
+	for name, c := range probeAll(t, p) {
+		if c.Status != model.CapabilitySupported {
+			t.Errorf("%s: status = %s (%s), want %s", name, c.Status, c.Detail, model.CapabilitySupported)
+		}
+	}
+	if errs := p.cleanup(context.Background()); len(errs) != 0 {
+		t.Fatal("cleanup() errors =", errs)
+	}
+	if len(reg.manifests) != 0 || len(reg.blobs) != 0 {
+		t.Errorf("cleanup() left %d manifests and %d blobs", len(reg.manifests), len(reg.blobs))
+	}
+}
+
+func TestProber_unsupported(t *testing.T) {
+	reg := newFakeRegistry()
+	reg.noReferrers = true
+	reg.noMount = true
+	reg.noChunked = true
+	reg.noDelete = true
+	reg.noPagination = true
+	p := newTestProber(t, reg)
+	results := probeAll(t, p)
+	for _, name := range []string{
+		model.CapabilityReferrersAPI,
+		model.CapabilityMount,
+		model.CapabilityChunkedUpload,
+		model.CapabilityManifestDelete,
+		model.CapabilityBlobDelete,
+		model.CapabilityTagListPagination,
+	} {
+		if c := results[name]; c.Status != model.CapabilityUnsupported {
+			t.Errorf("%s: status = %s (%s), want %s", name, c.Status, c.Detail, model.CapabilityUnsupported)
+		}
+	}
+	if c := results[model.CapabilityArtifactType]; c.Status != model.CapabilitySupported {
+		t.Errorf("%s: status = %s, want %s", c.Name, c.Status, model.CapabilitySupported)
+	}
+	if errs := p.cleanup(context.Background()); len(errs) == 0 {
+		t.Error("cleanup() errors = nil, want deletion errors")
+	}
+}
+
+func Test_parseScratchRepository(t *testing.T) {
+	tests := []struct {
+		raw          string
+		wantRegistry string
+		wantRepo     string
+		wantErr      bool
+	}{
+		{"localhost:5000", "localhost:5000", defaultRepository, false},
+		{"localhost:5000/", "localhost:5000", defaultRepository, false},
+		{"localhost:5000/tmp/probe", "localhost:5000", "tmp/probe", false},
+		{"localhost:5000/tmp:v1", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			gotRegistry, gotRepo, err := parseScratchRepository(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseScratchRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotRegistry != tt.wantRegistry || gotRepo != tt.wantRepo {
+				t.Errorf("parseScratchRepository() = %q, %q, want %q, %q", gotRegistry, gotRepo, tt.wantRegistry, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func Test_recommend(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities []model.Capability
+		want         model.RecommendedFlags
+	}{
+		{
+			name: "supported",
+			capabilities: []model.Capability{
+				{Name: model.CapabilityReferrersAPI, Status: model.CapabilitySupported},
+				{Name: model.CapabilityArtifactType, Status: model.CapabilitySupported},
+			},
+			want: model.RecommendedFlags{
+				DistributionSpec: option.DistributionSpecReferrersAPIV1_1,
+				ImageSpec:        option.ImageSpecV1_1,
+			},
+		},
+		{
+			name: "unsupported",
+			capabilities: []model.Capability{
+				{Name: model.CapabilityReferrersAPI, Status: model.CapabilityUnsupported},
+				{Name: model.CapabilityArtifactType, Status: model.CapabilityUnsupported},
+			},
+			want: model.RecommendedFlags{
+				DistributionSpec: option.DistributionSpecReferrersTagV1_1,
+				ImageSpec:        option.ImageSpecV1_0,
+			},
+		},
+		{
+			name: "unknown",
+			capabilities: []model.Capability{
+				{Name: model.CapabilityReferrersAPI, Status: model.CapabilityUnknown},
+				{Name: model.CapabilityCatalog, Status: model.CapabilitySupported},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recommend(tt.capabilities); got != tt.want {
+				t.Errorf("recommend() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
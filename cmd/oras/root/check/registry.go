@@ -0,0 +1,188 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/registryutil"
+)
+
+// defaultRepository is the scratch repository used if none is specified.
+const defaultRepository = "oras-check"
+
+type registryOptions struct {
+	option.Common
+	option.Remote
+	option.Format
+
+	cleanup    bool
+	registry   string
+	repository string
+}
+
+func registryCmd() *cobra.Command {
+	var opts registryOptions
+	cmd := &cobra.Command{
+		Use:   "registry [flags] <registry>[/<repository>]",
+		Short: "[Preview] Probe the capabilities of a registry",
+		Long: `[Preview] Probe the capabilities of a registry
+
+Probes the referrers API, OCI 1.1 artifactType, cross-repository mount, chunked
+uploads, manifest and blob deletion, tag list pagination and the catalog API by
+pushing small probe artifacts to a scratch repository, and recommends the
+--distribution-spec and --image-spec values for the registry. The scratch
+repository defaults to "oras-check". Blobs are mounted into the repository
+with the suffix "-mount".
+
+** This command is in preview and under development. **
+
+Example - Probe the capabilities of registry 'localhost:5000':
+  oras check registry localhost:5000
+
+Example - Probe using the scratch repository 'tmp/probe' and remove the probe content afterwards:
+  oras check registry --cleanup localhost:5000/tmp/probe
+
+Example - [Experimental] Probe and output the result in JSON format:
+  oras check registry localhost:5000 --format json
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the registry to probe"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if opts.registry, opts.repository, err = parseScratchRepository(args[0]); err != nil {
+				return err
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCheckRegistry(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.cleanup, "cleanup", "", false, "remove the probe content from the scratch repository after probing")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+func runCheckRegistry(cmd *cobra.Command, opts *registryOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	repo, err := opts.NewRepository(opts.registry+"/"+opts.repository, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	mountRepo, err := opts.NewRepository(opts.registry+"/"+opts.repository+"-mount", opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	handler, err := display.NewRegistryCheckHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, repo, auth.ActionPull, auth.ActionPush, auth.ActionDelete)
+	ctx = registryutil.WithScopeHint(ctx, mountRepo, auth.ActionPull, auth.ActionPush, auth.ActionDelete)
+
+	check := model.NewRegistryCheck(repo.Reference.Registry, repo.Reference.Repository)
+	p := newProber(repo, mountRepo)
+	cleanup := func() {
+		check.CleanupErrors = p.cleanup(ctx)
+		check.CleanedUp = len(check.CleanupErrors) == 0
+	}
+	base, capability, err := p.probeArtifactType(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to push probe content to %q: %w", repo.Reference, err)
+		if opts.cleanup {
+			cleanup()
+			if !check.CleanedUp {
+				cleanupErr := fmt.Errorf("failed to remove some probe content from %q: %s", check.Repository, strings.Join(check.CleanupErrors, "; "))
+				return errors.Join(err, cleanupErr)
+			}
+		}
+		return err
+	}
+	check.Capabilities = append(check.Capabilities,
+		p.probeReferrers(ctx, base),
+		capability,
+		p.probeMount(ctx),
+		p.probeChunkedUpload(ctx),
+		p.probeManifestDelete(ctx),
+		p.probeBlobDelete(ctx),
+		p.probeTagListPagination(ctx, base),
+		p.probeCatalog(ctx),
+	)
+	check.Recommended = recommend(check.Capabilities)
+	if opts.cleanup {
+		cleanup()
+	}
+
+	if err := handler.OnRegistryChecked(check); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// recommend returns the flag values recommended for a registry with the
+// probed capabilities.
+func recommend(capabilities []model.Capability) model.RecommendedFlags {
+	var flags model.RecommendedFlags
+	for _, capability := range capabilities {
+		switch capability.Name {
+		case model.CapabilityReferrersAPI:
+			switch capability.Status {
+			case model.CapabilitySupported:
+				flags.DistributionSpec = option.DistributionSpecReferrersAPIV1_1
+			case model.CapabilityUnsupported:
+				flags.DistributionSpec = option.DistributionSpecReferrersTagV1_1
+			}
+		case model.CapabilityArtifactType:
+			switch capability.Status {
+			case model.CapabilitySupported:
+				flags.ImageSpec = option.ImageSpecV1_1
+			case model.CapabilityUnsupported:
+				flags.ImageSpec = option.ImageSpecV1_0
+			}
+		}
+	}
+	return flags
+}
+
+// parseScratchRepository parses raw as a registry with an optional
+// repository.
+func parseScratchRepository(raw string) (registryName, repository string, err error) {
+	raw = strings.TrimSuffix(raw, "/")
+	if !strings.Contains(raw, "/") {
+		return raw, defaultRepository, nil
+	}
+	ref, err := registry.ParseReference(raw)
+	if err != nil {
+		return "", "", err
+	}
+	if ref.Reference != "" {
+		return "", "", fmt.Errorf("%q: tags or digests should not be provided", raw)
+	}
+	return ref.Registry, ref.Repository, nil
+}
@@ -17,7 +17,10 @@ package root
 
 import (
 	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/root/blob"
+	"oras.land/oras/cmd/oras/root/cache"
+	"oras.land/oras/cmd/oras/root/check"
 	"oras.land/oras/cmd/oras/root/manifest"
 	"oras.land/oras/cmd/oras/root/repo"
 )
@@ -26,7 +29,12 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "oras [command]",
 		SilenceUsage: true,
+		// errors are printed by the caller in text or JSON
+		SilenceErrors: true,
 	}
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return oerrors.InvalidInput(err)
+	})
 	cmd.AddCommand(
 		pullCmd(),
 		pushCmd(),
@@ -34,6 +42,10 @@ func New() *cobra.Command {
 		logoutCmd(),
 		versionCmd(),
 		discoverCmd(),
+		inspectCmd(),
+		diffCmd(),
+		doctorCmd(),
+		benchCmd(),
 		resolveCmd(),
 		copyCmd(),
 		tagCmd(),
@@ -43,6 +55,8 @@ func New() *cobra.Command {
 		blob.Cmd(),
 		manifest.Cmd(),
 		repo.Cmd(),
+		cache.Cmd(),
+		check.Cmd(),
 	)
 	return cmd
 }
@@ -36,17 +36,27 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
 	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/graph"
 	"oras.land/oras/internal/listener"
+	"oras.land/oras/internal/ratelimit"
 	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/stats"
+	"oras.land/oras/internal/trace"
 )
 
 type copyOptions struct {
+	option.Cache
 	option.Common
 	option.Platform
 	option.BinaryTarget
+	option.Format
 	option.Terminal
+	option.RateLimit
+	option.Segment
+	option.Trace
+	option.Stats
 
 	recursive   bool
 	concurrency int
@@ -90,6 +100,12 @@ Example - Copy an artifact with multiple tags:
 
 Example - Copy an artifact with multiple tags with concurrency tuned:
   oras cp --concurrency 10 localhost:5000/net-monitor:v1 localhost:5000/net-monitor-copy:tag1,tag2,tag3
+
+Example - [Experimental] Copy an artifact with each large blob downloaded with 8 concurrent range requests:
+  oras cp --segments 8 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - [Experimental] Copy an artifact and output the result in JSON format:
+  oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1 --format json
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(2), "the source and destination for copying"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -105,6 +121,7 @@ Example - Copy an artifact with multiple tags with concurrency tuned:
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = opts.verbose
 			return runCopy(cmd, &opts)
 		},
@@ -114,12 +131,16 @@ Example - Copy an artifact with multiple tags with concurrency tuned:
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.EnableDistributionSpecFlag()
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.BinaryTarget)
+	return oerrors.Command(command.Traced(cmd, &opts.Trace), &opts.BinaryTarget)
 }
 
 func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
+	ctx = opts.WithSegments(ctx)
 
 	// Prepare source
 	src, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
@@ -129,6 +150,10 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
 		return err
 	}
+	cachedSrc, err := opts.CachedGraphTarget(src)
+	if err != nil {
+		return err
+	}
 
 	// Prepare destination
 	dst, err := opts.To.NewTarget(opts.Common, logger)
@@ -136,9 +161,20 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 		return err
 	}
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
-	statusHandler, metadataHandler := display.NewCopyHandler(opts.Printer, opts.TTY, dst)
+	statusHandler, metadataHandler, err := display.NewCopyHandler(opts.Printer, opts.Format, opts.Terminal, dst)
+	if err != nil {
+		return err
+	}
 
-	desc, err := doCopy(ctx, statusHandler, src, dst, opts)
+	if opts.From.Type == option.TargetTypeOCILayout && opts.To.Type == option.TargetTypeOCILayout {
+		// copies between OCI layouts are not throttled by the transport
+		dst = ratelimit.NewTarget(dst, ratelimit.TotalReader)
+	}
+
+	collector := opts.NewCollector()
+	endCopy := collector.StartPhase("copy")
+	desc, err := doCopy(ctx, statusHandler, cachedSrc, dst, collector, opts)
+	endCopy()
 	if err != nil {
 		return err
 	}
@@ -156,15 +192,25 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 		tagNOpts := oras.DefaultTagNOptions
 		tagNOpts.Concurrency = opts.concurrency
 		tagListener := listener.NewTaggedListener(dst, metadataHandler.OnTagged)
-		if _, err = oras.TagN(ctx, tagListener, opts.To.Reference, opts.extraRefs, tagNOpts); err != nil {
+		tagCtx, span := trace.StartSpan(ctx, "oras.tag", trace.Int64("oras.tag.count", int64(len(opts.extraRefs))))
+		endTag := collector.StartPhase("tag")
+		_, err = oras.TagN(tagCtx, tagListener, opts.To.Reference, opts.extraRefs, tagNOpts)
+		endTag()
+		span.End(err)
+		if err != nil {
 			return err
 		}
 	}
 
+	if collector != nil {
+		if err := metadataHandler.OnStats(collector.Summary()); err != nil {
+			return err
+		}
+	}
 	return metadataHandler.Render()
 }
 
-func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions) (desc ocispec.Descriptor, err error) {
+func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, collector *stats.Collector, opts *copyOptions) (desc ocispec.Descriptor, err error) {
 	// Prepare copy options
 	extendedCopyGraphOptions := oras.DefaultExtendedCopyGraphOptions
 	extendedCopyGraphOptions.Concurrency = opts.concurrency
@@ -191,18 +237,20 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 	extendedCopyGraphOptions.PreCopy = copyHandler.PreCopy
 	extendedCopyGraphOptions.PostCopy = copyHandler.PostCopy
 	extendedCopyGraphOptions.OnMounted = copyHandler.OnMounted
+	trace.TraceCopyGraph(ctx, &extendedCopyGraphOptions.CopyGraphOptions)
+	collector.TrackCopyGraph(&extendedCopyGraphOptions.CopyGraphOptions)
 
 	rOpts := oras.DefaultResolveOptions
 	rOpts.TargetPlatform = opts.Platform.Platform
 	if opts.recursive {
-		desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+		desc, err = resolveSource(ctx, src, opts.From.Reference, rOpts)
 		if err != nil {
 			return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
 		}
 		err = recursiveCopy(ctx, src, dst, opts.To.Reference, desc, extendedCopyGraphOptions)
 	} else {
 		if opts.To.Reference == "" {
-			desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+			desc, err = resolveSource(ctx, src, opts.From.Reference, rOpts)
 			if err != nil {
 				return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
 			}
@@ -221,6 +269,15 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 	return desc, err
 }
 
+// resolveSource resolves the source reference, recording the resolution as a
+// span when tracing is enabled.
+func resolveSource(ctx context.Context, src oras.ReadOnlyGraphTarget, reference string, opts oras.ResolveOptions) (ocispec.Descriptor, error) {
+	ctx, span := trace.StartSpan(ctx, "oras.resolve", trace.String("oras.reference", reference))
+	desc, err := oras.Resolve(ctx, src, reference, opts)
+	span.End(err)
+	return desc, err
+}
+
 // recursiveCopy copies an artifact and its referrers from one target to another.
 // If the artifact is a manifest list or index, referrers of its manifests are copied as well.
 func recursiveCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.Target, dstRef string, root ocispec.Descriptor, opts oras.ExtendedCopyGraphOptions) error {
@@ -316,7 +373,7 @@ func prepareCopyOption(ctx context.Context, src oras.ReadOnlyGraphTarget, dst or
 // the repository name to be mounted from if applicable. Mount can be performed if the two
 // targets are both remote repositories, are in the same registry and have identical credentials.
 func getMountPoint(src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions) (string, bool) {
-	srcRepo, srcIsRemote := src.(*remote.Repository)
+	srcRepo, srcIsRemote := cache.Source(src).(*remote.Repository)
 	dstRepo, dstIsRemote := dst.(*remote.Repository)
 	if !srcIsRemote || !dstIsRemote {
 		return "", false
@@ -136,7 +136,7 @@ func Test_doCopy(t *testing.T) {
 	dst := memory.New()
 	handler := status.NewTTYCopyHandler(opts.TTY)
 	// test
-	_, err = doCopy(context.Background(), handler, memStore, dst, &opts)
+	_, err = doCopy(context.Background(), handler, memStore, dst, nil, &opts)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -159,7 +159,7 @@ func Test_doCopy_skipped(t *testing.T) {
 	handler := status.NewTTYCopyHandler(opts.TTY)
 
 	// test
-	_, err = doCopy(context.Background(), handler, memStore, memStore, &opts)
+	_, err = doCopy(context.Background(), handler, memStore, memStore, nil, &opts)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -193,7 +193,7 @@ func Test_doCopy_mounted(t *testing.T) {
 	handler := status.NewTTYCopyHandler(opts.TTY)
 
 	// test
-	_, err = doCopy(context.Background(), handler, from, to, &opts)
+	_, err = doCopy(context.Background(), handler, from, to, nil, &opts)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,134 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+type diffOptions struct {
+	option.Cache
+	option.Common
+	option.Platform
+	option.BinaryTarget
+	option.Format
+}
+
+func diffCmd() *cobra.Command {
+	var opts diffOptions
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <from>{:<tag>|@<digest>} <to>{:<tag>|@<digest>}",
+		Short: "[Preview] Show differences between two artifacts",
+		Long: `[Preview] Show differences between two artifacts
+
+Compares two manifests or indexes and reports changes of the media type,
+artifact type, config, subject, layers matched by title, annotations and
+platforms of indexes.
+
+** This command is in preview and under development. **
+
+Example - Show what changed between two tags:
+  oras diff localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Compare an artifact in a registry with its copy in an OCI image layout folder:
+  oras diff --to-oci-layout localhost:5000/hello:v1 layout-dir:v1
+
+Example - Compare the linux/amd64 manifests of two multi-arch images:
+  oras diff --platform linux/amd64 localhost:5000/hello:v1 localhost:6000/hello:v1
+
+Example - [Experimental] Show differences in JSON format:
+  oras diff localhost:5000/hello:v1 localhost:5000/hello:v2 --format json
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the two artifacts to compare"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.From.RawReference = args[0]
+			opts.To.RawReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, &opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	opts.EnableRemoteCacheFlags()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.BinaryTarget)
+}
+
+func runDiff(cmd *cobra.Command, opts *diffOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	from, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.From.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	to, err := opts.To.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.To.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	cachedFrom, err := opts.CachedTarget(from)
+	if err != nil {
+		return err
+	}
+	cachedTo, err := opts.CachedTarget(to)
+	if err != nil {
+		return err
+	}
+	handler, err := display.NewDiffHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	fromDesc, fromManifest, err := fetchManifest(ctx, cachedFrom, opts.From.Reference, opts.Platform.Platform)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", opts.From.RawReference, err)
+	}
+	toDesc, toManifest, err := fetchManifest(ctx, cachedTo, opts.To.Reference, opts.Platform.Platform)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", opts.To.RawReference, err)
+	}
+	diff, err := model.NewDiff(opts.From.Path, fromDesc, fromManifest, opts.To.Path, toDesc, toManifest)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnDiffed(diff); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+func fetchManifest(ctx context.Context, src oras.ReadOnlyTarget, reference string, targetPlatform *ocispec.Platform) (ocispec.Descriptor, []byte, error) {
+	fetchOpts := oras.DefaultFetchBytesOptions
+	fetchOpts.TargetPlatform = targetPlatform
+	return oras.FetchBytes(ctx, src, reference, fetchOpts)
+}
@@ -34,6 +34,7 @@ import (
 )
 
 type discoverOptions struct {
+	option.Cache
 	option.Common
 	option.Platform
 	option.Target
@@ -70,12 +71,18 @@ Example - [Experimental] Discover referrers and display in a table view:
 Example - [Experimental] Discover referrers and format output with Go template:
   oras discover localhost:5000/hello:v1 --format go-template --template "{{.referrers}}"
 
+Example - [Experimental] Discover referrers and print their short digests, artifact types and creation dates:
+  oras discover localhost:5000/hello:v1 --format go-template --template '{{range .referrers}}{{shortDigest .}} {{.artifactType}} {{created . | date "2006-01-02"}}{{"\n"}}{{end}}'
+
 Example - [Experimental] Discover only direct referrers, displayed in json view:
   oras discover localhost:5000/hello:v1 --format json --depth 1
 
 Example - Discover referrers with type 'test-artifact' of manifest 'hello:v1' in registry 'localhost:5000':
   oras discover --artifact-type test-artifact localhost:5000/hello:v1
 
+Example - [Experimental] Discover referrers, reusing the tag resolution and referrers listings cached within 10 minutes:
+  oras discover --cache-dir cache-dir --cache-resolve-ttl 10m --cache-referrers-ttl 10m localhost:5000/hello:v1
+
 Example - Discover referrers of the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
   oras discover --oci-layout layout-dir:v1
 
@@ -123,9 +130,12 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 		option.FormatTypeTree,
 		option.FormatTypeTable,
 		option.FormatTypeJSON.WithUsage("Get referrers and output in JSON format"),
+		option.FormatTypeYAML.WithUsage("Get referrers and output in YAML format"),
 		option.FormatTypeGoTemplate.WithUsage("Print referrers using the given Go template"),
 	)
 	opts.EnableDistributionSpecFlag()
+	opts.DisableProgressFlags()
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().Lookup(option.NoTTYFlag).Usage = "[Preview] disable colors"
 	return oerrors.Command(cmd, &opts.Target)
@@ -133,13 +143,17 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 
 func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
+	repo, err := opts.CachedGraphTarget(target)
+	if err != nil {
+		return err
+	}
 
 	// discover artifacts
 	resolveOpts := oras.DefaultResolveOptions
@@ -0,0 +1,464 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/cache"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/version"
+)
+
+const (
+	// certExpiryWarning is the remaining validity of a certificate below
+	// which a warning is reported.
+	certExpiryWarning = 30 * 24 * time.Hour
+	// clockSkewWarning and clockSkewFailure are the clock skews from a
+	// registry above which a warning or a failure is reported.
+	clockSkewWarning = 30 * time.Second
+	clockSkewFailure = 5 * time.Minute
+)
+
+// proxyEnvs are the environment variables configuring HTTP proxies.
+var proxyEnvs = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"}
+
+type doctorOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+	option.Remote
+
+	registry string
+}
+
+func doctorCmd() *cobra.Command {
+	var opts doctorOptions
+	cmd := &cobra.Command{
+		Use:   "doctor [flags] [<registry>]",
+		Short: "[Preview] Diagnose the environment and the connection to a registry",
+		Long: `[Preview] Diagnose the environment and the connection to a registry
+
+Reports the ORAS version, the config file, the credential store and its
+credential helpers, the local content cache and the proxy settings in use. If a
+registry is specified, its DNS resolution, TLS certificate, clock skew and
+authentication are checked as well. Each check passes, warns or fails, and the
+command fails if any check fails.
+
+** This command is in preview and under development. **
+
+Example - Diagnose the environment:
+  oras doctor
+
+Example - Diagnose the environment and the connection to registry 'localhost:5000':
+  oras doctor localhost:5000
+
+Example - Diagnose the connection to a registry using a specific registry config file:
+  oras doctor --registry-config path/to/config.json registry.example.com
+
+Example - [Experimental] Diagnose and output the result in JSON format:
+  oras doctor registry.example.com --format json
+`,
+		Args: oerrors.CheckArgs(argument.AtMost(1), "the registry to diagnose"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.registry = args[0]
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, &opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+func runDoctor(cmd *cobra.Command, opts *doctorOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewDoctorHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	doctor := model.NewDoctor(opts.registry)
+	doctor.Add("Version", model.CheckPass, fmt.Sprintf("%s (%s, %s/%s)", version.GetVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH))
+	status, message := checkConfigFile()
+	doctor.Add("Config file", status, message)
+	configPaths, status, message := checkCredentialStore(&opts.Remote)
+	doctor.Add("Credential store", status, message)
+	if configPaths != nil {
+		status, message = checkCredentialHelpers(configPaths, exec.LookPath)
+		doctor.Add("Credential helpers", status, message)
+	}
+	status, message = checkCache(&opts.Cache)
+	doctor.Add("Cache", status, message)
+
+	var registryURL *url.URL
+	var reg *remote.Registry
+	if opts.registry != "" {
+		if reg, err = opts.NewRegistry(opts.registry, opts.Common, logger); err != nil {
+			return err
+		}
+		registryURL = &url.URL{Scheme: "https", Host: reg.Reference.Host(), Path: "/v2/"}
+		if reg.PlainHTTP {
+			registryURL.Scheme = "http"
+		}
+	}
+	status, message = checkProxy(registryURL, http.ProxyFromEnvironment)
+	doctor.Add("Proxy", status, message)
+	if reg != nil {
+		diagnoseRegistry(ctx, doctor, reg, registryURL, opts)
+	}
+
+	if err := handler.OnDiagnosed(doctor); err != nil {
+		return err
+	}
+	if err := handler.Render(); err != nil {
+		return err
+	}
+	if n := doctor.Count(model.CheckFail); n > 0 {
+		return fmt.Errorf("%d diagnostic check(s) failed", n)
+	}
+	return nil
+}
+
+// diagnoseRegistry checks the DNS resolution, TLS certificate, clock skew and
+// authentication of reg.
+func diagnoseRegistry(ctx context.Context, doctor *model.Doctor, reg *remote.Registry, registryURL *url.URL, opts *doctorOptions) {
+	status, message := checkDNS(ctx, registryURL.Hostname())
+	doctor.Add("DNS", status, message)
+	if status == model.CheckFail {
+		return
+	}
+
+	// ping without authentication to inspect the connection
+	client := http.DefaultClient
+	if authClient, ok := reg.Client.(*auth.Client); ok && authClient.Client != nil {
+		client = authClient.Client
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL.String(), nil)
+	if err != nil {
+		doctor.Add("Connection", model.CheckFail, err.Error())
+		return
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			doctor.Add("TLS", model.CheckFail, certErr.Error())
+		} else {
+			doctor.Add("Connection", model.CheckFail, err.Error())
+		}
+		return
+	}
+	resp.Body.Close()
+	if reg.PlainHTTP {
+		doctor.Add("TLS", model.CheckWarn, "plain HTTP is used")
+	} else {
+		status, message = checkCertificate(resp.TLS, time.Now())
+		doctor.Add("TLS", status, message)
+	}
+	status, message = checkClockSkew(resp.Header.Get("Date"), time.Now())
+	doctor.Add("Clock skew", status, message)
+
+	err = reg.Ping(ctx)
+	status, message = checkAuthentication(ctx, err, reg.Reference.Registry, &opts.Remote)
+	doctor.Add("Authentication", status, message)
+}
+
+// checkConfigFile checks the oras config file.
+func checkConfigFile() (string, string) {
+	path, err := config.Path()
+	if err != nil {
+		return model.CheckFail, err.Error()
+	}
+	if _, err := config.Load(path); err != nil {
+		return model.CheckFail, err.Error()
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return model.CheckPass, path + " (not found, defaults are used)"
+	}
+	return model.CheckPass, path
+}
+
+// checkCredentialStore checks the credential store and returns the paths of
+// its config files.
+func checkCredentialStore(remo *option.Remote) ([]string, string, string) {
+	if _, err := remo.CredentialStore(); err != nil {
+		return nil, model.CheckFail, err.Error()
+	}
+	paths := remo.Configs
+	if path, err := remo.ConfigPath(); err == nil {
+		paths = []string{path}
+	}
+	message := strings.Join(paths, ", ")
+	if remo.Username != "" || remo.Secret != "" {
+		message += " (overridden by the credential flags)"
+	}
+	return paths, model.CheckPass, message
+}
+
+// dockerConfig is the part of a registry config file that configures
+// credential helpers.
+type dockerConfig struct {
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+	Auths       map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+}
+
+// checkCredentialHelpers checks that the credential helpers configured in
+// the config files are installed, and warns about plaintext credentials.
+func checkCredentialHelpers(paths []string, lookPath func(string) (string, error)) (string, string) {
+	var helpers, missing, plaintext []string
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return model.CheckFail, err.Error()
+		}
+		var cfg dockerConfig
+		if err := json.Unmarshal(content, &cfg); err != nil {
+			return model.CheckFail, fmt.Sprintf("failed to parse %s: %v", path, err)
+		}
+
+		names := map[string]string{}
+		if cfg.CredsStore != "" {
+			names[cfg.CredsStore] = "default"
+		}
+		for registry, name := range cfg.CredHelpers {
+			if names[name] == "" {
+				names[name] = registry
+			} else {
+				names[name] += ", " + registry
+			}
+		}
+		for name, registries := range names {
+			helpers = append(helpers, fmt.Sprintf("%s (%s)", name, registries))
+			if _, err := lookPath("docker-credential-" + name); err != nil {
+				missing = append(missing, "docker-credential-"+name)
+			}
+		}
+		if cfg.CredsStore == "" {
+			var n int
+			for registry, a := range cfg.Auths {
+				if (a.Auth != "" || a.IdentityToken != "") && cfg.CredHelpers[registry] == "" {
+					n++
+				}
+			}
+			if n > 0 {
+				plaintext = append(plaintext, fmt.Sprintf("%d in %s", n, path))
+			}
+		}
+	}
+	sort.Strings(helpers)
+	sort.Strings(missing)
+
+	switch {
+	case len(missing) > 0:
+		return model.CheckFail, strings.Join(missing, ", ") + " not found in PATH"
+	case len(plaintext) > 0:
+		return model.CheckWarn, "credentials are stored in plaintext: " + strings.Join(plaintext, ", ")
+	case len(helpers) == 0:
+		return model.CheckPass, "none configured"
+	default:
+		return model.CheckPass, strings.Join(helpers, ", ")
+	}
+}
+
+// checkCache checks the local content cache.
+func checkCache(opts *option.Cache) (string, string) {
+	root, err := opts.CacheRoot()
+	if err != nil {
+		return model.CheckFail, err.Error()
+	}
+	if root == "" {
+		return model.CheckPass, "not configured"
+	}
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return model.CheckPass, root + " (empty)"
+	}
+	usage, err := cache.GetUsage(root)
+	if err != nil {
+		return model.CheckWarn, fmt.Sprintf("%s: %v", root, err)
+	}
+	return model.CheckPass, fmt.Sprintf("%s (%s, %d blobs)", root, humanize.ToBytes(usage.Size), usage.BlobCount)
+}
+
+// checkProxy reports the proxy environment and the proxy selected by proxy
+// for registryURL if specified.
+func checkProxy(registryURL *url.URL, proxy func(*http.Request) (*url.URL, error)) (string, string) {
+	var settings []string
+	for _, env := range proxyEnvs {
+		if value := os.Getenv(env); value != "" {
+			settings = append(settings, env+"="+redactURL(value))
+		}
+	}
+	message := "no proxy configured"
+	if len(settings) > 0 {
+		message = strings.Join(settings, ", ")
+	}
+	if registryURL == nil {
+		return model.CheckPass, message
+	}
+
+	proxyURL, err := proxy(&http.Request{URL: registryURL})
+	if err != nil {
+		return model.CheckFail, fmt.Sprintf("%s: %v", message, err)
+	}
+	if proxyURL != nil {
+		return model.CheckPass, fmt.Sprintf("%s; %s is accessed via %s", message, registryURL.Host, redactURL(proxyURL.String()))
+	}
+	if len(settings) > 0 {
+		return model.CheckPass, fmt.Sprintf("%s; %s is accessed directly", message, registryURL.Host)
+	}
+	return model.CheckPass, message
+}
+
+// redactURL hides the password of a URL.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	return u.Redacted()
+}
+
+// checkDNS resolves host.
+func checkDNS(ctx context.Context, host string) (string, string) {
+	if net.ParseIP(host) != nil {
+		return model.CheckPass, host + " is an IP address"
+	}
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return model.CheckFail, err.Error()
+	}
+	return model.CheckPass, fmt.Sprintf("%s resolves to %s", host, strings.Join(addrs, ", "))
+}
+
+// checkCertificate checks the certificate chain presented in state.
+func checkCertificate(state *tls.ConnectionState, now time.Time) (string, string) {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return model.CheckFail, "no certificate is presented"
+	}
+	leaf := state.PeerCertificates[0]
+	message := fmt.Sprintf("%s issued by %s, chain of %d, valid until %s", certName(leaf), leaf.Issuer.CommonName, len(state.PeerCertificates), leaf.NotAfter.UTC().Format(time.DateOnly))
+	switch remaining := leaf.NotAfter.Sub(now); {
+	case remaining <= 0:
+		return model.CheckFail, fmt.Sprintf("certificate of %s expired on %s", certName(leaf), leaf.NotAfter.UTC().Format(time.DateOnly))
+	case now.Before(leaf.NotBefore):
+		return model.CheckFail, fmt.Sprintf("certificate of %s is not valid before %s", certName(leaf), leaf.NotBefore.UTC().Format(time.RFC3339))
+	case len(state.VerifiedChains) == 0:
+		return model.CheckWarn, message + "; certificate verification is skipped"
+	case remaining < certExpiryWarning:
+		return model.CheckWarn, fmt.Sprintf("%s; expires in %d days", message, int(remaining.Hours()/24))
+	default:
+		return model.CheckPass, message
+	}
+}
+
+func certName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return cert.Subject.String()
+}
+
+// checkClockSkew compares the local clock now with the Date header of a
+// registry response.
+func checkClockSkew(date string, now time.Time) (string, string) {
+	if date == "" {
+		return model.CheckWarn, "registry response has no Date header"
+	}
+	registryTime, err := http.ParseTime(date)
+	if err != nil {
+		return model.CheckWarn, fmt.Sprintf("invalid Date header %q", date)
+	}
+	skew := now.Sub(registryTime).Truncate(time.Second)
+	direction := "ahead of"
+	if skew < 0 {
+		skew = -skew
+		direction = "behind"
+	}
+	message := fmt.Sprintf("local clock is %s %s the registry", skew, direction)
+	switch {
+	case skew > clockSkewFailure:
+		return model.CheckFail, message
+	case skew > clockSkewWarning:
+		return model.CheckWarn, message
+	default:
+		return model.CheckPass, message
+	}
+}
+
+// checkAuthentication reports the result pingErr of an authenticated ping.
+func checkAuthentication(ctx context.Context, pingErr error, registry string, remo *option.Remote) (string, string) {
+	if pingErr != nil {
+		var errResp *errcode.ErrorResponse
+		if errors.Is(pingErr, auth.ErrBasicCredentialNotFound) || (errors.As(pingErr, &errResp) && errResp.StatusCode == http.StatusUnauthorized) {
+			return model.CheckFail, fmt.Sprintf("%v; try `oras login %s`", pingErr, registry)
+		}
+		return model.CheckFail, pingErr.Error()
+	}
+	if remo.Username != "" || remo.Secret != "" {
+		return model.CheckPass, "ping succeeded with the credential flags"
+	}
+	if store, err := remo.CredentialStore(); err == nil {
+		if cred, err := store.Get(ctx, registry); err == nil && cred != auth.EmptyCredential {
+			if cred.Username != "" {
+				return model.CheckPass, "ping succeeded as " + cred.Username
+			}
+			return model.CheckPass, "ping succeeded with an identity token"
+		}
+	}
+	return model.CheckPass, "ping succeeded anonymously"
+}
@@ -0,0 +1,149 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+type inspectOptions struct {
+	option.Cache
+	option.Common
+	option.Format
+	option.Platform
+	option.Target
+}
+
+func inspectCmd() *cobra.Command {
+	var opts inspectOptions
+	cmd := &cobra.Command{
+		Use:   "inspect [flags] <name>{:<tag>|@<digest>}",
+		Short: "[Preview] Inspect an artifact in a registry or an OCI image layout",
+		Long: `[Preview] Inspect an artifact in a registry or an OCI image layout
+
+Prints the media type, artifact type, digest and size of the artifact, a
+summary of its config, its layers or platforms, its annotations and a summary
+of its referrers.
+
+** This command is in preview and under development. **
+
+Example - Inspect the artifact 'hello:v1' in registry 'localhost:5000':
+  oras inspect localhost:5000/hello:v1
+
+Example - Inspect the linux/arm64 manifest of a multi-arch image:
+  oras inspect --platform linux/arm64 localhost:5000/hello:v1
+
+Example - [Experimental] Inspect the artifact and output in JSON format:
+  oras inspect localhost:5000/hello:v1 --format json
+
+Example - [Experimental] Print the title and size of each layer with Go template:
+  oras inspect localhost:5000/hello:v1 --format go-template --template '{{range .layers}}{{.title}} {{humanSize .size}}{{"\n"}}{{end}}'
+
+Example - Inspect the artifact tagged 'v1' in an OCI image layout folder 'layout-dir':
+  oras inspect --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the target artifact to inspect"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(cmd, &opts)
+		},
+	}
+
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	opts.EnableDistributionSpecFlag()
+	opts.EnableRemoteCacheFlags()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runInspect(cmd *cobra.Command, opts *inspectOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	src, err := opts.CachedGraphTarget(target)
+	if err != nil {
+		return err
+	}
+	handler, err := display.NewInspectHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	inspected, err := inspect(ctx, src, opts.Reference, opts.Path, opts.Platform.Platform)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnInspected(inspected); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// inspect fetches the manifest identified by reference, the image config it
+// references and its direct referrers.
+func inspect(ctx context.Context, src oras.ReadOnlyGraphTarget, reference string, path string, targetPlatform *ocispec.Platform) (*model.Inspect, error) {
+	desc, manifest, err := fetchManifest(ctx, src, reference, targetPlatform)
+	if err != nil {
+		return nil, err
+	}
+	inspected, err := model.NewInspect(path, desc, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if inspected.IsImageConfig() {
+		config, err := content.FetchAll(ctx, src, ocispec.Descriptor{
+			MediaType: inspected.Config.MediaType,
+			Digest:    inspected.Config.Digest,
+			Size:      inspected.Config.Size,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := inspected.SetImageConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	referrers, err := registry.Referrers(ctx, src, desc, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, referrer := range referrers {
+		inspected.AddReferrer(referrer)
+	}
+	return inspected, nil
+}
@@ -58,9 +58,18 @@ Example - Fetch the descriptor of a manifest from a registry:
 Example - [Experimental] Fetch the manifest digest from a registry similar to the resolve command:
   oras manifest fetch --format go-template --template '{{ .digest }}' localhost:5000/hello:v1
 
+Example - [Experimental] Fetch the manifest and print its short digest and human-readable size:
+  oras manifest fetch --format go-template --template '{{ shortDigest . }} {{ humanSize .size }}' localhost:5000/hello:v1
+
+Example - [Experimental] Fetch the manifest and format output with a Go template file:
+  oras manifest fetch --format go-template --template-file manifest.tmpl localhost:5000/hello:v1
+
 Example - [Experimental] Fetch manifest and output metadata encoded in JSON:
   oras manifest fetch localhost:5000/hello:v1 --format json
 
+Example - [Experimental] Fetch manifest and output metadata encoded in YAML:
+  oras manifest fetch localhost:5000/hello:v1 --format yaml
+
 Example - Fetch manifest from a registry with specified media type:
   oras manifest fetch --media-type 'application/vnd.oci.image.manifest.v1+json' localhost:5000/hello:v1
 
@@ -107,9 +116,11 @@ Example - Fetch raw manifest tagged 'example.com:v1' from an OCI image layout fo
 	opts.SetTypes(
 		option.FormatTypeText,
 		option.FormatTypeJSON.WithUsage("Print in prettified JSON format"),
+		option.FormatTypeYAML,
 		option.FormatTypeGoTemplate.WithUsage("Print using the given Go template"),
 	)
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
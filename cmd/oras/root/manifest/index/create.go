@@ -50,6 +50,7 @@ var mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126
 
 type createOptions struct {
 	option.Common
+	option.Format
 	option.Target
 	option.Pretty
 	option.Annotation
@@ -93,9 +94,15 @@ Example - Create an index and save it locally to index.json, auto push will be d
 
 Example - Create an index and output the index to stdout, auto push will be disabled:
   oras manifest index create localhost:5000/hello linux-arm64 --output - --pretty
+
+Example - [Experimental] Create and push an index and output the result in JSON format:
+  oras manifest index create localhost:5000/hello:v1 linux-amd64 linux-arm64 --format json
 `,
 		Args: oerrors.CheckArgs(argument.AtLeast(1), "the destination index to create."),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.outputPath == "-" && opts.FormatFlag != option.FormatTypeText.Name {
+				return fmt.Errorf("`--output -` cannot be used with `--format %s` at the same time", opts.FormatFlag)
+			}
 			refs := strings.Split(args[0], ",")
 			opts.RawReference = refs[0]
 			opts.extraRefs = refs[1:]
@@ -109,6 +116,7 @@ Example - Create an index and output the index to stdout, auto push will be disa
 	}
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type for overall index")
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "file `path` to write the created index to, use - for stdout")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
@@ -124,7 +132,10 @@ func createIndex(cmd *cobra.Command, opts createOptions) error {
 			return err
 		}
 	}
-	displayStatus, displayMetadata, displayContent := display.NewManifestIndexCreateHandler(opts.outputPath, opts.Printer, opts.Pretty.Pretty)
+	displayStatus, displayMetadata, displayContent, err := display.NewManifestIndexCreateHandler(opts.outputPath, opts.Printer, opts.Format, opts.Pretty.Pretty, &opts.Target)
+	if err != nil {
+		return err
+	}
 	manifests, err := fetchSourceManifests(ctx, displayStatus, target, opts.sources)
 	if err != nil {
 		return err
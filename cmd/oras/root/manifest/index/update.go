@@ -38,6 +38,7 @@ import (
 
 type updateOptions struct {
 	option.Common
+	option.Format
 	option.Target
 	option.Pretty
 
@@ -82,12 +83,18 @@ Example - Update an index to use a different artifact type:
 
 Example - Update an index to remove any existing artifact type:
   oras manifest index update localhost:5000/hello:v1 --artifact-type=""
+
+Example - [Experimental] Update an index and output the result in JSON format:
+  oras manifest index update localhost:5000/hello:v1 --add linux-amd64 --format json
   `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the target index to update"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "tag", "output"); err != nil {
 				return err
 			}
+			if opts.outputPath == "-" && opts.FormatFlag != option.FormatTypeText.Name {
+				return fmt.Errorf("`--output -` cannot be used with `--format %s` at the same time", opts.FormatFlag)
+			}
 			opts.RawReference = args[0]
 			for _, manifestRef := range opts.removeArguments {
 				if !contentutil.IsDigest(manifestRef) {
@@ -100,6 +107,7 @@ Example - Update an index to remove any existing artifact type:
 			return updateIndex(cmd, opts)
 		},
 	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "new artifact type for overall index")
 	cmd.Flags().StringArrayVarP(&opts.addArguments, "add", "", nil, "manifests to add to the index")
@@ -129,7 +137,10 @@ func updateIndex(cmd *cobra.Command, opts updateOptions) error {
 			return err
 		}
 	}
-	displayStatus, displayMetadata, displayContent := display.NewManifestIndexUpdateHandler(opts.outputPath, opts.Printer, opts.Pretty.Pretty)
+	displayStatus, displayMetadata, displayContent, err := display.NewManifestIndexUpdateHandler(opts.outputPath, opts.Printer, opts.Format, opts.Pretty.Pretty, &opts.Target)
+	if err != nil {
+		return err
+	}
 	index, err := fetchIndex(ctx, displayStatus, target, opts.Reference)
 	if err != nil {
 		return err
@@ -158,7 +169,7 @@ func updateIndex(cmd *cobra.Command, opts updateOptions) error {
 	if err := displayStatus.OnIndexPacked(desc); err != nil {
 		return err
 	}
-	path := getPushPath(opts.RawReference, opts.Type, opts.Reference, opts.Path)
+	path := getPushPath(opts.RawReference, opts.Target.Type, opts.Reference, opts.Path)
 	if err := displayContent.OnContentCreated(indexBytes); err != nil {
 		return err
 	}
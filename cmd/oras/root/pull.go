@@ -17,15 +17,24 @@ package root
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
@@ -35,7 +44,14 @@ import (
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/descriptor"
+	ofile "oras.land/oras/internal/file"
 	"oras.land/oras/internal/graph"
+	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/progress"
+	"oras.land/oras/internal/ratelimit"
+	"oras.land/oras/internal/resume"
+	"oras.land/oras/internal/stats"
+	"oras.land/oras/internal/trace"
 )
 
 type pullOptions struct {
@@ -45,13 +61,25 @@ type pullOptions struct {
 	option.Target
 	option.Format
 	option.Terminal
+	option.RateLimit
+	option.Segment
+	option.Trace
+	option.Stats
 
 	concurrency       int
+	Extract           bool
+	Includes          []string
+	Excludes          []string
+	MediaTypes        []string
+	StripComponents   int
 	KeepOldFiles      bool
+	SkipUnchanged     bool
 	IncludeSubject    bool
 	PathTraversal     bool
 	Output            string
 	ManifestConfigRef string
+	// fileName is the title of the file to write to stdout with --output -.
+	fileName string
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -59,10 +87,15 @@ type pullOptions struct {
 func pullCmd() *cobra.Command {
 	var opts pullOptions
 	cmd := &cobra.Command{
-		Use:   "pull [flags] <name>{:<tag>|@<digest>}",
+		Use:   "pull [flags] <name>{:<tag>|@<digest>} [<file>]",
 		Short: "Pull files from a registry or an OCI image layout",
 		Long: `Pull files from a registry or an OCI image layout
 
+Interrupted downloads of files from a registry are kept next to the output
+files as '<file>.<digest prefix>.partial', and resumed by the next pull of the
+same content with range requests if the registry supports them. Files are
+moved into place once completely downloaded and verified.
+
 Example - Pull artifact files from a registry:
   oras pull localhost:5000/hello:v1
 
@@ -79,18 +112,48 @@ Example - Pull files from a registry with local cache:
   export ORAS_CACHE=~/.oras/cache
   oras pull localhost:5000/hello:v1
 
+Example - [Experimental] Pull files from a registry with local cache in the directory 'cache-dir':
+  oras pull --cache-dir cache-dir localhost:5000/hello:v1
+
+Example - [Experimental] Pull files previously pulled with local cache, without network access:
+  oras pull --cache-dir cache-dir --offline localhost:5000/hello:v1
+
+Example - Pull files and extract tar, tar+gzip layers into the directory 'out':
+  oras pull --extract --output out localhost:5000/hello:v1
+
+Example - Pull files and extract tar layers without their leading directory:
+  oras pull --extract --strip-components 1 localhost:5000/hello:v1
+
+Example - Pull only the files with names matching '*.json' except 'secret.json':
+  oras pull --include '*.json' --exclude secret.json localhost:5000/hello:v1
+
+Example - Pull only the files of media type 'application/vnd.example+json':
+  oras pull --media-type application/vnd.example+json localhost:5000/hello:v1
+
+Example - Pull the file 'config.yaml' and write its content to stdout:
+  oras pull --output - localhost:5000/hello:v1 config.yaml
+
+Example - Pull files again into the same directory, downloading only the changed files:
+  oras pull --skip-unchanged localhost:5000/hello:v2
+
 Example - Pull files from a registry with certain platform:
   oras pull --platform linux/arm/v5 localhost:5000/hello:v1
 
 Example - Pull all files with concurrency level tuned:
   oras pull --concurrency 6 localhost:5000/hello:v1
 
+Example - [Experimental] Pull files with each file larger than 256 MiB downloaded with 8 concurrent range requests:
+  oras pull --segments 8 --segment-threshold 256M localhost:5000/hello:v1
+
 Example - [Experimental] Pull files and format output in JSON:
   oras pull localhost:5000/hello:v1 --format json
 
 Example - [Experimental] Pull files and format output with Go template:
   oras pull localhost:5000/hello:v1 --format go-template="{{.reference}}"
 
+Example - [Preview] Pull files and write progress events as JSON lines to a file:
+  oras pull localhost:5000/hello:v1 --progress json --progress-file progress.json
+
 Example - Pull artifact files from an OCI image layout folder 'layout-dir':
   oras pull --oci-layout layout-dir:v1
 
@@ -100,38 +163,66 @@ Example - Pull artifact files from an OCI layout archive 'layout.tar':
 Example - Pull artifact files tagged 'example.com:v1' from an OCI image layout folder 'layout-dir':
   oras pull example.com:v1 --oci-layout-path layout-dir
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact reference you want to pull"),
+		Args: oerrors.CheckArgs(argument.Between(1, 2), "the artifact reference you want to pull, followed by the file name if --output - is used"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.RawReference = args[0]
+			if err := opts.parseStdoutFile(cmd, args[1:]); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("strip-components") && !opts.Extract {
+				return errors.New("--strip-components must be used in conjunction with --extract")
+			}
+			if err := checkPatterns(opts.Includes, "include"); err != nil {
+				return err
+			}
+			if err := checkPatterns(opts.Excludes, "exclude"); err != nil {
+				return err
+			}
+			if opts.StripComponents < 0 {
+				return fmt.Errorf("invalid value %d for flag --strip-components: must not be negative", opts.StripComponents)
+			}
 			err := option.Parse(cmd, &opts)
 			if err != nil {
 				return err
 			}
-			opts.DisableTTY(opts.Debug, false)
+			opts.DisableTTY(opts.Debug, opts.Output == "-")
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = opts.verbose
 			return runPull(cmd, &opts)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.KeepOldFiles, "keep-old-files", "k", false, "do not replace existing files when pulling, treat them as errors")
+	cmd.Flags().BoolVarP(&opts.SkipUnchanged, "skip-unchanged", "", false, "skip downloading files identical to the existing ones in the output directory, compared by size and digest")
 	cmd.Flags().BoolVarP(&opts.PathTraversal, "allow-path-traversal", "T", false, "allow storing files out of the output directory")
+	cmd.Flags().BoolVarP(&opts.Extract, "extract", "x", false, "extract titled tar, tar+gzip and tar+zstd layers into the output directory instead of saving them as files")
+	cmd.Flags().IntVarP(&opts.StripComponents, "strip-components", "", 0, "strip `number` leading path components from the names of extracted files")
+	cmd.Flags().StringArrayVarP(&opts.Includes, "include", "", nil, "only pull files with names matching the glob `pattern`, matched against the full name and its base name, can be used multiple times")
+	cmd.Flags().StringArrayVarP(&opts.Excludes, "exclude", "", nil, "skip files with names matching the glob `pattern`, matched against the full name and its base name, can be used multiple times")
+	cmd.Flags().StringArrayVarP(&opts.MediaTypes, "media-type", "", nil, "only pull files of the `media type`, can be used multiple times")
 	cmd.Flags().BoolVarP(&opts.IncludeSubject, "include-subject", "", false, "recursively pull the subject of artifacts")
-	cmd.Flags().StringVarP(&opts.Output, "output", "o", ".", "output directory")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", ".", "output directory, use - to write the content of the named file to stdout")
 	cmd.Flags().StringVarP(&opts.ManifestConfigRef, "config", "", "", "output manifest config file")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
-	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.Target)
+	return oerrors.Command(command.Traced(cmd, &opts.Trace), &opts.Target)
 }
 
 func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	statusHandler, metadataHandler, err := display.NewPullHandler(opts.Printer, opts.Format, opts.Path, opts.TTY)
+	ctx = opts.WithRateLimit(ctx)
+	ctx = opts.WithSegments(ctx)
+	if opts.Output == "-" {
+		return pullToStdout(ctx, cmd, logger, opts)
+	}
+	statusHandler, metadataHandler, err := display.NewPullHandler(opts.Printer, opts.Format, opts.Path, opts.Terminal)
 	if err != nil {
 		return err
 	}
@@ -152,6 +243,11 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	if err != nil {
 		return err
 	}
+	var resumable *resume.Target
+	if _, ok := target.(*remote.Repository); ok {
+		resumable = resume.NewTarget(src, opts.resumePath)
+		src = resumable
+	}
 	dst, err := file.New(opts.Output)
 	if err != nil {
 		return err
@@ -163,23 +259,149 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	}()
 	dst.AllowPathTraversalOnWrite = opts.PathTraversal
 	dst.DisableOverwrite = opts.KeepOldFiles
+	// files with duplicated content are restored in doPull only if selected
+	dst.ForceCAS = opts.filtered()
+	var pullDst oras.GraphTarget = dst
+	if resumable != nil {
+		pullDst = newResumeTarget(dst, resumable)
+	}
+	if opts.Extract {
+		pullDst = newExtractTarget(dst, opts.Output, orasio.ExtractOptions{
+			StripComponents:    opts.StripComponents,
+			AllowPathTraversal: opts.PathTraversal,
+			KeepOldFiles:       opts.KeepOldFiles,
+		})
+	}
+	if opts.Target.Type == option.TargetTypeOCILayout {
+		// pulls from OCI layouts are not throttled by the transport
+		pullDst = ratelimit.NewTarget(pullDst, ratelimit.DownloadReader)
+	}
 
-	desc, err := doPull(ctx, src, dst, copyOptions, metadataHandler, statusHandler, opts)
+	collector := opts.NewCollector()
+	endDownload := collector.StartPhase("download")
+	desc, err := doPull(ctx, src, pullDst, copyOptions, metadataHandler, statusHandler, collector, opts)
+	endDownload()
 	if err != nil {
-		if !errors.Is(err, file.ErrPathTraversalDisallowed) {
+		switch {
+		case errors.Is(err, file.ErrPathTraversalDisallowed):
+			// customize friendly message for path traversal error
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: `Pulling files outside of working directory is insecure and blocked by default. If you trust the content producer, use --allow-path-traversal to bypass this check.`,
+			}
+		case errors.Is(err, orasio.ErrUnsupportedCompression):
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: `Pull without --extract to save the layer as a file and decompress it with an external tool`,
+			}
+		}
+		return err
+	}
+	metadataHandler.OnPulled(&opts.Target, desc)
+	if collector != nil {
+		if err := metadataHandler.OnStats(collector.Summary()); err != nil {
 			return err
 		}
-		// customize friendly message for path traversal error
+	}
+	return metadataHandler.Render()
+}
+
+// parseStdoutFile parses the name of the file to be written to stdout, which
+// must be specified if and only if --output - is used.
+func (opts *pullOptions) parseStdoutFile(cmd *cobra.Command, names []string) error {
+	if opts.Output != "-" {
+		if len(names) > 0 {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("unexpected file name %q", names[0]),
+				Recommendation: "Please use `--output -` to write the content of the named file to stdout",
+			}
+		}
+		return nil
+	}
+	if len(names) == 0 {
 		return &oerrors.Error{
-			Err:            err,
-			Recommendation: `Pulling files outside of working directory is insecure and blocked by default. If you trust the content producer, use --allow-path-traversal to bypass this check.`,
+			Err:            errors.New("`--output -` requires the name of the file to write to stdout"),
+			Recommendation: fmt.Sprintf(`Please specify the file name after the reference, e.g. "%s --output - %s <file>"`, cmd.CommandPath(), opts.RawReference),
 		}
 	}
-	metadataHandler.OnPulled(&opts.Target, desc)
-	return metadataHandler.Render()
+	for _, flag := range []string{"extract", "strip-components", "include", "exclude", "media-type", "config", "include-subject", "keep-old-files", "skip-unchanged", "format"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("`--output -` cannot be used with `--%s` at the same time", flag)
+		}
+	}
+	opts.fileName = names[0]
+	return nil
+}
+
+// pullToStdout writes the content of the file named opts.fileName in the
+// artifact to stdout without touching the disk.
+func pullToStdout(ctx context.Context, cmd *cobra.Command, logger logrus.FieldLogger, opts *pullOptions) error {
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	src, err := opts.CachedTarget(target)
+	if err != nil {
+		return err
+	}
+	return opts.writeNamedFile(ctx, src, cmd.OutOrStdout())
 }
 
-func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) (ocispec.Descriptor, error) {
+// writeNamedFile writes the content of the file named opts.fileName in the
+// artifact to w.
+func (opts *pullOptions) writeNamedFile(ctx context.Context, src oras.ReadOnlyTarget, w io.Writer) error {
+	manifestDesc, manifestBytes, err := fetchManifest(ctx, src, opts.Reference, opts.Platform.Platform)
+	if err != nil {
+		return err
+	}
+	if descriptor.IsIndex(manifestDesc) {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s: %s is an index", opts.RawReference, manifestDesc.Digest),
+			Recommendation: "Please specify the manifest to pull from via the --platform flag",
+		}
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return err
+	}
+	var names []string
+	for _, layer := range append(manifest.Layers, manifest.Config) {
+		name := layer.Annotations[ocispec.AnnotationTitle]
+		if name == "" {
+			continue
+		}
+		if name != opts.fileName {
+			names = append(names, name)
+			continue
+		}
+		if layer.Annotations[file.AnnotationUnpack] == "true" {
+			return fmt.Errorf("%q is a directory and cannot be written to stdout", name)
+		}
+		rc, err := src.Fetch(ctx, layer)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		vr := content.NewVerifyReader(rc, layer)
+		if _, err := io.Copy(w, vr); err != nil {
+			return err
+		}
+		return vr.Verify()
+	}
+	recommendation := "The artifact contains no named file"
+	if len(names) > 0 {
+		recommendation = fmt.Sprintf("Available files: %s", strings.Join(names, ", "))
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("file %q not found in %s", opts.fileName, opts.RawReference),
+		Recommendation: recommendation,
+	}
+}
+
+func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, collector *stats.Collector, po *pullOptions) (ocispec.Descriptor, error) {
 	var configPath, configMediaType string
 	var err error
 
@@ -189,6 +411,7 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 			return ocispec.Descriptor{}, err
 		}
 	}
+	store := dst
 	dst, stopTrack, err := statusHandler.TrackTarget(dst)
 	if err != nil {
 		return ocispec.Descriptor{}, err
@@ -198,6 +421,14 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 	}()
 	var printed sync.Map
 	var getConfigOnce sync.Once
+	onNodeSkipped := func(desc ocispec.Descriptor) error {
+		collector.Record(desc, progress.StateSkipped)
+		return statusHandler.OnNodeSkipped(desc)
+	}
+	onNodeRestored := func(desc ocispec.Descriptor) error {
+		collector.Record(desc, progress.StateRestored)
+		return statusHandler.OnNodeRestored(desc)
+	}
 	opts.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		statusFetcher := content.FetcherFunc(func(ctx context.Context, target ocispec.Descriptor) (fetched io.ReadCloser, fetchErr error) {
 			if _, ok := printed.LoadOrStore(descriptor.GenerateContentKey(target), true); ok {
@@ -241,6 +472,37 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 
 		var ret []ocispec.Descriptor
 		for _, s := range nodes {
+			name := s.Annotations[ocispec.AnnotationTitle]
+			if name != "" && name != configPath && !po.selectFile(s) {
+				// files not selected are never downloaded
+				if err := notifyOnce(&printed, s, func(desc ocispec.Descriptor) error {
+					if err := metadataHandler.OnFileSkipped(name, desc, po.Path); err != nil {
+						return err
+					}
+					return onNodeSkipped(desc)
+				}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if path, ok := po.localPath(name); ok && po.SkipUnchanged && s.Annotations[file.AnnotationUnpack] != "true" {
+				// files identical to the existing ones are not downloaded
+				unchanged, err := ofile.Matches(path, s)
+				if err != nil {
+					return nil, err
+				}
+				if unchanged {
+					if err := notifyOnce(&printed, s, func(desc ocispec.Descriptor) error {
+						if err := metadataHandler.OnFileSkipped(name, desc, po.Path); err != nil {
+							return err
+						}
+						return onNodeSkipped(desc)
+					}); err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
 			if s.Annotations[ocispec.AnnotationTitle] == "" {
 				if content.Equal(s, ocispec.DescriptorEmptyJSON) {
 					// empty layer
@@ -258,7 +520,7 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 				}
 				if len(ss) == 0 {
 					// skip s if it is unnamed AND has no successors.
-					if err := notifyOnce(&printed, s, statusHandler.OnNodeSkipped); err != nil {
+					if err := notifyOnce(&printed, s, onNodeSkipped); err != nil {
 						return nil, err
 					}
 					continue
@@ -280,10 +542,18 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		}
 		for _, s := range successors {
 			if name, ok := s.Annotations[ocispec.AnnotationTitle]; ok {
+				if po.filtered() && name != configPath {
+					if !po.selectFile(s) {
+						continue
+					}
+					if err := restoreFile(ctx, store, s); err != nil {
+						return err
+					}
+				}
 				if err = metadataHandler.OnFilePulled(name, po.Output, s, po.Path); err != nil {
 					return err
 				}
-				if err = notifyOnce(&printed, s, statusHandler.OnNodeRestored); err != nil {
+				if err = notifyOnce(&printed, s, onNodeRestored); err != nil {
 					return err
 				}
 			}
@@ -292,11 +562,117 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		return statusHandler.OnNodeDownloaded(desc)
 	}
 
+	trace.TraceCopyGraph(ctx, &opts.CopyGraphOptions)
+	collector.TrackCopyGraph(&opts.CopyGraphOptions)
+
 	// Copy
 	desc, err := oras.Copy(ctx, src, po.Reference, dst, po.Reference, opts)
 	return desc, oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
 }
 
+// filtered returns true if any of the --include, --exclude and --media-type
+// filters is specified.
+func (opts *pullOptions) filtered() bool {
+	return len(opts.Includes) > 0 || len(opts.Excludes) > 0 || len(opts.MediaTypes) > 0
+}
+
+// selectFile returns true if the titled layer desc passes the --include,
+// --exclude and --media-type filters.
+func (opts *pullOptions) selectFile(desc ocispec.Descriptor) bool {
+	name := desc.Annotations[ocispec.AnnotationTitle]
+	if len(opts.MediaTypes) > 0 && !slices.Contains(opts.MediaTypes, desc.MediaType) {
+		return false
+	}
+	if len(opts.Includes) > 0 && !slices.ContainsFunc(opts.Includes, func(pattern string) bool {
+		return matchName(pattern, name)
+	}) {
+		return false
+	}
+	return !slices.ContainsFunc(opts.Excludes, func(pattern string) bool {
+		return matchName(pattern, name)
+	})
+}
+
+// matchName returns true if the glob pattern matches the file name or its
+// base name.
+func matchName(pattern, name string) bool {
+	name = filepath.ToSlash(name)
+	if matched, _ := path.Match(pattern, name); matched {
+		return true
+	}
+	matched, _ := path.Match(pattern, path.Base(name))
+	return matched
+}
+
+// checkPatterns checks the syntax of the glob patterns of the flag.
+func checkPatterns(patterns []string, flag string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid value %q for flag --%s: %w", pattern, flag, err)
+		}
+	}
+	return nil
+}
+
+// outputPath returns the path of the pulled file of the given name.
+func outputPath(outputDir, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(outputDir, name)
+}
+
+// resumePath returns the output path of the named content desc if its
+// download is resumable, or an empty string otherwise. Directories to be
+// unpacked and layers to be extracted are not resumable as they are not
+// saved as files.
+func (po *pullOptions) resumePath(desc ocispec.Descriptor) string {
+	if po.Extract || desc.Annotations[file.AnnotationUnpack] == "true" {
+		return ""
+	}
+	path, _ := po.localPath(desc.Annotations[ocispec.AnnotationTitle])
+	return path
+}
+
+// localPath returns the output path of the file of the given name, and false
+// if the name is empty or the path is out of the output directory without
+// --allow-path-traversal.
+func (po *pullOptions) localPath(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	path := outputPath(po.Output, name)
+	if !po.PathTraversal {
+		rel, err := filepath.Rel(po.Output, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			return "", false
+		}
+	}
+	return path, true
+}
+
+// restoreFile stores the named content desc in dst if it is deduplicated by
+// another name with the same content.
+func restoreFile(ctx context.Context, dst oras.Target, desc ocispec.Descriptor) error {
+	exists, err := dst.Exists(ctx, desc)
+	if err != nil || exists {
+		return err
+	}
+	rc, err := dst.Fetch(ctx, ocispec.Descriptor{
+		MediaType: desc.MediaType,
+		Digest:    desc.Digest,
+		Size:      desc.Size,
+	})
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	defer rc.Close()
+	return dst.Push(ctx, desc, rc)
+}
+
 func notifyOnce(notified *sync.Map, s ocispec.Descriptor, notify func(ocispec.Descriptor) error) error {
 	if _, loaded := notified.LoadOrStore(descriptor.GenerateContentKey(s), true); !loaded {
 		return notify(s)
@@ -0,0 +1,131 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	orasio "oras.land/oras/internal/io"
+)
+
+// extractTarget is a graph target extracting titled tar layers into a
+// directory instead of storing them as files. Layers to be unpacked by the
+// file store itself are left to it.
+type extractTarget struct {
+	oras.GraphTarget
+	dir  string
+	opts orasio.ExtractOptions
+
+	// lock serializes extractions as layers may overwrite each other
+	lock      sync.Mutex
+	extracted sync.Map // map[digest.Digest]bool
+}
+
+// newExtractTarget wraps target to extract tar layers into dir.
+func newExtractTarget(target oras.GraphTarget, dir string, opts orasio.ExtractOptions) *extractTarget {
+	return &extractTarget{
+		GraphTarget: target,
+		dir:         dir,
+		opts:        opts,
+	}
+}
+
+// Push extracts desc if it is a titled tar layer, or pushes it to the
+// underlying target otherwise. Files pushed with a tar media type but not
+// being tar archives, such as files pushed by oras with the default media
+// type, are pushed to the underlying target as well.
+func (t *extractTarget) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	compression, ok := extractCompression(desc)
+	if ok {
+		var err error
+		if r, ok, err = orasio.SniffTar(r, compression); err != nil {
+			return err
+		}
+	}
+	if !ok {
+		return t.GraphTarget.Push(ctx, desc, r)
+	}
+	// the layer is verified before extraction so that nothing in the
+	// directory is touched by tampered or corrupted content
+	staged, err := stageVerified(desc, r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = staged.Close()
+		_ = os.Remove(staged.Name())
+	}()
+	tr, err := orasio.Decompress(staged, compression)
+	if err != nil {
+		return err
+	}
+	defer tr.Close()
+
+	t.lock.Lock()
+	err = orasio.ExtractTar(tr, t.dir, t.opts)
+	t.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	t.extracted.Store(desc.Digest, true)
+	return nil
+}
+
+// stageVerified writes the content read from r to a temporary file and verifies
+// it against desc. The returned file is positioned at the start of the content.
+func stageVerified(desc ocispec.Descriptor, r io.Reader) (*os.File, error) {
+	f, err := os.CreateTemp("", "oras_extract_*")
+	if err != nil {
+		return nil, err
+	}
+	vr := content.NewVerifyReader(r, desc)
+	if _, err = io.Copy(f, vr); err == nil {
+		if err = vr.Verify(); err == nil {
+			_, err = f.Seek(0, io.SeekStart)
+		}
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// Exists returns true if desc is extracted or exists in the underlying
+// target.
+func (t *extractTarget) Exists(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+	if _, ok := t.extracted.Load(desc.Digest); ok {
+		return true, nil
+	}
+	return t.GraphTarget.Exists(ctx, desc)
+}
+
+// extractCompression returns the compression of desc if it is a titled tar
+// layer to be extracted.
+func extractCompression(desc ocispec.Descriptor) (string, bool) {
+	if desc.Annotations[ocispec.AnnotationTitle] == "" || desc.Annotations[file.AnnotationUnpack] == "true" {
+		return "", false
+	}
+	return orasio.TarCompression(desc.MediaType)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	orasio "oras.land/oras/internal/io"
+)
+
+func Test_extractTarget_Push(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "dir/hello.txt", Mode: 0644, Size: 5, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = tw.Write([]byte("hello"))
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.Bytes()
+	plain := []byte("not a tar archive")
+
+	newDesc := func(blob []byte, title string) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, blob)
+		if title != "" {
+			desc.Annotations = map[string]string{ocispec.AnnotationTitle: title}
+		}
+		return desc
+	}
+	tests := []struct {
+		name          string
+		desc          ocispec.Descriptor
+		blob          []byte
+		wantExtracted bool
+	}{
+		{"titled tar", newDesc(archive, "dir.tar"), archive, true},
+		{"untitled tar", newDesc(archive, ""), archive, false},
+		{"titled plain file", newDesc(plain, "plain.txt"), plain, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			store := memory.New()
+			target := newExtractTarget(store, dir, orasio.ExtractOptions{StripComponents: 1})
+			if err := target.Push(ctx, tt.desc, bytes.NewReader(tt.blob)); err != nil {
+				t.Fatal("Push() error =", err)
+			}
+			if exists, err := target.Exists(ctx, tt.desc); err != nil || !exists {
+				t.Errorf("Exists() = %v, %v, want true", exists, err)
+			}
+			inStore, err := store.Exists(ctx, tt.desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inStore == tt.wantExtracted {
+				t.Errorf("pushed to the underlying target = %v, want %v", inStore, !tt.wantExtracted)
+			}
+			_, err = os.Stat(filepath.Join(dir, "hello.txt"))
+			if extracted := err == nil; extracted != tt.wantExtracted {
+				t.Errorf("extracted = %v, want %v", extracted, tt.wantExtracted)
+			}
+		})
+	}
+}
+
+func Test_extractTarget_Push_digestMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "a.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("a"))
+	_ = tw.WriteHeader(&tar.Header{Name: "b.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("b"))
+	_ = tw.Close()
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, buf.Bytes())
+	desc.Annotations = map[string]string{ocispec.AnnotationTitle: "a.tar"}
+	// tamper the content of a.txt
+	tampered := bytes.Replace(buf.Bytes(), []byte("a\x00"), []byte("x\x00"), 1)
+	if bytes.Equal(tampered, buf.Bytes()) {
+		t.Fatal("failed to tamper the archive")
+	}
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(existing, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	target := newExtractTarget(memory.New(), dir, orasio.ExtractOptions{})
+	if err := target.Push(context.Background(), desc, bytes.NewReader(tampered)); err == nil {
+		t.Error("Push() error = nil, want digest mismatch")
+	}
+	if exists, _ := target.Exists(context.Background(), desc); exists {
+		t.Error("Exists() = true for a layer failed to be verified")
+	}
+
+	// the output directory is untouched
+	if got, err := os.ReadFile(existing); err != nil || string(got) != "old" {
+		t.Errorf("existing file = %q, %v, want %q", got, err, "old")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("output directory has %d entries, want 1", len(entries))
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras/internal/resume"
+)
+
+// resumeTarget is a graph target adding the files downloaded into place by a
+// resume target to the file store, so that the content of the files is
+// written only once.
+type resumeTarget struct {
+	oras.GraphTarget
+	store *file.Store
+	src   *resume.Target
+}
+
+// newResumeTarget wraps store to add the files downloaded by src.
+func newResumeTarget(store *file.Store, src *resume.Target) *resumeTarget {
+	return &resumeTarget{
+		GraphTarget: store,
+		store:       store,
+		src:         src,
+	}
+}
+
+// Push reads the content of desc to complete its download into place if it
+// is fetched from the resume target and adds the downloaded file to the file
+// store, or pushes desc to the file store otherwise.
+func (t *resumeTarget) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	path := t.src.Destination(desc)
+	if path == "" {
+		return t.GraphTarget.Push(ctx, desc, r)
+	}
+	if t.store.DisableOverwrite {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s: %w", path, file.ErrOverwriteDisallowed)
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	_, err = t.store.Add(ctx, desc.Annotations[ocispec.AnnotationTitle], desc.MediaType, path)
+	return err
+}
@@ -16,12 +16,31 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/cmd/oras/internal/display/metadata/structured"
+	"oras.land/oras/cmd/oras/internal/display/status"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/resume"
 )
 
 func Test_runPull_errType(t *testing.T) {
@@ -41,3 +60,383 @@ func Test_runPull_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_pullOptions_selectFile(t *testing.T) {
+	newDesc := func(name, mediaType string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType:   mediaType,
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		}
+	}
+	tests := []struct {
+		name string
+		opts pullOptions
+		desc ocispec.Descriptor
+		want bool
+	}{
+		{"no filters", pullOptions{}, newDesc("a.txt", "text/plain"), true},
+		{"include matched", pullOptions{Includes: []string{"*.txt"}}, newDesc("a.txt", "text/plain"), true},
+		{"include matched by base name", pullOptions{Includes: []string{"*.txt"}}, newDesc("dir/a.txt", "text/plain"), true},
+		{"include matched by full name", pullOptions{Includes: []string{"dir/*"}}, newDesc("dir/a.txt", "text/plain"), true},
+		{"include not matched", pullOptions{Includes: []string{"*.json", "*.yaml"}}, newDesc("a.txt", "text/plain"), false},
+		{"excluded", pullOptions{Includes: []string{"*.txt"}, Excludes: []string{"a.*"}}, newDesc("a.txt", "text/plain"), false},
+		{"exclude not matched", pullOptions{Excludes: []string{"b.*"}}, newDesc("a.txt", "text/plain"), true},
+		{"media type matched", pullOptions{MediaTypes: []string{"text/plain"}}, newDesc("a.txt", "text/plain"), true},
+		{"media type not matched", pullOptions{MediaTypes: []string{"application/json"}}, newDesc("a.txt", "text/plain"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.selectFile(tt.desc); got != tt.want {
+				t.Errorf("selectFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pullOptions_resumePath(t *testing.T) {
+	newDesc := func(name string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		}
+	}
+	tests := []struct {
+		name string
+		opts pullOptions
+		desc ocispec.Descriptor
+		want string
+	}{
+		{"file", pullOptions{Output: "out"}, newDesc("a.txt"), filepath.Join("out", "a.txt")},
+		{"file in sub directory", pullOptions{Output: "out"}, newDesc("dir/a.txt"), filepath.Join("out", "dir", "a.txt")},
+		{"untitled", pullOptions{Output: "out"}, ocispec.Descriptor{}, ""},
+		{"directory to unpack", pullOptions{Output: "out"}, ocispec.Descriptor{Annotations: map[string]string{ocispec.AnnotationTitle: "dir", file.AnnotationUnpack: "true"}}, ""},
+		{"extract", pullOptions{Output: "out", Extract: true}, newDesc("a.tar"), ""},
+		{"path traversal", pullOptions{Output: "out"}, newDesc("../a.txt"), ""},
+		{"absolute path", pullOptions{Output: "out"}, newDesc("/etc/shadow"), ""},
+		{"path traversal allowed", pullOptions{Output: "out", PathTraversal: true}, newDesc("../a.txt"), "a.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.resumePath(tt.desc); got != tt.want {
+				t.Errorf("resumePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checkPatterns(t *testing.T) {
+	if err := checkPatterns([]string{"*.txt", "dir/[ab].json"}, "include"); err != nil {
+		t.Errorf("checkPatterns() error = %v", err)
+	}
+	if err := checkPatterns([]string{"*.txt", "["}, "include"); err == nil {
+		t.Error("checkPatterns() error = nil, want error")
+	}
+}
+
+func Test_doPull_filters(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	pushBlob := func(name, mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if exists, _ := src.Exists(ctx, desc); !exists {
+			if err := src.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+		return desc
+	}
+	layers := []ocispec.Descriptor{
+		pushBlob("a.json", "application/json", []byte("{}")),
+		// same content as a.json
+		pushBlob("secret.json", "application/json", []byte("{}")),
+		pushBlob("readme.txt", "text/plain", []byte("hello")),
+	}
+	manifest, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: layers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	outDir := t.TempDir()
+	dst, err := file.New(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	opts := &pullOptions{
+		Includes: []string{"*.json", "*.txt"},
+		Excludes: []string{"secret.*"},
+		Output:   outDir,
+	}
+	opts.Reference = "v1"
+	dst.ForceCAS = opts.filtered()
+	var buf bytes.Buffer
+	metadataHandler := structured.NewPullHandler(&buf, output.PrintPrettyJSON, "test")
+	copyOptions := oras.DefaultCopyOptions
+	if _, err := doPull(ctx, src, dst, copyOptions, metadataHandler, status.NewDiscardHandler(), nil, opts); err != nil {
+		t.Fatal("doPull() error =", err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	sort.Strings(got)
+	if want := []string{"a.json", "readme.txt"}; !slices.Equal(got, want) {
+		t.Errorf("pulled files = %v, want %v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "secret.json")); err == nil {
+		t.Error("skipped file secret.json is written")
+	}
+
+	if err := metadataHandler.Render(); err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Files []struct {
+			Path string `json:"path"`
+		} `json:"files"`
+		Skipped []struct {
+			Name string `json:"name"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Files) != 2 {
+		t.Errorf("reported %d pulled files, want 2", len(result.Files))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Name != "secret.json" {
+		t.Errorf("reported skipped files = %+v, want secret.json", result.Skipped)
+	}
+}
+
+func Test_pullOptions_writeNamedFile(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	blob := []byte("key: value\n")
+	layer := content.NewDescriptorFromBytes("application/yaml", blob)
+	if err := src.Push(ctx, layer, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	layer.Annotations = map[string]string{ocispec.AnnotationTitle: "config.yaml"}
+	manifest, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &pullOptions{fileName: "config.yaml"}
+	opts.Reference = "v1"
+	var buf bytes.Buffer
+	if err := opts.writeNamedFile(ctx, src, &buf); err != nil {
+		t.Fatal("writeNamedFile() error =", err)
+	}
+	if got := buf.String(); got != string(blob) {
+		t.Errorf("writeNamedFile() wrote %q, want %q", got, blob)
+	}
+
+	opts.fileName = "missing.yaml"
+	err = opts.writeNamedFile(ctx, src, io.Discard)
+	var oerr *errors.Error
+	if !stderrors.As(err, &oerr) || !strings.Contains(oerr.Recommendation, "config.yaml") {
+		t.Errorf("writeNamedFile() error = %v, want not found error listing config.yaml", err)
+	}
+}
+
+func Test_pullOptions_parseStdoutFile(t *testing.T) {
+	newCmd := func(flags ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "pull"}
+		cmd.Flags().Bool("extract", false, "")
+		if err := cmd.Flags().Parse(flags); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+	tests := []struct {
+		name     string
+		output   string
+		cmd      *cobra.Command
+		names    []string
+		wantName string
+		wantErr  bool
+	}{
+		{"output directory", ".", newCmd(), nil, "", false},
+		{"file name without stdout", ".", newCmd(), []string{"a.txt"}, "", true},
+		{"stdout", "-", newCmd(), []string{"a.txt"}, "a.txt", false},
+		{"stdout without file name", "-", newCmd(), nil, "", true},
+		{"stdout with extract", "-", newCmd("--extract"), []string{"a.txt"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &pullOptions{Output: tt.output}
+			err := opts.parseStdoutFile(tt.cmd, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStdoutFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if opts.fileName != tt.wantName {
+				t.Errorf("parseStdoutFile() file name = %q, want %q", opts.fileName, tt.wantName)
+			}
+		})
+	}
+}
+
+// fetchCounter counts the fetches of each blob.
+type fetchCounter struct {
+	oras.Target
+	fetched map[string]int
+}
+
+func (c *fetchCounter) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	c.fetched[desc.Digest.String()]++
+	return c.Target.Fetch(ctx, desc)
+}
+
+func Test_doPull_resume(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	var layers []ocispec.Descriptor
+	for _, f := range []struct{ name, blob string }{
+		{"a.txt", "hello"},
+		{"dir/b.txt", "world"},
+		{"c.txt", "hello"},
+	} {
+		desc := content.NewDescriptorFromBytes("text/plain", []byte(f.blob))
+		if exists, _ := store.Exists(ctx, desc); !exists {
+			if err := store.Push(ctx, desc, strings.NewReader(f.blob)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: f.name}
+		layers = append(layers, desc)
+	}
+	manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: layers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	outDir := t.TempDir()
+	// a stale partial download of dir/b.txt of other content
+	stale := resume.PartialPath(filepath.Join(outDir, "dir", "b.txt"), digest.FromString("previous"))
+	if err := os.MkdirAll(filepath.Dir(stale), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("prev"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := file.New(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	opts := &pullOptions{Output: outDir}
+	opts.Reference = "v1"
+	src := resume.NewTarget(store, opts.resumePath)
+	metadataHandler := structured.NewPullHandler(io.Discard, output.PrintPrettyJSON, "test")
+	if _, err := doPull(ctx, src, newResumeTarget(dst, src), oras.DefaultCopyOptions, metadataHandler, status.NewDiscardHandler(), nil, opts); err != nil {
+		t.Fatal("doPull() error =", err)
+	}
+
+	for name, want := range map[string]string{"a.txt": "hello", "dir/b.txt": "world", "c.txt": "hello"} {
+		got, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("content of %s = %q, want %q", name, got, want)
+		}
+	}
+	for _, pattern := range []string{"*" + resume.PartialSuffix, "dir/*" + resume.PartialSuffix} {
+		if partials, _ := filepath.Glob(filepath.Join(outDir, pattern)); len(partials) != 0 {
+			t.Errorf("partial files are left: %v", partials)
+		}
+	}
+}
+
+func Test_doPull_skipUnchanged(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	var layers []ocispec.Descriptor
+	for name, blob := range map[string]string{"same.txt": "same", "changed.txt": "new", "new.txt": "brand new"} {
+		desc := content.NewDescriptorFromBytes("text/plain", []byte(blob))
+		if err := store.Push(ctx, desc, strings.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+		layers = append(layers, desc)
+	}
+	manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: layers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	outDir := t.TempDir()
+	for name, existing := range map[string]string{"same.txt": "same", "changed.txt": "old"} {
+		if err := os.WriteFile(filepath.Join(outDir, name), []byte(existing), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dst, err := file.New(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	opts := &pullOptions{SkipUnchanged: true, Output: outDir}
+	opts.Reference = "v1"
+	src := &fetchCounter{Target: store, fetched: map[string]int{}}
+	var out bytes.Buffer
+	metadataHandler := structured.NewPullHandler(&out, output.PrintPrettyJSON, "test")
+	if _, err := doPull(ctx, src, dst, oras.DefaultCopyOptions, metadataHandler, status.NewDiscardHandler(), nil, opts); err != nil {
+		t.Fatal("doPull() error =", err)
+	}
+
+	// unchanged files are reported as skipped
+	if err := metadataHandler.Render(); err != nil {
+		t.Fatal("Render() error =", err)
+	}
+	var pulled struct {
+		Skipped []struct {
+			Name string `json:"name"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &pulled); err != nil {
+		t.Fatal(err)
+	}
+	if len(pulled.Skipped) != 1 || pulled.Skipped[0].Name != "same.txt" {
+		t.Errorf("skipped files = %+v, want same.txt", pulled.Skipped)
+	}
+
+	for _, layer := range layers {
+		name := layer.Annotations[ocispec.AnnotationTitle]
+		wantFetched := 1
+		if name == "same.txt" {
+			wantFetched = 0
+		}
+		if got := src.fetched[layer.Digest.String()]; got != wantFetched {
+			t.Errorf("%s fetched %d time(s), want %d", name, got, wantFetched)
+		}
+	}
+	for name, want := range map[string]string{"same.txt": "same", "changed.txt": "new", "new.txt": "brand new"} {
+		got, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("content of %s = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -37,7 +37,9 @@ import (
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/listener"
+	"oras.land/oras/internal/ratelimit"
 	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/trace"
 )
 
 type pushOptions struct {
@@ -48,6 +50,10 @@ type pushOptions struct {
 	option.Target
 	option.Format
 	option.Terminal
+	option.RateLimit
+	option.Chunk
+	option.Trace
+	option.Stats
 
 	extraRefs         []string
 	manifestConfigRef string
@@ -115,6 +121,12 @@ Example - Push file "hi.txt" with multiple tags:
 Example - Push file "hi.txt" with multiple tags and concurrency level tuned:
   oras push --concurrency 6 localhost:5000/hello:tag1,tag2,tag3 hi.txt
 
+Example - [Experimental] Push file "model.bin" in chunks of 64 MiB:
+  oras push --chunk-size 64M localhost:5000/hello:v1 model.bin
+
+Example - [Preview] Push all files in a directory showing the overall progress and the files in progress only:
+  oras push --progress compact localhost:5000/hello:v1 ./data/
+
 Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with tag 'test':
   oras push --oci-layout layout-dir:test hi.txt
 
@@ -163,6 +175,7 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = opts.verbose
 			return runPush(cmd, &opts)
 		},
@@ -172,13 +185,16 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
-	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.Target)
+	return oerrors.Command(command.Traced(cmd, &opts.Trace), &opts.Target)
 }
 
 func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
+	ctx = opts.WithChunkedUpload(ctx)
+	collector := opts.NewCollector()
 
 	// prepare pack
 	packOpts := oras.PackManifestOptions{
@@ -222,11 +238,13 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	}
 	memoryStore := memory.New()
 	union := contentutil.MultiReadOnlyTarget(memoryStore, store)
-	statusHandler, metadataHandler, err := display.NewPushHandler(opts.Printer, opts.Format, opts.TTY, union)
+	statusHandler, metadataHandler, err := display.NewPushHandler(opts.Printer, opts.Format, opts.Terminal, union)
 	if err != nil {
 		return err
 	}
+	endLoad := collector.StartPhase("load")
 	descs, err := loadFiles(ctx, store, opts.Annotations, opts.FileRefs, statusHandler)
+	endLoad()
 	if err != nil {
 		return err
 	}
@@ -247,7 +265,12 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	if err != nil {
 		return err
 	}
-	dst, stopTrack, err := statusHandler.TrackTarget(originalDst)
+	var limitedDst oras.GraphTarget = originalDst
+	if opts.Target.Type == option.TargetTypeOCILayout {
+		// pushes to OCI layouts are not throttled by the transport
+		limitedDst = ratelimit.NewTarget(originalDst, ratelimit.UploadReader)
+	}
+	dst, stopTrack, err := statusHandler.TrackTarget(limitedDst)
 	if err != nil {
 		return err
 	}
@@ -256,6 +279,8 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	copyOptions.OnCopySkipped = statusHandler.OnCopySkipped
 	copyOptions.PreCopy = statusHandler.PreCopy
 	copyOptions.PostCopy = statusHandler.PostCopy
+	trace.TraceCopyGraph(ctx, &copyOptions.CopyGraphOptions)
+	collector.TrackCopyGraph(&copyOptions.CopyGraphOptions)
 	copyWithScopeHint := func(root ocispec.Descriptor) error {
 		// add both pull and push scope hints for dst repository
 		// to save potential push-scope token requests during copy
@@ -270,7 +295,9 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	}
 
 	// Push
+	endUpload := collector.StartPhase("upload")
 	root, err := doPush(dst, stopTrack, pack, copyWithScopeHint)
+	endUpload()
 	if err != nil {
 		return err
 	}
@@ -287,11 +314,19 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		tagBytesNOpts := oras.DefaultTagBytesNOptions
 		tagBytesNOpts.Concurrency = opts.concurrency
 		dst := listener.NewTagListener(originalDst, nil, metadataHandler.OnTagged)
-		if _, err = oras.TagBytesN(ctx, dst, root.MediaType, contentBytes, opts.extraRefs, tagBytesNOpts); err != nil {
+		endTag := collector.StartPhase("tag")
+		_, err = oras.TagBytesN(ctx, dst, root.MediaType, contentBytes, opts.extraRefs, tagBytesNOpts)
+		endTag()
+		if err != nil {
 			return err
 		}
 	}
 
+	if collector != nil {
+		if err := metadataHandler.OnStats(collector.Summary()); err != nil {
+			return err
+		}
+	}
 	err = metadataHandler.Render()
 	if err != nil {
 		return err
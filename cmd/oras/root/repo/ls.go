@@ -76,7 +76,7 @@ Example - [Experimental] List the repositories under the registry using the give
 
 	cmd.Flags().StringVar(&opts.last, "last", "", "start after the repository specified by `last`")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
-	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -70,6 +70,9 @@ Example - [Experimental] Show tags associated with a digest:
 Example - [Experimental] Show tags of the target repository in JSON format:
   oras repo tags localhost:5000/hello --format json
 
+Example - [Experimental] Show tags of the target repository in YAML format:
+  oras repo tags localhost:5000/hello --format yaml
+
 Example - [Experimental] Show tags of the target repository using the given Go template:
   oras repo tags localhost:5000/hello --format go-template --template "{{.tags}}"
 
@@ -89,7 +92,7 @@ Example - [Experimental] Show tags of a specific repository in OCI layout:
 	cmd.Flags().StringVar(&opts.last, "last", "", "start after the tag specified by `last`")
 	cmd.Flags().BoolVar(&opts.excludeDigestTag, "exclude-digest-tags", false, "[Preview] exclude all digest-like tags such as 'sha256-aaaa...'")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
-	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
@@ -28,7 +28,9 @@ import (
 )
 
 type resolveOptions struct {
+	option.Cache
 	option.Common
+	option.Format
 	option.Platform
 	option.Target
 
@@ -45,10 +47,16 @@ func resolveCmd() *cobra.Command {
 
 Example - Resolve digest of the target artifact:
   oras resolve localhost:5000/hello-world:v1
+
+Example - [Experimental] Resolve the descriptor of the target artifact in JSON format:
+  oras resolve localhost:5000/hello-world:v1 --format json
 `,
 		Args:    oerrors.CheckArgs(argument.Exactly(1), "the target artifact reference to resolve"),
 		Aliases: []string{"digest"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "format", "full-reference"); err != nil {
+				return err
+			}
 			opts.RawReference = args[0]
 			return option.Parse(cmd, &opts)
 		},
@@ -59,6 +67,8 @@ Example - Resolve digest of the target artifact:
 
 	cmd.Flags().BoolVarP(&opts.fullRef, "full-reference", "l", false, "print the full artifact reference with digest")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
+	opts.EnableRemoteCacheFlags()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
@@ -72,12 +82,22 @@ func runResolve(cmd *cobra.Command, opts *resolveOptions) error {
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
-	metadataHandler := display.NewResolveHandler(opts.Printer, opts.fullRef, opts.Path)
+	src, err := opts.CachedGraphTarget(repo)
+	if err != nil {
+		return err
+	}
+	metadataHandler, err := display.NewResolveHandler(opts.Printer, opts.Format, opts.fullRef, opts.Path)
+	if err != nil {
+		return err
+	}
 	resolveOpts := oras.DefaultResolveOptions
 	resolveOpts.TargetPlatform = opts.Platform.Platform
-	desc, err := oras.Resolve(ctx, repo, opts.Reference, resolveOpts)
+	desc, err := oras.Resolve(ctx, src, opts.Reference, resolveOpts)
 	if err != nil {
 		return fmt.Errorf("failed to resolve digest: %w", err)
 	}
-	return metadataHandler.OnResolved(desc)
+	if err := metadataHandler.OnResolved(desc); err != nil {
+		return err
+	}
+	return metadataHandler.Render()
 }
@@ -39,7 +39,9 @@ import (
 type restoreOptions struct {
 	option.Common
 	option.Remote
+	option.Format
 	option.Terminal
+	option.RateLimit
 
 	// flags
 	input            string
@@ -91,6 +93,9 @@ Example - Restore to a plain HTTP registry (no TLS):
 
 Example - Set custom concurrency level:
   oras restore --input hello --concurrency 6 localhost:5000/hello:v1
+
+Example - [Experimental] Restore and output the result in JSON format:
+  oras restore --input hello.tar --format json localhost:5000/hello:v1
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the targets to restore to"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -109,6 +114,7 @@ Example - Set custom concurrency level:
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() { _ = opts.Terminal.Close() }()
 			opts.Printer.Verbose = true // always print verbose output
 			return runRestore(cmd, &opts)
 		},
@@ -123,6 +129,7 @@ Example - Set custom concurrency level:
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
 	opts.EnableDistributionSpecFlag()
 	// apply flags
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -133,13 +140,17 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 	}
 	startTime := time.Now() // start timing the restore process
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	ctx = opts.WithRateLimit(ctx)
 
 	// prepare the target registry
 	dstRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
 	if err != nil {
 		return fmt.Errorf("failed to prepare target repository %q: %w", opts.repository, err)
 	}
-	statusHandler, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, dstRepo, opts.dryRun)
+	statusHandler, metadataHandler, err := display.NewRestoreHandler(opts.Printer, opts.Format, opts.Terminal, dstRepo, opts.input, opts.dryRun)
+	if err != nil {
+		return err
+	}
 
 	// prepare the source OCI store
 	var srcOCI oras.ReadOnlyGraphTarget
@@ -243,5 +254,8 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 	}
 
 	duration := time.Since(startTime)
-	return metadataHandler.OnRestoreCompleted(len(tags), opts.repository, duration)
+	if err := metadataHandler.OnRestoreCompleted(len(tags), opts.repository, duration); err != nil {
+		return err
+	}
+	return metadataHandler.Render()
 }
@@ -34,6 +34,7 @@ import (
 
 type tagOptions struct {
 	option.Common
+	option.Format
 	option.Target
 
 	concurrency int
@@ -61,6 +62,9 @@ Example - Tag the manifest 'v1.0.1' in 'localhost:5000/hello' to 'v1.0.1', 'v1.0
 
 Example - Tag the manifest 'v1.0.1' to 'v1.0.2' in an OCI image layout folder 'layout-dir':
   oras tag --oci-layout layout-dir:v1.0.1 v1.0.2
+
+Example - [Experimental] Tag the manifest 'v1.0.1' to 'v1.0.2' and output the result in JSON format:
+  oras tag localhost:5000/hello:v1.0.1 v1.0.2 --format json
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && (args[0] == "list" || args[0] == "ls") {
@@ -94,6 +98,7 @@ Example - Tag the manifest 'v1.0.1' to 'v1.0.2' in an OCI image layout folder 'l
 		},
 	}
 
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeYAML, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
@@ -117,7 +122,10 @@ func tagManifest(cmd *cobra.Command, opts *tagOptions) error {
 
 	tagNOpts := oras.DefaultTagNOptions
 	tagNOpts.Concurrency = opts.concurrency
-	tagHandler := display.NewTagHandler(opts.Printer, opts.Target)
+	tagHandler, err := display.NewTagHandler(opts.Printer, opts.Format, opts.Target)
+	if err != nil {
+		return err
+	}
 	tagListener := listener.NewTagListener(target, tagHandler.OnTagging, tagHandler.OnTagged)
 	_, err = oras.TagN(
 		ctx,
@@ -126,5 +134,8 @@ func tagManifest(cmd *cobra.Command, opts *tagOptions) error {
 		opts.targetRefs,
 		tagNOpts,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return tagHandler.Render()
 }
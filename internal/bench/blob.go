@@ -0,0 +1,51 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"io"
+	"math/rand"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Blob is a synthetic blob of pseudo-random content generated from a seed,
+// so that it can be pushed without being held in memory.
+type Blob struct {
+	Descriptor ocispec.Descriptor
+	seed       int64
+}
+
+// NewBlob generates the blob of the given size from seed.
+func NewBlob(seed int64, size int64) (Blob, error) {
+	blob := Blob{seed: seed}
+	digester := digest.Canonical.Digester()
+	if _, err := io.Copy(digester.Hash(), io.LimitReader(rand.New(rand.NewSource(seed)), size)); err != nil {
+		return Blob{}, err
+	}
+	blob.Descriptor = ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digester.Digest(),
+		Size:      size,
+	}
+	return blob, nil
+}
+
+// Reader returns a reader of the blob content.
+func (b Blob) Reader() io.Reader {
+	return io.LimitReader(rand.New(rand.NewSource(b.seed)), b.Descriptor.Size)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"io"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestBlob(t *testing.T) {
+	blob, err := NewBlob(42, 3000)
+	if err != nil {
+		t.Fatal("NewBlob() error =", err)
+	}
+	for i := 0; i < 2; i++ {
+		content, err := io.ReadAll(blob.Reader())
+		if err != nil {
+			t.Fatal("ReadAll() error =", err)
+		}
+		if got := int64(len(content)); got != blob.Descriptor.Size {
+			t.Fatalf("len(content) = %d, want %d", got, blob.Descriptor.Size)
+		}
+		if got := digest.FromBytes(content); got != blob.Descriptor.Digest {
+			t.Fatalf("digest = %v, want %v", got, blob.Descriptor.Digest)
+		}
+	}
+
+	other, err := NewBlob(43, 3000)
+	if err != nil {
+		t.Fatal("NewBlob() error =", err)
+	}
+	if other.Descriptor.Digest == blob.Descriptor.Digest {
+		t.Error("NewBlob() generated the same content for different seeds")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bench measures registry request latencies and generates synthetic
+// blobs for benchmarking.
+package bench
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// KindToken is the kind of requests fetching auth tokens.
+const KindToken = "TOKEN"
+
+// Recorder is an http.RoundTripper recording the latency of each request by
+// kind, which is either the request method or KindToken.
+type Recorder struct {
+	base http.RoundTripper
+
+	lock    sync.Mutex
+	samples map[string][]time.Duration
+}
+
+// NewRecorder returns a recorder sending requests via base.
+func NewRecorder(base http.RoundTripper) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{
+		base:    base,
+		samples: make(map[string][]time.Duration),
+	}
+}
+
+// RoundTrip sends req and records the time until the response headers are
+// received.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := r.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	kind := req.Method
+	if isTokenRequest(req) {
+		kind = KindToken
+	}
+	r.lock.Lock()
+	r.samples[kind] = append(r.samples[kind], elapsed)
+	r.lock.Unlock()
+	return resp, err
+}
+
+// Take returns the recorded latencies by kind and resets the recorder.
+func (r *Recorder) Take() map[string][]time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	samples := r.samples
+	r.samples = make(map[string][]time.Duration)
+	return samples
+}
+
+// isTokenRequest returns true if req fetches a bearer token, which is either
+// a GET request with the service or scope parameter or a POST form.
+func isTokenRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet:
+		query := req.URL.Query()
+		return query.Has("service") || query.Has("scope")
+	case http.MethodPost:
+		return req.Header.Get("Content-Type") == "application/x-www-form-urlencoded"
+	}
+	return false
+}
+
+// LatencySummary summarizes latencies.
+type LatencySummary struct {
+	Count int
+	Total time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// Summarize computes the percentiles of latencies.
+func Summarize(latencies []time.Duration) LatencySummary {
+	if len(latencies) == 0 {
+		return LatencySummary{}
+	}
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	return LatencySummary{
+		Count: len(sorted),
+		Total: total,
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P99:   percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of sorted by the nearest-rank
+// method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,110 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	recorder := NewRecorder(nil)
+	client := &http.Client{Transport: recorder}
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodHead, ts.URL+"/v2/test/blobs/sha256:a", nil),
+		httptest.NewRequest(http.MethodGet, ts.URL+"/v2/test/blobs/sha256:a", nil),
+		httptest.NewRequest(http.MethodGet, ts.URL+"/v2/test/blobs/sha256:b", nil),
+		httptest.NewRequest(http.MethodGet, ts.URL+"/token?scope=repository:test:pull&service=localhost", nil),
+	} {
+		req.RequestURI = ""
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	samples := recorder.Take()
+	for kind, want := range map[string]int{http.MethodHead: 1, http.MethodGet: 2, KindToken: 1} {
+		if got := len(samples[kind]); got != want {
+			t.Errorf("len(samples[%s]) = %d, want %d", kind, got, want)
+		}
+	}
+	if got := recorder.Take(); len(got) != 0 {
+		t.Errorf("Take() after Take() = %v, want empty", got)
+	}
+}
+
+func Test_isTokenRequest(t *testing.T) {
+	form := httptest.NewRequest(http.MethodPost, "https://auth.example/token", strings.NewReader(url.Values{"grant_type": {"password"}}.Encode()))
+	form.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	upload := httptest.NewRequest(http.MethodPost, "https://registry.example/v2/test/blobs/uploads/", nil)
+	tests := []struct {
+		name string
+		req  *http.Request
+		want bool
+	}{
+		{"token GET", httptest.NewRequest(http.MethodGet, "https://auth.example/token?scope=repository:test:pull", nil), true},
+		{"token GET with service only", httptest.NewRequest(http.MethodGet, "https://auth.example/token?service=registry", nil), true},
+		{"token POST", form, true},
+		{"blob GET", httptest.NewRequest(http.MethodGet, "https://registry.example/v2/test/blobs/sha256:a", nil), false},
+		{"upload POST", upload, false},
+		{"blob PUT", httptest.NewRequest(http.MethodPut, "https://registry.example/v2/test/blobs/uploads/1?digest=sha256:a", nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTokenRequest(tt.req); got != tt.want {
+				t.Errorf("isTokenRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	got := Summarize(latencies)
+	want := LatencySummary{
+		Count: 100,
+		Total: 5050 * time.Millisecond,
+		P50:   50 * time.Millisecond,
+		P90:   90 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+	if latencies[0] != 100*time.Millisecond {
+		t.Error("Summarize() modified the input")
+	}
+
+	if got := Summarize(nil); got != (LatencySummary{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero", got)
+	}
+	if got := Summarize([]time.Duration{time.Second}); got.P50 != time.Second || got.P99 != time.Second {
+		t.Errorf("Summarize() of a single latency = %+v", got)
+	}
+}
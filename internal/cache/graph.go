@@ -0,0 +1,259 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/contentutil"
+	onet "oras.land/oras/internal/net"
+)
+
+// GraphOptions configures caching of tag resolutions and referrers listings
+// on top of the content cache.
+type GraphOptions struct {
+	// Metadata stores the resolutions and listings. Nothing is recorded if
+	// Metadata is nil.
+	Metadata *Metadata
+	// ResolveTTL is how long a tag resolution is reused. Zero disables
+	// caching of tag resolutions.
+	ResolveTTL time.Duration
+	// ReferrersTTL is how long a referrers listing is reused. Zero disables
+	// caching of referrers listings.
+	ReferrersTTL time.Duration
+	// Offline serves everything from the cache without accessing the source,
+	// reusing recorded resolutions and listings regardless of their age.
+	Offline bool
+	// OnOfflineTag is called when a recorded tag resolution is used in
+	// offline mode.
+	OnOfflineTag func(record TagRecord)
+}
+
+// Cache graphTarget struct.
+type graphTarget struct {
+	*target
+	source oras.ReadOnlyGraphTarget
+	opts   GraphOptions
+}
+
+// NewGraph generates a new graph target with caching of fetched content, and
+// optionally tag resolutions and referrers listings.
+func NewGraph(source oras.ReadOnlyGraphTarget, cache content.Storage, opts GraphOptions) oras.ReadOnlyGraphTarget {
+	return &graphTarget{
+		target: &target{
+			ReadOnlyTarget: source,
+			cache:          cache,
+		},
+		source: source,
+		opts:   opts,
+	}
+}
+
+// Source returns the origin target of a cached target, or t itself if t is
+// not a cached target.
+func Source(t oras.ReadOnlyTarget) oras.ReadOnlyTarget {
+	switch t := t.(type) {
+	case *target:
+		return t.ReadOnlyTarget
+	case *referenceTarget:
+		return t.ReadOnlyTarget
+	case *graphTarget:
+		return t.source
+	}
+	return t
+}
+
+// Resolve resolves a reference to a descriptor, reusing a recorded resolution
+// of a tag within the TTL.
+func (t *graphTarget) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	if t.opts.Offline {
+		return t.resolveOffline(ctx, reference)
+	}
+	if desc, ok := t.loadTag(reference); ok {
+		return desc, nil
+	}
+	desc, err := t.source.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	t.saveTag(reference, desc)
+	return desc, nil
+}
+
+// FetchReference fetches the content identified by the reference, reusing a
+// recorded resolution of a tag within the TTL.
+func (t *graphTarget) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	if t.opts.Offline {
+		desc, err := t.resolveOffline(ctx, reference)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		rc, err := t.Fetch(ctx, desc)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		return desc, rc, nil
+	}
+	if desc, ok := t.loadTag(reference); ok {
+		rc, err := t.Fetch(ctx, desc)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		return desc, rc, nil
+	}
+
+	var desc ocispec.Descriptor
+	var rc io.ReadCloser
+	var err error
+	if refFetcher, ok := t.source.(registry.ReferenceFetcher); ok {
+		rt := &referenceTarget{
+			target:           t.target,
+			ReferenceFetcher: refFetcher,
+		}
+		desc, rc, err = rt.FetchReference(ctx, reference)
+	} else {
+		if desc, err = t.source.Resolve(ctx, reference); err == nil {
+			rc, err = t.Fetch(ctx, desc)
+		}
+	}
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	t.saveTag(reference, desc)
+	return desc, rc, nil
+}
+
+// Predecessors returns the nodes directly pointing to the current node.
+func (t *graphTarget) Predecessors(ctx context.Context, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	if t.opts.Offline {
+		return t.listReferrers(ctx, node)
+	}
+	if _, ok := t.source.(registry.ReferrerLister); !ok || t.opts.ReferrersTTL <= 0 {
+		return t.source.Predecessors(ctx, node)
+	}
+	// the predecessors of a node in a remote repository are its referrers
+	return t.listReferrers(ctx, node)
+}
+
+// Referrers lists the referrers of desc with the given artifactType, reusing
+// a recorded listing within the TTL.
+func (t *graphTarget) Referrers(ctx context.Context, desc ocispec.Descriptor, artifactType string, fn func(referrers []ocispec.Descriptor) error) error {
+	if !t.opts.Offline && (t.opts.Metadata == nil || t.opts.ReferrersTTL <= 0) {
+		if lister, ok := t.source.(registry.ReferrerLister); ok {
+			return lister.Referrers(ctx, desc, artifactType, fn)
+		}
+		referrers, err := registry.Referrers(ctx, t.source, desc, artifactType)
+		if err != nil {
+			return err
+		}
+		return fn(referrers)
+	}
+
+	// record the unfiltered listing so that it serves any artifact type
+	referrers, err := t.listReferrers(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if artifactType != "" {
+		var filtered []ocispec.Descriptor
+		for _, r := range referrers {
+			if r.ArtifactType == artifactType {
+				filtered = append(filtered, r)
+			}
+		}
+		referrers = filtered
+	}
+	if len(referrers) == 0 {
+		return nil
+	}
+	return fn(referrers)
+}
+
+// listReferrers lists all referrers of desc, reusing a recorded listing
+// within the TTL.
+func (t *graphTarget) listReferrers(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	if t.opts.Metadata != nil {
+		record, ok, err := t.opts.Metadata.LoadReferrers(desc.Digest)
+		if err == nil && ok && (t.opts.Offline || time.Since(record.ListedAt) <= t.opts.ReferrersTTL) {
+			return record.Referrers, nil
+		}
+	}
+	if t.opts.Offline {
+		return nil, fmt.Errorf("referrers of %s are not recorded in the local cache: %w", desc.Digest, onet.ErrOffline)
+	}
+	referrers, err := registry.Referrers(ctx, t.source, desc, "")
+	if err != nil {
+		return nil, err
+	}
+	if t.opts.Metadata != nil {
+		// failing to record the listing only costs a future request
+		_ = t.opts.Metadata.SaveReferrers(desc.Digest, referrers)
+	}
+	return referrers, nil
+}
+
+// loadTag returns the recorded resolution of reference if it is a tag
+// resolved within the TTL.
+func (t *graphTarget) loadTag(reference string) (ocispec.Descriptor, bool) {
+	if !t.cachesTag(reference) {
+		return ocispec.Descriptor{}, false
+	}
+	record, ok, err := t.opts.Metadata.LoadTag(reference)
+	if err != nil || !ok || time.Since(record.ResolvedAt) > t.opts.ResolveTTL {
+		return ocispec.Descriptor{}, false
+	}
+	return record.Descriptor, true
+}
+
+// saveTag records the resolution of reference if it is a tag, so that it can
+// be reused later within the TTL or in offline mode.
+func (t *graphTarget) saveTag(reference string, desc ocispec.Descriptor) {
+	if t.opts.Metadata != nil && isTag(reference) {
+		// failing to record the resolution only costs a future request
+		_ = t.opts.Metadata.SaveTag(reference, desc)
+	}
+}
+
+// cachesTag tells if a recorded resolution of reference can be reused.
+func (t *graphTarget) cachesTag(reference string) bool {
+	return t.opts.Metadata != nil && t.opts.ResolveTTL > 0 && isTag(reference)
+}
+
+// isTag tells if reference is a tag. Digest references are immutable and
+// resolved without recording.
+func isTag(reference string) bool {
+	return reference != "" && !contentutil.IsDigest(reference)
+}
+
+// Tags lists the tags in the origin repository. Tag listings are never cached.
+func (t *graphTarget) Tags(ctx context.Context, last string, fn func(tags []string) error) error {
+	if t.opts.Offline {
+		return fmt.Errorf("tags are not recorded in the local cache: %w", onet.ErrOffline)
+	}
+	lister, ok := t.source.(registry.TagLister)
+	if !ok {
+		return errdef.ErrUnsupported
+	}
+	return lister.Tags(ctx, last, fn)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry"
+)
+
+// countingSource is a graph target counting resolutions and referrers
+// listings.
+type countingSource struct {
+	*memory.Store
+	resolved int
+	listed   int
+}
+
+func (s *countingSource) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	s.resolved++
+	return s.Store.Resolve(ctx, reference)
+}
+
+func (s *countingSource) Referrers(ctx context.Context, desc ocispec.Descriptor, artifactType string, fn func(referrers []ocispec.Descriptor) error) error {
+	s.listed++
+	referrers, err := registry.Referrers(ctx, s.Store, desc, artifactType)
+	if err != nil {
+		return err
+	}
+	return fn(referrers)
+}
+
+func pushJSON(t *testing.T, store oras.Target, mediaType string, v any) ocispec.Descriptor {
+	t.Helper()
+	blob, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := content.NewDescriptorFromBytes(mediaType, blob)
+	if err := store.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	return desc
+}
+
+func newCountingSource(t *testing.T) (*countingSource, ocispec.Descriptor, []ocispec.Descriptor) {
+	t.Helper()
+	src := &countingSource{Store: memory.New()}
+	config := pushJSON(t, src, ocispec.MediaTypeEmptyJSON, map[string]string{})
+	subject := pushJSON(t, src, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{},
+	})
+	if err := src.Tag(context.Background(), subject, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	var referrers []ocispec.Descriptor
+	for _, artifactType := range []string{"application/vnd.test.sbom", "application/vnd.test.signature"} {
+		desc := pushJSON(t, src, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       config,
+			Layers:       []ocispec.Descriptor{},
+			Subject:      &subject,
+		})
+		desc.ArtifactType = artifactType
+		referrers = append(referrers, desc)
+	}
+	return src, subject, referrers
+}
+
+func TestGraph_Resolve(t *testing.T) {
+	ctx := context.Background()
+	src, subject, _ := newCountingSource(t)
+	metadata := NewMetadata(t.TempDir(), "localhost:5000/test")
+	target := NewGraph(src, memory.New(), GraphOptions{
+		Metadata:   metadata,
+		ResolveTTL: time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		got, err := target.Resolve(ctx, "v1")
+		if err != nil {
+			t.Fatal("graphTarget.Resolve() error =", err)
+		}
+		if !content.Equal(got, subject) {
+			t.Errorf("graphTarget.Resolve() = %v, want %v", got, subject)
+		}
+	}
+	if src.resolved != 1 {
+		t.Errorf("resolved %d times from source, want 1", src.resolved)
+	}
+
+	// digests are never recorded
+	if target.(*graphTarget).cachesTag(subject.Digest.String()) {
+		t.Error("digest resolution is recorded")
+	}
+
+	// expired resolutions are resolved again
+	expired := NewGraph(src, memory.New(), GraphOptions{
+		Metadata:   metadata,
+		ResolveTTL: time.Nanosecond,
+	})
+	time.Sleep(time.Millisecond)
+	if _, err := expired.Resolve(ctx, "v1"); err != nil {
+		t.Fatal("graphTarget.Resolve() error =", err)
+	}
+	if src.resolved != 2 {
+		t.Errorf("resolved %d times from source, want 2", src.resolved)
+	}
+}
+
+func TestGraph_Resolve_disabled(t *testing.T) {
+	ctx := context.Background()
+	src, _, _ := newCountingSource(t)
+	target := NewGraph(src, memory.New(), GraphOptions{
+		Metadata: NewMetadata(t.TempDir(), "localhost:5000/test"),
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := target.Resolve(ctx, "v1"); err != nil {
+			t.Fatal("graphTarget.Resolve() error =", err)
+		}
+	}
+	if src.resolved != 2 {
+		t.Errorf("resolved %d times from source, want 2", src.resolved)
+	}
+}
+
+func TestGraph_FetchReference(t *testing.T) {
+	ctx := context.Background()
+	src, subject, _ := newCountingSource(t)
+	target := NewGraph(src, memory.New(), GraphOptions{
+		Metadata:   NewMetadata(t.TempDir(), "localhost:5000/test"),
+		ResolveTTL: time.Hour,
+	})
+	want, err := content.FetchAll(ctx, src, subject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		desc, rc, err := target.(registry.ReferenceFetcher).FetchReference(ctx, "v1")
+		if err != nil {
+			t.Fatal("graphTarget.FetchReference() error =", err)
+		}
+		got, err := content.ReadAll(rc, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("graphTarget.FetchReference() = %s, want %s", got, want)
+		}
+	}
+	if src.resolved != 1 {
+		t.Errorf("resolved %d times from source, want 1", src.resolved)
+	}
+}
+
+func TestGraph_Referrers(t *testing.T) {
+	ctx := context.Background()
+	src, subject, referrers := newCountingSource(t)
+	metadata := NewMetadata(t.TempDir(), "localhost:5000/test")
+	target := NewGraph(src, memory.New(), GraphOptions{
+		Metadata:     metadata,
+		ReferrersTTL: time.Hour,
+	})
+
+	got, err := registry.Referrers(ctx, target, subject, "")
+	if err != nil {
+		t.Fatal("registry.Referrers() error =", err)
+	}
+	if len(got) != len(referrers) {
+		t.Fatalf("registry.Referrers() = %v, want %v", got, referrers)
+	}
+	// a filtered listing is served from the recorded unfiltered listing
+	got, err = registry.Referrers(ctx, target, subject, referrers[1].ArtifactType)
+	if err != nil {
+		t.Fatal("registry.Referrers() error =", err)
+	}
+	if want := referrers[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("registry.Referrers() = %v, want %v", got, want)
+	}
+	if _, err := target.Predecessors(ctx, subject); err != nil {
+		t.Fatal("graphTarget.Predecessors() error =", err)
+	}
+	if src.listed != 1 {
+		t.Errorf("listed %d times from source, want 1", src.listed)
+	}
+
+	// invalidated listings are listed again
+	if err := metadata.DeleteReferrers(subject.Digest); err != nil {
+		t.Fatal("Metadata.DeleteReferrers() error =", err)
+	}
+	if _, err := registry.Referrers(ctx, target, subject, ""); err != nil {
+		t.Fatal("registry.Referrers() error =", err)
+	}
+	if src.listed != 2 {
+		t.Errorf("listed %d times from source, want 2", src.listed)
+	}
+}
+
+func TestSource(t *testing.T) {
+	src := memory.New()
+	if got := Source(NewGraph(src, memory.New(), GraphOptions{})); got != src {
+		t.Errorf("Source() = %v, want %v", got, src)
+	}
+	if got := Source(New(src, memory.New())); got != src {
+		t.Errorf("Source() = %v, want %v", got, src)
+	}
+	if got := Source(src); got != src {
+		t.Errorf("Source() = %v, want %v", got, src)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ingestDir is the directory of the OCI store holding partially written
+// blobs.
+const ingestDir = "ingest"
+
+// Entry is a blob stored in the cache.
+type Entry struct {
+	Digest digest.Digest
+	Size   int64
+	// LastAccessed is the last time the blob was written to or read from the
+	// cache.
+	LastAccessed time.Time
+	path         string
+}
+
+// Usage summarizes the content of the cache.
+type Usage struct {
+	Size      int64
+	BlobCount int
+}
+
+// List lists the blobs in the cache rooted at root, least recently used
+// first.
+func List(root string) ([]Entry, error) {
+	blobsDir := filepath.Join(root, ocispec.ImageBlobsDir)
+	var entries []Entry
+	algDirs, err := os.ReadDir(blobsDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, algDir := range algDirs {
+		if !algDir.IsDir() {
+			continue
+		}
+		alg := digest.Algorithm(algDir.Name())
+		blobs, err := os.ReadDir(filepath.Join(blobsDir, algDir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range blobs {
+			if blob.IsDir() {
+				continue
+			}
+			dgst := digest.NewDigestFromEncoded(alg, blob.Name())
+			if dgst.Validate() != nil {
+				continue
+			}
+			info, err := blob.Info()
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					// removed concurrently
+					continue
+				}
+				return nil, err
+			}
+			entries = append(entries, Entry{
+				Digest:       dgst,
+				Size:         info.Size(),
+				LastAccessed: info.ModTime(),
+				path:         filepath.Join(blobsDir, algDir.Name(), blob.Name()),
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].LastAccessed.Equal(entries[j].LastAccessed) {
+			return entries[i].Digest < entries[j].Digest
+		}
+		return entries[i].LastAccessed.Before(entries[j].LastAccessed)
+	})
+	return entries, nil
+}
+
+// GetUsage returns the total size and the number of blobs in the cache rooted
+// at root.
+func GetUsage(root string) (Usage, error) {
+	entries, err := List(root)
+	if err != nil {
+		return Usage{}, err
+	}
+	usage := Usage{BlobCount: len(entries)}
+	for _, e := range entries {
+		usage.Size += e.Size
+	}
+	return usage, nil
+}
+
+// PruneOptions contains parameters for Prune.
+type PruneOptions struct {
+	// MaxSize evicts least recently used blobs until the cache size is no
+	// more than MaxSize. Zero or negative means no size limit.
+	MaxSize int64
+	// OlderThan evicts blobs not accessed within the duration. Zero or
+	// negative means no age limit.
+	OlderThan time.Duration
+	// Now is the reference time of OlderThan. The current time is used if
+	// not set.
+	Now time.Time
+	// DryRun reports the blobs to be evicted without removing them.
+	DryRun bool
+}
+
+// Prune evicts blobs from the cache rooted at root in least recently used
+// order and returns the evicted blobs.
+func Prune(root string, opts PruneOptions) ([]Entry, error) {
+	entries, err := List(root)
+	if err != nil {
+		return nil, err
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	var evicted []Entry
+	for _, e := range entries {
+		expired := opts.OlderThan > 0 && now.Sub(e.LastAccessed) > opts.OlderThan
+		oversized := opts.MaxSize > 0 && total > opts.MaxSize
+		if !expired && !oversized {
+			// entries are sorted by access time so the remaining entries are
+			// neither expired nor needed to be evicted for space
+			break
+		}
+		if !opts.DryRun {
+			if err := os.Remove(e.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return evicted, err
+			}
+		}
+		total -= e.Size
+		evicted = append(evicted, e)
+	}
+	return evicted, nil
+}
+
+// Clear removes all blobs from the cache rooted at root, including partially
+// written ones, as well as recorded metadata. The cache stays usable as an
+// OCI layout.
+func Clear(root string) error {
+	for _, dir := range []string{ocispec.ImageBlobsDir, ingestDir, metadataDir} {
+		if err := os.RemoveAll(filepath.Join(root, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markAccessed updates the modification time of a blob read from an OCI
+// store, which is used as the last access time for eviction.
+// Access times are not tracked if rc is not backed by a file.
+func markAccessed(rc io.ReadCloser) {
+	if f, ok := rc.(interface{ Name() string }); ok {
+		now := time.Now()
+		_ = os.Chtimes(f.Name(), now, now)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// newTestCache creates a cache with blobs of the given contents, accessed one
+// hour apart in order.
+func newTestCache(t *testing.T, now time.Time, blobs ...string) (string, []ocispec.Descriptor) {
+	t.Helper()
+	root := t.TempDir()
+	store, err := oci.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var descs []ocispec.Descriptor
+	for i, blob := range blobs {
+		desc := content.NewDescriptorFromBytes("test", []byte(blob))
+		if err := store.Push(context.Background(), desc, bytes.NewReader([]byte(blob))); err != nil {
+			t.Fatal(err)
+		}
+		accessed := now.Add(time.Duration(i-len(blobs)) * time.Hour)
+		path := filepath.Join(root, ocispec.ImageBlobsDir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+		if err := os.Chtimes(path, accessed, accessed); err != nil {
+			t.Fatal(err)
+		}
+		descs = append(descs, desc)
+	}
+	return root, descs
+}
+
+func digests(entries []Entry) []digest.Digest {
+	var got []digest.Digest
+	for _, e := range entries {
+		got = append(got, e.Digest)
+	}
+	return got
+}
+
+func TestList(t *testing.T) {
+	now := time.Now()
+	root, descs := newTestCache(t, now, "foo", "hello", "world!")
+	entries, err := List(root)
+	if err != nil {
+		t.Fatal("List() error =", err)
+	}
+	if len(entries) != len(descs) {
+		t.Fatalf("List() got %d entries, want %d", len(entries), len(descs))
+	}
+	for i, e := range entries {
+		if e.Digest != descs[i].Digest || e.Size != descs[i].Size {
+			t.Errorf("List()[%d] = %v (%d), want %v (%d)", i, e.Digest, e.Size, descs[i].Digest, descs[i].Size)
+		}
+	}
+
+	usage, err := GetUsage(root)
+	if err != nil {
+		t.Fatal("GetUsage() error =", err)
+	}
+	if want := (Usage{Size: 14, BlobCount: 3}); usage != want {
+		t.Errorf("GetUsage() = %v, want %v", usage, want)
+	}
+}
+
+func TestList_notExist(t *testing.T) {
+	entries, err := List(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatal("List() error =", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("List() = %v, want empty", entries)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		opts    PruneOptions
+		evicted []int
+	}{
+		{
+			name: "no limit",
+		},
+		{
+			name:    "max size",
+			opts:    PruneOptions{MaxSize: 11},
+			evicted: []int{0},
+		},
+		{
+			name:    "max size evicts least recently used first",
+			opts:    PruneOptions{MaxSize: 6},
+			evicted: []int{0, 1},
+		},
+		{
+			name:    "older than",
+			opts:    PruneOptions{OlderThan: 90 * time.Minute},
+			evicted: []int{0, 1},
+		},
+		{
+			name:    "older than and max size",
+			opts:    PruneOptions{OlderThan: 150 * time.Minute, MaxSize: 6},
+			evicted: []int{0, 1},
+		},
+		{
+			name:    "dry run",
+			opts:    PruneOptions{MaxSize: 1, DryRun: true},
+			evicted: []int{0, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, descs := newTestCache(t, now, "foo", "hello", "world!")
+			tt.opts.Now = now
+			evicted, err := Prune(root, tt.opts)
+			if err != nil {
+				t.Fatal("Prune() error =", err)
+			}
+			var want []digest.Digest
+			for _, i := range tt.evicted {
+				want = append(want, descs[i].Digest)
+			}
+			if got := digests(evicted); len(got) != len(want) {
+				t.Fatalf("Prune() evicted %v, want %v", got, want)
+			} else {
+				for i := range got {
+					if got[i] != want[i] {
+						t.Errorf("Prune() evicted %v, want %v", got, want)
+					}
+				}
+			}
+
+			remaining, err := List(root)
+			if err != nil {
+				t.Fatal("List() error =", err)
+			}
+			wantRemaining := len(descs) - len(want)
+			if tt.opts.DryRun {
+				wantRemaining = len(descs)
+			}
+			if len(remaining) != wantRemaining {
+				t.Errorf("remaining %d blobs, want %d", len(remaining), wantRemaining)
+			}
+		})
+	}
+}
+
+func TestClear(t *testing.T) {
+	root, descs := newTestCache(t, time.Now(), "foo", "hello")
+	if err := Clear(root); err != nil {
+		t.Fatal("Clear() error =", err)
+	}
+	usage, err := GetUsage(root)
+	if err != nil {
+		t.Fatal("GetUsage() error =", err)
+	}
+	if usage.BlobCount != 0 {
+		t.Errorf("GetUsage() = %v, want empty", usage)
+	}
+
+	// the cache is still usable after being cleared
+	store, err := oci.New(root)
+	if err != nil {
+		t.Fatal("oci.New() error =", err)
+	}
+	if err := store.Push(context.Background(), descs[0], bytes.NewReader([]byte("foo"))); err != nil {
+		t.Fatal("Store.Push() error =", err)
+	}
+}
+
+func TestProxy_fetchCache_markAccessed(t *testing.T) {
+	now := time.Now()
+	root, descs := newTestCache(t, now, "foo", "hello")
+	store, err := oci.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := New(memory.New(), store)
+	if _, err := content.FetchAll(context.Background(), p, descs[0]); err != nil {
+		t.Fatal("Proxy.Fetch() error =", err)
+	}
+	entries, err := List(root)
+	if err != nil {
+		t.Fatal("List() error =", err)
+	}
+	// the fetched blob becomes the most recently used one
+	if got := entries[len(entries)-1].Digest; got != descs[0].Digest {
+		t.Errorf("most recently used = %v, want %v", got, descs[0].Digest)
+	}
+}
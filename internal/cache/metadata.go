@@ -0,0 +1,155 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// metadataDir is the directory in the cache root holding tag resolutions and
+// referrers listings.
+const metadataDir = "metadata"
+
+// TagRecord is a recorded resolution of a tag.
+type TagRecord struct {
+	Repository string             `json:"repository"`
+	Reference  string             `json:"reference"`
+	Descriptor ocispec.Descriptor `json:"descriptor"`
+	ResolvedAt time.Time          `json:"resolvedAt"`
+}
+
+// ReferrersRecord is a recorded listing of the referrers of a subject.
+type ReferrersRecord struct {
+	Repository string               `json:"repository"`
+	Subject    digest.Digest        `json:"subject"`
+	Referrers  []ocispec.Descriptor `json:"referrers"`
+	ListedAt   time.Time            `json:"listedAt"`
+}
+
+// Metadata stores tag resolutions and referrers listings of a repository in
+// the cache.
+type Metadata struct {
+	repository string
+	dir        string
+}
+
+// NewMetadata returns the metadata store of the repository, such as
+// "localhost:5000/hello", in the cache rooted at root.
+func NewMetadata(root, repository string) *Metadata {
+	return &Metadata{
+		repository: repository,
+		dir:        filepath.Join(root, metadataDir, digest.FromString(repository).Encoded()),
+	}
+}
+
+// LoadTag returns the recorded resolution of reference. ok is false if there
+// is no record.
+func (m *Metadata) LoadTag(reference string) (record TagRecord, ok bool, err error) {
+	ok, err = m.load(m.tagPath(reference), &record)
+	return record, ok, err
+}
+
+// SaveTag records the resolution of reference.
+func (m *Metadata) SaveTag(reference string, desc ocispec.Descriptor) error {
+	return m.save(m.tagPath(reference), TagRecord{
+		Repository: m.repository,
+		Reference:  reference,
+		Descriptor: desc,
+		ResolvedAt: time.Now().UTC(),
+	})
+}
+
+// LoadReferrers returns the recorded referrers of subject. ok is false if
+// there is no record.
+func (m *Metadata) LoadReferrers(subject digest.Digest) (record ReferrersRecord, ok bool, err error) {
+	ok, err = m.load(m.referrersPath(subject), &record)
+	return record, ok, err
+}
+
+// SaveReferrers records the referrers of subject.
+func (m *Metadata) SaveReferrers(subject digest.Digest, referrers []ocispec.Descriptor) error {
+	return m.save(m.referrersPath(subject), ReferrersRecord{
+		Repository: m.repository,
+		Subject:    subject,
+		Referrers:  referrers,
+		ListedAt:   time.Now().UTC(),
+	})
+}
+
+// DeleteReferrers removes the recorded referrers of subject, if any.
+func (m *Metadata) DeleteReferrers(subject digest.Digest) error {
+	if err := os.Remove(m.referrersPath(subject)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (m *Metadata) tagPath(reference string) string {
+	return filepath.Join(m.dir, "tags", digest.FromString(reference).Encoded()+".json")
+}
+
+func (m *Metadata) referrersPath(subject digest.Digest) string {
+	return filepath.Join(m.dir, "referrers", digest.FromString(subject.String()).Encoded()+".json")
+}
+
+func (m *Metadata) load(path string, v any) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		// treat a corrupted record as missing so that it gets overwritten
+		return false, nil
+	}
+	return true, nil
+}
+
+// save writes the record to a temporary file and renames it to path so that
+// concurrent readers never see a partially written record.
+func (m *Metadata) save(path string, v any) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	fp, err := os.CreateTemp(dir, ".record-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fp.Name())
+	if _, err := fp.Write(content); err != nil {
+		_ = fp.Close()
+		return err
+	}
+	if err := fp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fp.Name(), path)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+	onet "oras.land/oras/internal/net"
+)
+
+// maxManifestSize is the maximum size of a manifest read from the cache to
+// determine its media type.
+const maxManifestSize = 4 * 1024 * 1024
+
+// Fetch fetches the content identified by the descriptor, only from the cache
+// in offline mode.
+func (t *graphTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if !t.opts.Offline {
+		return t.target.Fetch(ctx, target)
+	}
+	rc, err := t.cache.Fetch(ctx, target)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil, fmt.Errorf("%s: not found in the local cache: %w", target.Digest, onet.ErrOffline)
+		}
+		return nil, err
+	}
+	markAccessed(rc)
+	return rc, nil
+}
+
+// Exists returns true if the described content exists, only in the cache in
+// offline mode.
+func (t *graphTarget) Exists(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+	if !t.opts.Offline {
+		return t.target.Exists(ctx, desc)
+	}
+	return t.cache.Exists(ctx, desc)
+}
+
+// resolveOffline resolves a tag with its recorded resolution, or a digest
+// with the manifest in the cache.
+func (t *graphTarget) resolveOffline(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	if isTag(reference) {
+		if t.opts.Metadata != nil {
+			record, ok, err := t.opts.Metadata.LoadTag(reference)
+			if err != nil {
+				return ocispec.Descriptor{}, err
+			}
+			if ok {
+				if t.opts.OnOfflineTag != nil {
+					t.opts.OnOfflineTag(record)
+				}
+				return record.Descriptor, nil
+			}
+		}
+		return ocispec.Descriptor{}, fmt.Errorf("%s: no resolution of the tag is recorded in the local cache: %w", reference, onet.ErrOffline)
+	}
+
+	dgst, err := digest.Parse(reference)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return t.resolveManifest(ctx, dgst)
+}
+
+// resolveManifest resolves a manifest in the cache by its digest. The media
+// type of the manifest is determined from its content as it is not stored in
+// the cache.
+func (t *graphTarget) resolveManifest(ctx context.Context, dgst digest.Digest) (ocispec.Descriptor, error) {
+	rc, err := t.Fetch(ctx, ocispec.Descriptor{Digest: dgst})
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer rc.Close()
+	manifestJSON, err := io.ReadAll(io.LimitReader(rc, maxManifestSize+1))
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if len(manifestJSON) > maxManifestSize {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: content is too large to be a manifest", dgst)
+	}
+	var manifest struct {
+		MediaType string           `json:"mediaType"`
+		Config    *json.RawMessage `json:"config"`
+		Manifests *json.RawMessage `json:"manifests"`
+	}
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: content is not a manifest: %w", dgst, err)
+	}
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		switch {
+		case manifest.Manifests != nil:
+			mediaType = ocispec.MediaTypeImageIndex
+		case manifest.Config != nil:
+			mediaType = ocispec.MediaTypeImageManifest
+		default:
+			return ocispec.Descriptor{}, fmt.Errorf("%s: unable to determine the media type of the manifest", dgst)
+		}
+	}
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      int64(len(manifestJSON)),
+	}, nil
+}
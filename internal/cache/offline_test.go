@@ -0,0 +1,190 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+	onet "oras.land/oras/internal/net"
+)
+
+// newOCIStore creates an OCI store as the cache, which fetches blobs by
+// digest only like the real one.
+func newOCIStore(t *testing.T) *oci.Store {
+	t.Helper()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestGraph_offline(t *testing.T) {
+	ctx := context.Background()
+	src, subject, referrers := newCountingSource(t)
+	store := newOCIStore(t)
+	metadata := NewMetadata(t.TempDir(), "localhost:5000/test")
+
+	// populate the cache while online
+	online := NewGraph(src, store, GraphOptions{
+		Metadata:     metadata,
+		ReferrersTTL: time.Nanosecond,
+	})
+	dst := memory.New()
+	if _, err := oras.Copy(ctx, online, "v1", dst, "v1", oras.DefaultCopyOptions); err != nil {
+		t.Fatal("oras.Copy() error =", err)
+	}
+	if _, err := registry.Referrers(ctx, online, subject, ""); err != nil {
+		t.Fatal("registry.Referrers() error =", err)
+	}
+	resolved, listed := src.resolved, src.listed
+
+	var notified []TagRecord
+	offline := NewGraph(src, store, GraphOptions{
+		Metadata: metadata,
+		Offline:  true,
+		OnOfflineTag: func(record TagRecord) {
+			notified = append(notified, record)
+		},
+	})
+
+	// tags are resolved with recorded resolutions regardless of their age
+	desc, err := offline.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatal("graphTarget.Resolve() error =", err)
+	}
+	if !content.Equal(desc, subject) {
+		t.Errorf("graphTarget.Resolve() = %v, want %v", desc, subject)
+	}
+	if len(notified) != 1 || notified[0].Reference != "v1" {
+		t.Errorf("OnOfflineTag() called with %v, want the record of v1", notified)
+	}
+
+	// digests are resolved with the cached manifests
+	desc, err = offline.Resolve(ctx, subject.Digest.String())
+	if err != nil {
+		t.Fatal("graphTarget.Resolve() error =", err)
+	}
+	if !content.Equal(desc, subject) {
+		t.Errorf("graphTarget.Resolve() = %v, want %v", desc, subject)
+	}
+
+	// content is copied from the cache
+	if _, err := oras.Copy(ctx, offline, "v1", memory.New(), "v1", oras.DefaultCopyOptions); err != nil {
+		t.Fatal("oras.Copy() error =", err)
+	}
+
+	// referrers are listed with recorded listings regardless of their age
+	got, err := registry.Referrers(ctx, offline, subject, "")
+	if err != nil {
+		t.Fatal("registry.Referrers() error =", err)
+	}
+	if len(got) != len(referrers) {
+		t.Errorf("registry.Referrers() = %v, want %v", got, referrers)
+	}
+
+	if src.resolved != resolved || src.listed != listed {
+		t.Error("source is accessed in offline mode")
+	}
+}
+
+func TestGraph_offline_missing(t *testing.T) {
+	ctx := context.Background()
+	src, subject, _ := newCountingSource(t)
+	offline := NewGraph(src, memory.New(), GraphOptions{
+		Metadata: NewMetadata(t.TempDir(), "localhost:5000/test"),
+		Offline:  true,
+	})
+
+	if _, err := offline.Resolve(ctx, "v1"); !errors.Is(err, onet.ErrOffline) {
+		t.Errorf("graphTarget.Resolve() error = %v, want %v", err, onet.ErrOffline)
+	}
+	if _, err := offline.Fetch(ctx, subject); !errors.Is(err, onet.ErrOffline) {
+		t.Errorf("graphTarget.Fetch() error = %v, want %v", err, onet.ErrOffline)
+	}
+	if _, err := offline.Predecessors(ctx, subject); !errors.Is(err, onet.ErrOffline) {
+		t.Errorf("graphTarget.Predecessors() error = %v, want %v", err, onet.ErrOffline)
+	}
+	if exists, err := offline.Exists(ctx, subject); err != nil || exists {
+		t.Errorf("graphTarget.Exists() = %v, %v, want false", exists, err)
+	}
+	if src.resolved != 0 || src.listed != 0 {
+		t.Error("source is accessed in offline mode")
+	}
+}
+
+func TestGraph_resolveManifest(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "media type in manifest",
+			content: `{"mediaType":"application/vnd.docker.distribution.manifest.v2+json","config":{}}`,
+			want:    "application/vnd.docker.distribution.manifest.v2+json",
+		},
+		{
+			name:    "index without media type",
+			content: `{"schemaVersion":2,"manifests":[]}`,
+			want:    ocispec.MediaTypeImageIndex,
+		},
+		{
+			name:    "manifest without media type",
+			content: `{"schemaVersion":2,"config":{},"layers":[]}`,
+			want:    ocispec.MediaTypeImageManifest,
+		},
+		{
+			name:    "unknown",
+			content: `{"schemaVersion":2}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			content: `hello`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOCIStore(t)
+			desc := content.NewDescriptorFromBytes("", []byte(tt.content))
+			if err := store.Push(ctx, desc, bytes.NewReader([]byte(tt.content))); err != nil {
+				t.Fatal(err)
+			}
+			target := NewGraph(memory.New(), store, GraphOptions{Offline: true}).(*graphTarget)
+			got, err := target.resolveManifest(ctx, desc.Digest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("graphTarget.resolveManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.MediaType != tt.want || got.Size != desc.Size) {
+				t.Errorf("graphTarget.resolveManifest() = %v, want media type %s and size %d", got, tt.want, desc.Size)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"io"
 	"sync"
 
@@ -26,12 +27,6 @@ import (
 	"oras.land/oras-go/v2/registry"
 )
 
-type closer func() error
-
-func (fn closer) Close() error {
-	return fn()
-}
-
 // Cache target struct.
 type target struct {
 	oras.ReadOnlyTarget
@@ -58,6 +53,7 @@ func (t *target) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadC
 	rc, err := t.cache.Fetch(ctx, target)
 	if err == nil {
 		// Fetch from cache
+		markAccessed(rc)
 		return rc, nil
 	}
 
@@ -71,35 +67,76 @@ func (t *target) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadC
 
 func (t *target) cacheReadCloser(ctx context.Context, rc io.ReadCloser, target ocispec.Descriptor) io.ReadCloser {
 	pr, pw := io.Pipe()
-	var wg sync.WaitGroup
-
-	wg.Add(1)
-	var pushErr error
+	cr := &cacheReader{
+		rc: rc,
+		pw: pw,
+		r:  io.TeeReader(rc, pw),
+	}
+	cr.wg.Add(1)
 	go func() {
-		defer wg.Done()
-		pushErr = t.cache.Push(ctx, target, pr)
-		if pushErr != nil {
-			pr.CloseWithError(pushErr)
+		defer cr.wg.Done()
+		cr.pushErr = t.cache.Push(ctx, target, pr)
+		if cr.pushErr != nil {
+			pr.CloseWithError(cr.pushErr)
 		}
 	}()
+	return cr
+}
+
+// errSeeked is the error the cache push is aborted with if the content is
+// not read from the start.
+var errSeeked = errors.New("content is not read from the start")
+
+// cacheReader reads the content fetched from the origin while pushing it to
+// the cache.
+type cacheReader struct {
+	rc      io.ReadCloser
+	pw      *io.PipeWriter
+	r       io.Reader
+	n       int64
+	skipped bool
+	wg      sync.WaitGroup
+	pushErr error
+}
 
-	return struct {
-		io.Reader
-		io.Closer
-	}{
-		Reader: io.TeeReader(rc, pw),
-		Closer: closer(func() error {
-			rcErr := rc.Close()
-			if err := pw.Close(); err != nil {
-				return err
-			}
-			wg.Wait()
-			if pushErr != nil {
-				return pushErr
-			}
-			return rcErr
-		}),
+// Read implements io.Reader.
+func (cr *cacheReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// Seek seeks the content fetched from the origin if it is seekable, such as
+// for resuming a partial download with a range request. The content is not
+// cached unless it is read from the start.
+func (cr *cacheReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := cr.rc.(io.Seeker)
+	if !ok {
+		return 0, errors.New("seeking is not supported by the origin")
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	if (pos != 0 || cr.n != 0) && !cr.skipped {
+		cr.skipped = true
+		cr.pw.CloseWithError(errSeeked)
+		cr.r = cr.rc
+	}
+	return pos, nil
+}
+
+// Close closes the content, and waits for the cache push to complete.
+func (cr *cacheReader) Close() error {
+	rcErr := cr.rc.Close()
+	if err := cr.pw.Close(); err != nil {
+		return err
+	}
+	cr.wg.Wait()
+	if cr.pushErr != nil && !cr.skipped {
+		return cr.pushErr
 	}
+	return rcErr
 }
 
 // Exists returns true if the described content exists.
@@ -143,6 +180,7 @@ func (t *referenceTarget) FetchReference(ctx context.Context, reference string)
 		if err != nil {
 			return ocispec.Descriptor{}, nil, err
 		}
+		markAccessed(rc)
 
 		// no need to do tee'd push
 		return target, rc, nil
@@ -0,0 +1,306 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chunk uploads large blobs in chunks with the chunked upload flow of
+// the distribution spec.
+package chunk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+type contextKey int
+
+// optionsKey is the associated key type for Options in context.
+const optionsKey contextKey = iota
+
+// maxAttempts is the maximum number of attempts to upload a chunk, resuming
+// from the offset reported by the registry after each failure.
+const maxAttempts = 3
+
+// errChunkedUploadUnsupported is returned if the registry rejects the first
+// chunk of an upload as not found, not allowed or not implemented.
+var errChunkedUploadUnsupported = errors.New("chunked upload is not supported")
+
+// Options configures chunked uploads.
+type Options struct {
+	// ChunkSize is the size of the chunks to upload a blob in. Blobs not
+	// larger than ChunkSize are uploaded in a single request.
+	ChunkSize int64
+}
+
+// WithOptions returns a context carrying opts.
+func WithOptions(ctx context.Context, opts *Options) context.Context {
+	return context.WithValue(ctx, optionsKey, opts)
+}
+
+// OptionsFromContext returns the options carried by ctx, or nil.
+func OptionsFromContext(ctx context.Context) *Options {
+	opts, _ := ctx.Value(optionsKey).(*Options)
+	return opts
+}
+
+// Transport is an http.RoundTripper turning monolithic blob uploads into
+// chunked uploads with the options carried by the request context. A failed
+// chunk is resumed from the offset reported by the registry, and the upload
+// falls back to a monolithic upload if the registry does not support chunked
+// uploads. Other requests are passed through untouched.
+type Transport struct {
+	http.RoundTripper
+
+	// Client sends the requests of chunked uploads if set, such as an
+	// auth.Client whose transport is Transport, so that expired tokens are
+	// refreshed during long uploads. The requests are sent with the
+	// authorization of the upload request by the base transport otherwise.
+	Client remote.Client
+}
+
+// NewTransport creates and returns a new instance of Transport.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		RoundTripper: base,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	opts := OptionsFromContext(req.Context())
+	if opts == nil || opts.ChunkSize <= 0 ||
+		req.Method != http.MethodPut ||
+		req.Body == nil || req.Body == http.NoBody ||
+		req.ContentLength <= opts.ChunkSize ||
+		!strings.Contains(req.URL.Path, "/blobs/uploads/") ||
+		!req.URL.Query().Has("digest") {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	location := *req.URL
+	query := location.Query()
+	query.Del("digest")
+	location.RawQuery = query.Encode()
+	u := &upload{
+		base:     t.RoundTripper,
+		client:   t.Client,
+		req:      req,
+		location: &location,
+	}
+	if u.client == nil {
+		u.client = &http.Client{Transport: t.RoundTripper}
+		u.keepAuthorization = true
+	}
+	resp, err := u.run(opts.ChunkSize)
+	if !u.handedOver {
+		_ = req.Body.Close()
+	}
+	return resp, err
+}
+
+// upload is a chunked upload of the body of a monolithic upload request.
+type upload struct {
+	base     http.RoundTripper
+	client   remote.Client
+	req      *http.Request
+	location *url.URL
+	// keepAuthorization is set if the requests of the upload are sent with
+	// the authorization of the upload request.
+	keepAuthorization bool
+	// handedOver is set if the body is handed over to a fallback request.
+	handedOver bool
+}
+
+// run uploads the body in chunks of chunkSize and completes the upload.
+func (u *upload) run(chunkSize int64) (*http.Response, error) {
+	size := u.req.ContentLength
+	buf := make([]byte, chunkSize)
+	for offset := int64(0); offset < size; {
+		chunk := buf[:min(chunkSize, size-offset)]
+		if _, err := io.ReadFull(u.req.Body, chunk); err != nil {
+			return nil, err
+		}
+		if err := u.sendChunk(chunk, offset); err != nil {
+			if errors.Is(err, errChunkedUploadUnsupported) {
+				return u.fallback(chunk)
+			}
+			return nil, err
+		}
+		offset += int64(len(chunk))
+	}
+	return u.complete()
+}
+
+// sendChunk uploads chunk starting at offset start. If the upload of chunk
+// fails with a network or server error, it is resumed from the offset
+// reported by the registry.
+func (u *upload) sendChunk(chunk []byte, start int64) error {
+	var sent int64
+	for attempt := 1; ; attempt++ {
+		resp, err := u.patch(chunk[sent:], start+sent)
+		if err == nil {
+			switch code := resp.StatusCode; {
+			case code == http.StatusAccepted:
+				u.updateLocation(resp)
+				return nil
+			case start == 0 && sent == 0 && isUnsupported(code):
+				return fmt.Errorf("%w: %s %q: unexpected status code %d", errChunkedUploadUnsupported, http.MethodPatch, u.location, code)
+			case code < http.StatusInternalServerError:
+				return fmt.Errorf("%s %q: unexpected status code %d", http.MethodPatch, u.location, code)
+			default:
+				err = fmt.Errorf("%s %q: unexpected status code %d", http.MethodPatch, u.location, code)
+			}
+		}
+		if attempt == maxAttempts {
+			return err
+		}
+		offset, statusErr := u.status()
+		if statusErr != nil {
+			return errors.Join(err, statusErr)
+		}
+		if offset < start || offset > start+int64(len(chunk)) {
+			return fmt.Errorf("failed to resume upload from offset %d reported by the registry: %w", offset, err)
+		}
+		if sent = offset - start; sent == int64(len(chunk)) {
+			return nil
+		}
+	}
+}
+
+// isUnsupported returns true if the status code of the response to the first
+// chunk shows that chunked uploads are not supported.
+func isUnsupported(code int) bool {
+	switch code {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// patch uploads chunk starting at offset start.
+func (u *upload) patch(chunk []byte, start int64) (*http.Response, error) {
+	req := u.newRequest(http.MethodPatch, u.location)
+	req.Body = io.NopCloser(bytes.NewReader(chunk))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(chunk)), nil
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", start, start+int64(len(chunk))-1))
+	return u.do(req)
+}
+
+// status returns the offset of the upload reported by the registry.
+func (u *upload) status() (int64, error) {
+	resp, err := u.do(u.newRequest(http.MethodGet, u.location))
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return 0, fmt.Errorf("%s %q: unexpected status code %d", http.MethodGet, u.location, resp.StatusCode)
+	}
+	u.updateLocation(resp)
+	return parseRange(resp.Header.Get("Range"))
+}
+
+// complete completes the upload with the digest of the content.
+func (u *upload) complete() (*http.Response, error) {
+	location := *u.location
+	query := location.Query()
+	query.Set("digest", u.req.URL.Query().Get("digest"))
+	location.RawQuery = query.Encode()
+	req := u.newRequest(http.MethodPut, &location)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	return u.client.Do(req)
+}
+
+// fallback uploads chunk followed by the rest of the body in a single request.
+func (u *upload) fallback(chunk []byte) (*http.Response, error) {
+	req := u.req.Clone(u.req.Context())
+	req.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(chunk), u.req.Body),
+		Closer: u.req.Body,
+	}
+	req.GetBody = nil
+	u.handedOver = true
+	return u.base.RoundTrip(req)
+}
+
+// newRequest returns a request to target with the headers of the upload
+// request. The authorization is left to the client unless it is kept.
+func (u *upload) newRequest(method string, target *url.URL) *http.Request {
+	req := u.req.Clone(u.req.Context())
+	req.Method = method
+	req.URL = target
+	req.Host = target.Host
+	if !u.keepAuthorization {
+		req.Header.Del("Authorization")
+	}
+	req.Body = http.NoBody
+	req.GetBody = nil
+	req.ContentLength = 0
+	req.Header.Del("Content-Type")
+	return req
+}
+
+// do sends req and closes the response body.
+func (u *upload) do(req *http.Request) (*http.Response, error) {
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp, resp.Body.Close()
+}
+
+// updateLocation updates the location of the upload from resp, if any.
+func (u *upload) updateLocation(resp *http.Response) {
+	if location, err := resp.Location(); err == nil {
+		u.location = location
+	}
+}
+
+// parseRange parses the offset of an upload from a range header in the form
+// of "0-<last>", where the "bytes=" prefix is optional. As registries report
+// "0-0" for empty uploads, it is parsed as no content uploaded.
+func parseRange(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimPrefix(value, "bytes="), "-")
+	if !ok || first != "0" {
+		return 0, fmt.Errorf("invalid range %q", value)
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < -1 {
+		return 0, fmt.Errorf("invalid range %q", value)
+	}
+	if end <= 0 {
+		return 0, nil
+	}
+	return end + 1, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
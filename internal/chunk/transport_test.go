@@ -0,0 +1,320 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chunk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// uploadServer is a registry serving a single blob upload session.
+type uploadServer struct {
+	// patchStatus is the status code responded to PATCH requests, if set.
+	patchStatus int
+	// failPatch is the number of the PATCH request accepting only half of its
+	// chunk before failing, if positive.
+	failPatch int
+	// tokenUses is the number of requests a bearer token issued by the
+	// server is valid for, if positive.
+	tokenUses int
+
+	lock     sync.Mutex
+	uploaded []byte
+	stored   []byte
+	requests map[string]int
+	tokens   map[string]int // remaining uses by token
+	issued   int
+}
+
+func (s *uploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if r.URL.Path == "/token" {
+		s.issued++
+		token := fmt.Sprintf("token-%d", s.issued)
+		s.tokens[token] = s.tokenUses
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"token":%q}`, token)
+		return
+	}
+	if s.tokenUses > 0 {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if s.tokens[token] <= 0 {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test",scope="repository:test:pull,push"`, r.Host))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.tokens[token]--
+	}
+	s.requests[r.Method]++
+	if !strings.HasPrefix(r.URL.Path, "/v2/test/blobs/uploads/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	reportProgress := func() {
+		w.Header().Set("Location", fmt.Sprintf("/v2/test/blobs/uploads/session?_state=%d", len(s.uploaded)))
+		w.Header().Set("Range", fmt.Sprintf("0-%d", max(len(s.uploaded)-1, 0)))
+	}
+	switch r.Method {
+	case http.MethodPatch:
+		if s.patchStatus != 0 {
+			w.WriteHeader(s.patchStatus)
+			return
+		}
+		start, _, _ := strings.Cut(r.Header.Get("Content-Range"), "-")
+		if start != strconv.Itoa(len(s.uploaded)) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		chunk, _ := io.ReadAll(r.Body)
+		if s.requests[r.Method] == s.failPatch {
+			s.uploaded = append(s.uploaded, chunk[:len(chunk)/2]...)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.uploaded = append(s.uploaded, chunk...)
+		reportProgress()
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodGet:
+		reportProgress()
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		content := append(s.uploaded, body...)
+		if digest.FromBytes(content).String() != r.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.stored = content
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newUploadRequest(t *testing.T, ctx context.Context, url string, blob []byte) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url+"/v2/test/blobs/uploads/session?digest="+digest.FromBytes(blob).String(), bytes.NewReader(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func putBlob(t *testing.T, ctx context.Context, url string, blob []byte) *http.Response {
+	t.Helper()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	resp, err := client.Do(newUploadRequest(t, ctx, url, blob))
+	if err != nil {
+		t.Fatalf("upload error = %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789"), 1000)
+	tests := []struct {
+		name        string
+		opts        *Options
+		patchStatus int
+		failPatch   int
+		wantPatches int
+		wantGets    int
+	}{
+		{
+			name:        "chunked",
+			opts:        &Options{ChunkSize: 3000},
+			wantPatches: 4,
+		},
+		{
+			name:        "chunk size of the blob",
+			opts:        &Options{ChunkSize: int64(len(blob))},
+			wantPatches: 0,
+		},
+		{
+			name: "no options",
+		},
+		{
+			name:        "chunked upload not found",
+			opts:        &Options{ChunkSize: 3000},
+			patchStatus: http.StatusNotFound,
+			wantPatches: 1,
+		},
+		{
+			name:        "chunked upload not allowed",
+			opts:        &Options{ChunkSize: 3000},
+			patchStatus: http.StatusMethodNotAllowed,
+			wantPatches: 1,
+		},
+		{
+			name:        "chunked upload not implemented",
+			opts:        &Options{ChunkSize: 3000},
+			patchStatus: http.StatusNotImplemented,
+			wantPatches: 1,
+		},
+		{
+			name:        "resumed after a failure",
+			opts:        &Options{ChunkSize: 3000},
+			failPatch:   2,
+			wantPatches: 5,
+			wantGets:    1,
+		},
+		{
+			name:        "resumed after a failure of the first chunk",
+			opts:        &Options{ChunkSize: 3000},
+			failPatch:   1,
+			wantPatches: 5,
+			wantGets:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &uploadServer{
+				patchStatus: tt.patchStatus,
+				failPatch:   tt.failPatch,
+				requests:    map[string]int{},
+				tokens:      map[string]int{},
+			}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			ctx := context.Background()
+			if tt.opts != nil {
+				ctx = WithOptions(ctx, tt.opts)
+			}
+			resp := putBlob(t, ctx, ts.URL, blob)
+			if resp.StatusCode != http.StatusCreated {
+				t.Fatalf("upload status code = %d, want %d", resp.StatusCode, http.StatusCreated)
+			}
+			if !bytes.Equal(server.stored, blob) {
+				t.Errorf("stored %d bytes, want %d bytes", len(server.stored), len(blob))
+			}
+			if n := server.requests[http.MethodPatch]; n != tt.wantPatches {
+				t.Errorf("got %d PATCH requests, want %d", n, tt.wantPatches)
+			}
+			if n := server.requests[http.MethodGet]; n != tt.wantGets {
+				t.Errorf("got %d GET requests, want %d", n, tt.wantGets)
+			}
+			if n := server.requests[http.MethodPut]; n != 1 {
+				t.Errorf("got %d PUT requests, want 1", n)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip_noFallback(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789"), 1000)
+	for _, status := range []int{
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusRequestEntityTooLarge,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := &uploadServer{
+				patchStatus: status,
+				requests:    map[string]int{},
+				tokens:      map[string]int{},
+			}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			ctx := WithOptions(context.Background(), &Options{ChunkSize: 3000})
+			client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+			if _, err := client.Do(newUploadRequest(t, ctx, ts.URL, blob)); err == nil {
+				t.Fatal("upload error = nil, want error")
+			}
+			if n := server.requests[http.MethodPut]; n != 0 {
+				t.Errorf("got %d PUT requests, want 0", n)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip_tokenRefresh(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789"), 1000)
+	server := &uploadServer{
+		tokenUses: 2,
+		requests:  map[string]int{},
+		tokens:    map[string]int{},
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	transport := NewTransport(http.DefaultTransport)
+	client := &auth.Client{
+		Client: &http.Client{Transport: transport},
+		Cache:  auth.NewCache(),
+	}
+	transport.Client = client
+	ctx := WithOptions(context.Background(), &Options{ChunkSize: 3000})
+	resp, err := client.Do(newUploadRequest(t, ctx, ts.URL, blob))
+	if err != nil {
+		t.Fatalf("upload error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("upload status code = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if !bytes.Equal(server.stored, blob) {
+		t.Errorf("stored %d bytes, want %d bytes", len(server.stored), len(blob))
+	}
+	if n := server.requests[http.MethodPatch]; n != 4 {
+		t.Errorf("got %d PATCH requests, want 4", n)
+	}
+	// 4 PATCH requests and 1 PUT request with 2 uses of each token
+	if server.issued < 3 {
+		t.Errorf("issued %d tokens, want at least 3", server.issued)
+	}
+}
+
+func Test_parseRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0-0", 0, false},
+		{"0-1023", 1024, false},
+		{"bytes=0-1023", 1024, false},
+		{"1-1023", 0, true},
+		{"0-", 0, true},
+		{"1023", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseRange(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// EnvPath is the environment variable overriding the config file path.
+	EnvPath = "ORAS_CONFIG"
+	// FileName is the name of the config file under the oras config
+	// directory.
+	FileName = "config.json"
+)
+
+// Config is the oras config file.
+type Config struct {
+	// CacheDir is the root directory of the local content cache.
+	// A relative path is resolved against the directory of the config file.
+	CacheDir string `json:"cacheDir,omitempty"`
+}
+
+// Path returns the path of the config file, which is either specified by
+// the ORAS_CONFIG environment variable or `oras/config.json` under the user
+// config directory.
+func Path() (string, error) {
+	if path := os.Getenv(EnvPath); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oras", FileName), nil
+}
+
+// Load loads the config file at path. An empty config is returned if the file
+// does not exist.
+func Load(path string) (*Config, error) {
+	var cfg Config
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if cfg.CacheDir != "" && !filepath.IsAbs(cfg.CacheDir) {
+		cfg.CacheDir = filepath.Join(filepath.Dir(path), cfg.CacheDir)
+	}
+	return &cfg, nil
+}
+
+// LoadDefault loads the config file at the default path.
+func LoadDefault() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "absolute cache dir",
+			content: `{"cacheDir":"/var/cache/oras"}`,
+			want:    "/var/cache/oras",
+		},
+		{
+			name:    "relative cache dir",
+			content: `{"cacheDir":"cache"}`,
+			want:    filepath.Join(dir, "cache"),
+		},
+		{
+			name:    "no cache dir",
+			content: `{}`,
+		},
+		{
+			name:    "malformed",
+			content: `{`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, FileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.CacheDir != tt.want {
+				t.Errorf("Load() CacheDir = %q, want %q", got.CacheDir, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_notExist(t *testing.T) {
+	got, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.CacheDir != "" {
+		t.Errorf("Load() CacheDir = %q, want empty", got.CacheDir)
+	}
+}
+
+func TestPath_env(t *testing.T) {
+	want := filepath.Join(t.TempDir(), "custom.json")
+	t.Setenv(EnvPath, want)
+	got, err := Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
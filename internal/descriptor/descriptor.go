@@ -16,6 +16,8 @@ limitations under the License.
 package descriptor
 
 import (
+	"strings"
+
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/internal/docker"
@@ -56,6 +58,19 @@ func ShortDigest(desc ocispec.Descriptor) (digestString string) {
 	return digestString
 }
 
+// PlatformString formats a platform as os/arch[/variant] for displaying. An
+// empty string is returned if p is nil.
+func PlatformString(p *ocispec.Platform) string {
+	if p == nil {
+		return ""
+	}
+	parts := []string{p.OS, p.Architecture}
+	if p.Variant != "" {
+		parts = append(parts, p.Variant)
+	}
+	return strings.Join(parts, "/")
+}
+
 // Plain returns a plain descriptor that contains only MediaType, Digest and Size.
 // Copied from `oras-go`: https://github.com/oras-project/oras-go/blob/d6c837e439f4c567f8003eab6e423c22900452a8/internal/descriptor/descriptor.go#L81
 func Plain(desc ocispec.Descriptor) ocispec.Descriptor {
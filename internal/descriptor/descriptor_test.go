@@ -76,6 +76,26 @@ func TestDescriptor_ShortDigest(t *testing.T) {
 	}
 }
 
+func TestDescriptor_PlatformString(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform *ocispec.Platform
+		want     string
+	}{
+		{"nil", nil, ""},
+		{"os and architecture", &ocispec.Platform{OS: "linux", Architecture: "amd64"}, "linux/amd64"},
+		{"variant", &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}, "linux/arm64/v8"},
+		{"os version", &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.20348"}, "windows/amd64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptor.PlatformString(tt.platform); got != tt.want {
+				t.Errorf("PlatformString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDescriptor_GetTitleOrMediaType(t *testing.T) {
 	expected := "application/vnd.oci.image.manifest.v1+json"
 	name, isTitle := descriptor.GetTitleOrMediaType(imageDesc)
@@ -117,3 +117,28 @@ func PrepareBlobContent(path string, mediaType string, digestString string, size
 		Size:      actualSize,
 	}, file, nil
 }
+
+// Matches returns true if path is a regular file with the size and the
+// digest described by desc. A missing file does not match.
+func Matches(path string, desc ocispec.Descriptor) (bool, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !fi.Mode().IsRegular() || fi.Size() != desc.Size || !desc.Digest.Algorithm().Available() {
+		return false, nil
+	}
+	fp, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer fp.Close()
+	actual, err := desc.Digest.Algorithm().FromReader(fp)
+	if err != nil {
+		return false, err
+	}
+	return actual == desc.Digest, nil
+}
@@ -266,3 +266,42 @@ func TestFile_PrepareBlobContent_errOpenFile(t *testing.T) {
 		t.Fatalf("PrepareBlobContent() error = %v, wantErr %v", err, expected)
 	}
 }
+
+func TestFile_Matches(t *testing.T) {
+	tempDir := t.TempDir()
+	content := []byte("hello world")
+	path := filepath.Join(tempDir, "hello.txt")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal("error calling WriteFile(), error =", err)
+	}
+	desc := ocispec.Descriptor{
+		MediaType: blobMediaType,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	changed := []byte("hello there")
+
+	tests := []struct {
+		name string
+		path string
+		desc ocispec.Descriptor
+		want bool
+	}{
+		{"same content", path, desc, true},
+		{"different content of the same size", path, ocispec.Descriptor{Digest: digest.FromBytes(changed), Size: int64(len(changed))}, false},
+		{"different size", path, ocispec.Descriptor{Digest: desc.Digest, Size: desc.Size + 1}, false},
+		{"missing file", filepath.Join(tempDir, "missing.txt"), desc, false},
+		{"directory", tempDir, desc, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := file.Matches(tt.path, tt.desc)
+			if err != nil {
+				t.Fatal("Matches() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
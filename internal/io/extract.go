@@ -0,0 +1,277 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"oras.land/oras-go/v2/content/file"
+)
+
+// Compressions of tar archives.
+const (
+	CompressionNone = ""
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// ErrUnsupportedCompression is returned when extracting a tar archive
+// compressed by an unsupported algorithm.
+var ErrUnsupportedCompression = errors.New("unsupported compression")
+
+// TarCompression returns the compression of a tar archive of the given media
+// type, e.g. "application/vnd.oci.image.layer.v1.tar+gzip". ok is false if the
+// media type is not a tar archive.
+func TarCompression(mediaType string) (compression string, ok bool) {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = parsed
+	}
+	mediaType = strings.ToLower(mediaType)
+	switch {
+	case strings.HasSuffix(mediaType, ".tar"), strings.HasSuffix(mediaType, "/tar"), strings.HasSuffix(mediaType, "/x-tar"):
+		return CompressionNone, true
+	case strings.HasSuffix(mediaType, ".tar+gzip"), strings.HasSuffix(mediaType, ".tar.gzip"):
+		return CompressionGzip, true
+	case strings.HasSuffix(mediaType, ".tar+zstd"), strings.HasSuffix(mediaType, ".tar.zstd"):
+		return CompressionZstd, true
+	}
+	return "", false
+}
+
+// magics are the leading bytes of tar archives by compression, which are
+// found at offset 257 for uncompressed tar archives.
+var magics = map[string][]byte{
+	CompressionNone: []byte("ustar"),
+	CompressionGzip: {0x1f, 0x8b},
+	CompressionZstd: {0x28, 0xb5, 0x2f, 0xfd},
+}
+
+// SniffTar checks whether the content read from r looks like a tar archive
+// of the given compression, as files of any content may be pushed with a
+// tar media type. The returned reader reads the whole content of r.
+func SniffTar(r io.Reader, compression string) (io.Reader, bool, error) {
+	magic, ok := magics[compression]
+	if !ok {
+		return r, false, nil
+	}
+	offset := 0
+	if compression == CompressionNone {
+		offset = 257
+	}
+	br := bufio.NewReaderSize(r, offset+len(magic))
+	header, err := br.Peek(offset + len(magic))
+	if err != nil && err != io.EOF {
+		return nil, false, err
+	}
+	return br, len(header) == offset+len(magic) && bytes.Equal(header[offset:], magic), nil
+}
+
+// Decompress returns a reader of r decompressed by the given compression.
+func Decompress(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%s: %w", compression, ErrUnsupportedCompression)
+	}
+}
+
+// ExtractOptions contains parameters for ExtractTar.
+type ExtractOptions struct {
+	// StripComponents is the number of leading path components removed from
+	// the name of each entry. Entries without remaining components are
+	// skipped.
+	StripComponents int
+	// AllowPathTraversal allows entries and symbolic links pointing out of
+	// the directory.
+	AllowPathTraversal bool
+	// KeepOldFiles fails the extraction instead of replacing existing files.
+	KeepOldFiles bool
+}
+
+// ExtractTar extracts the tar archive read from r into dir. Regular files,
+// directories, hard links and symbolic links are extracted and other entries
+// are skipped.
+func ExtractTar(r io.Reader, dir string, opts ExtractOptions) error {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		name := stripComponents(header.Name, opts.StripComponents)
+		if name == "" {
+			continue
+		}
+		target, err := resolveExtractPath(base, name, opts.AllowPathTraversal)
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeDir {
+			if err := prepareExtractPath(target, opts.KeepOldFiles); err != nil {
+				return err
+			}
+		}
+
+		switch header.Typeflag {
+		case tar.TypeReg:
+			err = writeExtractedFile(target, tr, header.FileInfo().Mode().Perm())
+		case tar.TypeDir:
+			err = os.MkdirAll(target, header.FileInfo().Mode().Perm()|0700)
+		case tar.TypeLink:
+			linkName := stripComponents(header.Linkname, opts.StripComponents)
+			if linkName == "" {
+				return fmt.Errorf("%q: hard link to %q is stripped", header.Name, header.Linkname)
+			}
+			var linkTarget string
+			if linkTarget, err = resolveExtractPath(base, linkName, opts.AllowPathTraversal); err == nil {
+				err = os.Link(linkTarget, target)
+			}
+		case tar.TypeSymlink:
+			if !opts.AllowPathTraversal {
+				linkTarget := header.Linkname
+				if !filepath.IsAbs(linkTarget) {
+					linkTarget = filepath.Join(filepath.Dir(target), linkTarget)
+				}
+				if !isWithin(base, linkTarget) {
+					return fmt.Errorf("%q: symbolic link to %q: %w", header.Name, header.Linkname, file.ErrPathTraversalDisallowed)
+				}
+			}
+			err = os.Symlink(header.Linkname, target)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeDir {
+			// errors ignored as times are not essential
+			_ = os.Chtimes(target, header.AccessTime, header.ModTime)
+		}
+	}
+}
+
+// stripComponents removes n leading components from the slash-separated
+// name, and returns an empty string if nothing remains. Absolute names are
+// treated as relative ones.
+func stripComponents(name string, n int) string {
+	name = strings.TrimLeft(path.Clean(name), "/")
+	if name == "." {
+		return ""
+	}
+	for ; n > 0 && name != ""; n-- {
+		if i := strings.IndexByte(name, '/'); i >= 0 {
+			name = name[i+1:]
+		} else {
+			name = ""
+		}
+	}
+	return name
+}
+
+// resolveExtractPath resolves the path of the entry name in base. Unless
+// allowTraversal is set, the path must be within base and not under any
+// symbolic link.
+func resolveExtractPath(base, name string, allowTraversal bool) (string, error) {
+	target := filepath.Join(base, filepath.FromSlash(name))
+	if allowTraversal {
+		return target, nil
+	}
+	if !isWithin(base, target) {
+		return "", fmt.Errorf("%q: %w", name, file.ErrPathTraversalDisallowed)
+	}
+	for dir := filepath.Dir(target); dir != base; dir = filepath.Dir(dir) {
+		if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("%q: symbolic link in path: %w", name, file.ErrPathTraversalDisallowed)
+		}
+	}
+	return target, nil
+}
+
+// isWithin returns true if target is base or is in base.
+func isWithin(base, target string) bool {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// prepareExtractPath creates the parent directory of target and removes any
+// existing file at target, which is an error if keepOldFiles is set.
+func prepareExtractPath(target string, keepOldFiles bool) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	info, err := os.Lstat(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if keepOldFiles {
+		return fmt.Errorf("%s: %w", target, file.ErrOverwriteDisallowed)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s: cannot replace a directory", target)
+	}
+	// remove the old file so that links are replaced instead of followed
+	return os.Remove(target)
+}
+
+// writeExtractedFile writes the content read from r to a new file.
+func writeExtractedFile(target string, r io.Reader, perm os.FileMode) (err error) {
+	fp, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := fp.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	_, err = io.Copy(fp, r)
+	return err
+}
@@ -0,0 +1,328 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"oras.land/oras-go/v2/content/file"
+)
+
+// tarEntry is an entry of a test tar archive.
+type tarEntry struct {
+	name     string
+	typeflag byte
+	content  string
+	linkname string
+}
+
+func buildTar(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		header := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0644,
+			Size:     int64(len(e.content)),
+		}
+		if e.typeflag == tar.TypeDir {
+			header.Mode = 0755
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestTarCompression(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		want      string
+		wantOK    bool
+	}{
+		{"application/vnd.oci.image.layer.v1.tar", CompressionNone, true},
+		{"application/vnd.oci.image.layer.v1.tar+gzip", CompressionGzip, true},
+		{"application/vnd.oci.image.layer.v1.tar+zstd", CompressionZstd, true},
+		{"application/vnd.docker.image.rootfs.diff.tar.gzip", CompressionGzip, true},
+		{"application/x-tar", CompressionNone, true},
+		{"application/vnd.oci.image.layer.v1.tar+gzip; charset=binary", CompressionGzip, true},
+		{"application/gzip", "", false},
+		{"text/plain", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			got, ok := TarCompression(tt.mediaType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("TarCompression() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func compress(t *testing.T, content []byte, compression string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = zw
+	default:
+		return content
+	}
+	if _, err := w.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSniffTar(t *testing.T) {
+	archive := buildTar(t, tarEntry{name: "a.txt", typeflag: tar.TypeReg, content: "hello"})
+	gz := compress(t, archive, CompressionGzip)
+	zst := compress(t, archive, CompressionZstd)
+
+	tests := []struct {
+		name        string
+		content     []byte
+		compression string
+		want        bool
+	}{
+		{"tar", archive, CompressionNone, true},
+		{"tar+gzip", gz, CompressionGzip, true},
+		{"tar+zstd", zst, CompressionZstd, true},
+		{"plain file as tar", []byte("hello world"), CompressionNone, false},
+		{"plain file as tar+gzip", []byte("hello world"), CompressionGzip, false},
+		{"plain file as tar+zstd", []byte("hello world"), CompressionZstd, false},
+		{"gzip as tar", gz, CompressionNone, false},
+		{"empty", nil, CompressionNone, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, got, err := SniffTar(bytes.NewReader(tt.content), tt.compression)
+			if err != nil {
+				t.Fatal("SniffTar() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("SniffTar() = %v, want %v", got, tt.want)
+			}
+			all, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal("ReadAll() error =", err)
+			}
+			if !bytes.Equal(all, tt.content) {
+				t.Error("SniffTar() reader does not read the whole content")
+			}
+		})
+	}
+}
+
+func TestDecompress_unsupported(t *testing.T) {
+	if _, err := Decompress(bytes.NewReader(nil), "bzip2"); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Errorf("Decompress() error = %v, want %v", err, ErrUnsupportedCompression)
+	}
+}
+
+func TestExtractTar(t *testing.T) {
+	archive := buildTar(t,
+		tarEntry{name: "top/", typeflag: tar.TypeDir},
+		tarEntry{name: "top/a.txt", typeflag: tar.TypeReg, content: "a"},
+		tarEntry{name: "top/sub/b.txt", typeflag: tar.TypeReg, content: "b"},
+		tarEntry{name: "top/link", typeflag: tar.TypeSymlink, linkname: "a.txt"},
+		tarEntry{name: "top/hard", typeflag: tar.TypeLink, linkname: "top/a.txt"},
+	)
+	tests := []struct {
+		name  string
+		strip int
+		want  map[string]string
+	}{
+		{
+			name: "no strip",
+			want: map[string]string{"top/a.txt": "a", "top/sub/b.txt": "b", "top/link": "a", "top/hard": "a"},
+		},
+		{
+			name:  "strip 1",
+			strip: 1,
+			want:  map[string]string{"a.txt": "a", "sub/b.txt": "b", "link": "a", "hard": "a"},
+		},
+		{
+			name:  "strip 2",
+			strip: 2,
+			want:  map[string]string{"b.txt": "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := ExtractTar(bytes.NewReader(archive), dir, ExtractOptions{StripComponents: tt.strip})
+			if err != nil {
+				t.Fatal("ExtractTar() error =", err)
+			}
+			for name, want := range tt.want {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("ReadFile(%q) error = %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("content of %q = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTar_compressed(t *testing.T) {
+	archive := buildTar(t,
+		tarEntry{name: "top/", typeflag: tar.TypeDir},
+		tarEntry{name: "top/a.txt", typeflag: tar.TypeReg, content: "a"},
+		tarEntry{name: "top/sub/b.txt", typeflag: tar.TypeReg, content: "b"},
+	)
+	for name, compression := range map[string]string{
+		"tar":      CompressionNone,
+		"tar+gzip": CompressionGzip,
+		"tar+zstd": CompressionZstd,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r, ok, err := SniffTar(bytes.NewReader(compress(t, archive, compression)), compression)
+			if err != nil || !ok {
+				t.Fatalf("SniffTar() = %v, %v, want true", ok, err)
+			}
+			rc, err := Decompress(r, compression)
+			if err != nil {
+				t.Fatal("Decompress() error =", err)
+			}
+			defer rc.Close()
+			dir := t.TempDir()
+			if err := ExtractTar(rc, dir, ExtractOptions{}); err != nil {
+				t.Fatal("ExtractTar() error =", err)
+			}
+			for name, want := range map[string]string{"top/a.txt": "a", "top/sub/b.txt": "b"} {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("ReadFile(%q) error = %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("content of %q = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTar_strippedLinkTarget(t *testing.T) {
+	archive := buildTar(t,
+		tarEntry{name: "a.txt", typeflag: tar.TypeReg, content: "a"},
+		tarEntry{name: "top/hard", typeflag: tar.TypeLink, linkname: "a.txt"},
+	)
+	if err := ExtractTar(bytes.NewReader(archive), t.TempDir(), ExtractOptions{StripComponents: 1}); err == nil {
+		t.Error("ExtractTar() error = nil, want error")
+	}
+}
+
+func TestExtractTar_pathTraversal(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name:    "parent directory",
+			entries: []tarEntry{{name: "../evil.txt", typeflag: tar.TypeReg, content: "evil"}},
+		},
+		{
+			name:    "symbolic link out of directory",
+			entries: []tarEntry{{name: "link", typeflag: tar.TypeSymlink, linkname: "../.."}},
+		},
+		{
+			name: "file under symbolic link",
+			entries: []tarEntry{
+				{name: "link", typeflag: tar.TypeSymlink, linkname: "."},
+				{name: "link/evil.txt", typeflag: tar.TypeReg, content: "evil"},
+			},
+		},
+		{
+			name:    "hard link out of directory",
+			entries: []tarEntry{{name: "hard", typeflag: tar.TypeLink, linkname: "../evil.txt"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "out")
+			err := ExtractTar(bytes.NewReader(buildTar(t, tt.entries...)), dir, ExtractOptions{})
+			if !errors.Is(err, file.ErrPathTraversalDisallowed) {
+				t.Errorf("ExtractTar() error = %v, want %v", err, file.ErrPathTraversalDisallowed)
+			}
+		})
+	}
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "out")
+	archive := buildTar(t, tarEntry{name: "../allowed.txt", typeflag: tar.TypeReg, content: "ok"})
+	if err := ExtractTar(bytes.NewReader(archive), dir, ExtractOptions{AllowPathTraversal: true}); err != nil {
+		t.Fatal("ExtractTar() error =", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "allowed.txt")); err != nil {
+		t.Error("ExtractTar() did not allow path traversal:", err)
+	}
+}
+
+func TestExtractTar_keepOldFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	archive := buildTar(t, tarEntry{name: "a.txt", typeflag: tar.TypeReg, content: "new"})
+
+	err := ExtractTar(bytes.NewReader(archive), dir, ExtractOptions{KeepOldFiles: true})
+	if !errors.Is(err, file.ErrOverwriteDisallowed) {
+		t.Fatalf("ExtractTar() error = %v, want %v", err, file.ErrOverwriteDisallowed)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "old" {
+		t.Errorf("content = %q, want %q", got, "old")
+	}
+
+	if err := ExtractTar(bytes.NewReader(archive), dir, ExtractOptions{}); err != nil {
+		t.Fatal("ExtractTar() error =", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "new" {
+		t.Errorf("content = %q, want %q", got, "new")
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrOffline is returned when a network access is required in offline mode.
+var ErrOffline = errors.New("network access is refused in offline mode")
+
+type contextKey int
+
+// offlineKey is the associated key type for offline mode in context.
+const offlineKey contextKey = iota
+
+// WithOffline returns a context in offline mode.
+func WithOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineKey, true)
+}
+
+// IsOffline tells if ctx is in offline mode.
+func IsOffline(ctx context.Context) bool {
+	offline, _ := ctx.Value(offlineKey).(bool)
+	return offline
+}
+
+// OfflineTransport is an http.RoundTripper refusing all requests whose context
+// is in offline mode. Other requests are passed through untouched.
+type OfflineTransport struct {
+	http.RoundTripper
+}
+
+// NewOfflineTransport creates and returns a new instance of OfflineTransport.
+func NewOfflineTransport(base http.RoundTripper) *OfflineTransport {
+	return &OfflineTransport{
+		RoundTripper: base,
+	}
+}
+
+// RoundTrip refuses the request in offline mode.
+func (t *OfflineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if IsOffline(req.Context()) {
+		// the request is described by the *url.Error wrapping ErrOffline
+		return nil, ErrOffline
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
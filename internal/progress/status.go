@@ -39,3 +39,25 @@ type Status struct {
 	// Offset is discarded if set to a negative value.
 	Offset int64
 }
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateInitialized:
+		return "initialized"
+	case StateTransmitting:
+		return "transmitting"
+	case StateTransmitted:
+		return "transmitted"
+	case StateExists:
+		return "exists"
+	case StateSkipped:
+		return "skipped"
+	case StateMounted:
+		return "mounted"
+	case StateRestored:
+		return "restored"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit limits the bandwidth of transfers with token buckets.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket refilled at a fixed number of bytes per second.
+// A Limiter is safe for concurrent use, so that a single bandwidth limit can
+// be shared by all concurrent transfers.
+type Limiter struct {
+	rate  float64 // bytes per second
+	burst int
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a limiter allowing rate bytes per second.
+// The bucket holds up to one second worth of tokens.
+func NewLimiter(rate int64) *Limiter {
+	burst := int(rate)
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   float64(rate),
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WaitN blocks until n bytes are allowed or ctx is done.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	for n > 0 {
+		chunk := min(n, l.burst)
+		if err := l.wait(ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// wait reserves n tokens and waits for the deficit to be refilled. Reserving
+// ahead of time lets concurrent waiters queue fairly for the shared bucket.
+func (l *Limiter) wait(ctx context.Context, n int) error {
+	l.lock.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	deficit := -l.tokens
+	l.lock.Unlock()
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / l.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// return the reserved tokens
+		l.lock.Lock()
+		l.tokens += float64(n)
+		l.lock.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestLimiter_WaitN(t *testing.T) {
+	l := NewLimiter(100 * 1024)
+	ctx := context.Background()
+	start := time.Now()
+	// the first 100 KiB are served by the initial burst
+	if err := l.WaitN(ctx, 100*1024); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("burst should not wait, waited %v", elapsed)
+	}
+	// the next 20 KiB take about 200ms
+	if err := l.WaitN(ctx, 20*1024); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("WaitN() returned too early after %v", elapsed)
+	}
+}
+
+func TestLimiter_WaitN_canceled(t *testing.T) {
+	l := NewLimiter(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.WaitN(ctx, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitN() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	r := strings.NewReader("foo")
+	if got := NewReader(context.Background(), r, nil, nil); got != r {
+		t.Error("NewReader() should return the original reader without limiters")
+	}
+
+	content := bytes.Repeat([]byte("a"), 2*maxReadSize)
+	limited := NewReader(context.Background(), bytes.NewReader(content), NewLimiter(1<<30))
+	buf := make([]byte, len(content))
+	n, err := limited.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != maxReadSize {
+		t.Errorf("Read() = %d, want %d", n, maxReadSize)
+	}
+	rest, err := io.ReadAll(limited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n+len(rest) != len(content) {
+		t.Errorf("read %d bytes, want %d", n+len(rest), len(content))
+	}
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1024)
+	var received int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+		_, _ = w.Write(content)
+	}))
+	defer ts.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	for _, limits := range []*Limits{nil, {Total: NewLimiter(1 << 20), Download: NewLimiter(1 << 20)}} {
+		ctx := context.Background()
+		if limits != nil {
+			ctx = WithLimits(ctx, limits)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, ts.URL, bytes.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received != len(content) || len(body) != len(content) {
+			t.Errorf("sent %d bytes and received %d bytes, want %d", received, len(body), len(content))
+		}
+		if limits == nil {
+			continue
+		}
+		// both directions consume the total limiter, minus the tokens refilled
+		// in the meantime
+		limits.Total.lock.Lock()
+		consumed := float64(1<<20) - limits.Total.tokens
+		limits.Total.lock.Unlock()
+		if consumed <= float64(len(content)) {
+			t.Errorf("total limiter consumed %v tokens, want more than %d", consumed, len(content))
+		}
+	}
+}
+
+func TestNewTarget(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1024)
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	limits := &Limits{Total: NewLimiter(1 << 20), Download: NewLimiter(1 << 20)}
+	ctx := WithLimits(context.Background(), limits)
+	store := memory.New()
+	if err := NewTarget(store, DownloadReader).Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		t.Fatal("Push() error =", err)
+	}
+	if exists, err := store.Exists(ctx, desc); err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+	for name, l := range map[string]*Limiter{"total": limits.Total, "download": limits.Download} {
+		l.lock.Lock()
+		consumed := float64(1<<20) - l.tokens
+		l.lock.Unlock()
+		if consumed < float64(len(content)) {
+			t.Errorf("%s limiter consumed %v tokens, want at least %d", name, consumed, len(content))
+		}
+	}
+}
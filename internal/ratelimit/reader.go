@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+)
+
+// maxReadSize caps the size of a single read so that waits are spread evenly
+// instead of stalling on large buffers.
+const maxReadSize = 32 * 1024
+
+// reader is an io.Reader throttled by limiters.
+type reader struct {
+	ctx      context.Context
+	base     io.Reader
+	limiters []*Limiter
+}
+
+// NewReader returns a reader consuming tokens of all limiters for the bytes
+// read from r. Nil limiters are ignored and r is returned untouched if no
+// limiter is given.
+func NewReader(ctx context.Context, r io.Reader, limiters ...*Limiter) io.Reader {
+	var active []*Limiter
+	for _, l := range limiters {
+		if l != nil {
+			active = append(active, l)
+		}
+	}
+	if len(active) == 0 {
+		return r
+	}
+	return &reader{
+		ctx:      ctx,
+		base:     r,
+		limiters: active,
+	}
+}
+
+// Read implements io.Reader.
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) > maxReadSize {
+		p = p[:maxReadSize]
+	}
+	n, err := r.base.Read(p)
+	for _, l := range r.limiters {
+		if waitErr := l.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
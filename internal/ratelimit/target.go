@@ -0,0 +1,46 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+)
+
+// target is an oras.GraphTarget throttling the content pushed to it.
+type target struct {
+	oras.GraphTarget
+	limit func(ctx context.Context, r io.Reader) io.Reader
+}
+
+// NewTarget returns t with the content pushed to it throttled at the reader
+// level by limit, e.g. DownloadReader, with the limits carried by the push
+// context. It is meant for copies between local targets, such as OCI layouts,
+// which are not throttled by Transport.
+func NewTarget(t oras.GraphTarget, limit func(ctx context.Context, r io.Reader) io.Reader) oras.GraphTarget {
+	return &target{
+		GraphTarget: t,
+		limit:       limit,
+	}
+}
+
+// Push pushes the content throttled by the limits carried by ctx.
+func (t *target) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	return t.GraphTarget.Push(ctx, expected, t.limit(ctx, content))
+}
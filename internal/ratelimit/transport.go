@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+type contextKey int
+
+// limitsKey is the associated key type for Limits in context.
+const limitsKey contextKey = iota
+
+// Limits holds the limiters of a command. Transfers in both directions
+// consume the Total limiter, if any, in addition to the limiter of their
+// direction.
+type Limits struct {
+	Total    *Limiter
+	Upload   *Limiter
+	Download *Limiter
+}
+
+// WithLimits returns a context carrying limits.
+func WithLimits(ctx context.Context, limits *Limits) context.Context {
+	return context.WithValue(ctx, limitsKey, limits)
+}
+
+// LimitsFromContext returns the limits carried by ctx, or nil.
+func LimitsFromContext(ctx context.Context) *Limits {
+	limits, _ := ctx.Value(limitsKey).(*Limits)
+	return limits
+}
+
+// TotalReader returns r throttled by the total limit carried by ctx.
+func TotalReader(ctx context.Context, r io.Reader) io.Reader {
+	limits := LimitsFromContext(ctx)
+	if limits == nil {
+		return r
+	}
+	return NewReader(ctx, r, limits.Total)
+}
+
+// UploadReader returns r throttled by the upload limits carried by ctx.
+func UploadReader(ctx context.Context, r io.Reader) io.Reader {
+	limits := LimitsFromContext(ctx)
+	if limits == nil {
+		return r
+	}
+	return NewReader(ctx, r, limits.Total, limits.Upload)
+}
+
+// DownloadReader returns r throttled by the download limits carried by ctx.
+func DownloadReader(ctx context.Context, r io.Reader) io.Reader {
+	limits := LimitsFromContext(ctx)
+	if limits == nil {
+		return r
+	}
+	return NewReader(ctx, r, limits.Total, limits.Download)
+}
+
+// Transport is an http.RoundTripper throttling request and response bodies
+// with the limits carried by the request context. Requests without limits in
+// context are passed through untouched.
+type Transport struct {
+	http.RoundTripper
+}
+
+// NewTransport creates and returns a new instance of Transport.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		RoundTripper: base,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if LimitsFromContext(ctx) == nil {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(ctx)
+		req.Body = readCloser{
+			Reader: UploadReader(ctx, req.Body),
+			Closer: req.Body,
+		}
+	}
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = readCloser{
+			Reader: DownloadReader(ctx, resp.Body),
+			Closer: resp.Body,
+		}
+	}
+	return resp, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
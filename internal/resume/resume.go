@@ -0,0 +1,261 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resume provides targets persisting partial downloads of blobs so
+// that interrupted downloads can be resumed with range requests.
+package resume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/descriptor"
+)
+
+// PartialSuffix is the suffix of files persisting partial downloads.
+const PartialSuffix = ".partial"
+
+// digestLength is the number of leading characters of the encoded digest
+// in the name of a partial file.
+const digestLength = 16
+
+// PathFunc returns the path the content of desc is downloaded to, or an
+// empty string if the download of desc is not resumable.
+type PathFunc func(desc ocispec.Descriptor) string
+
+// PartialPath returns the path of the file persisting the partial download
+// of the content of dgst to path. Partial files are keyed by digest so that
+// a partial download of other content to the same path is never resumed.
+func PartialPath(path string, dgst digest.Digest) string {
+	encoded := dgst.Encoded()
+	return path + "." + encoded[:min(len(encoded), digestLength)] + PartialSuffix
+}
+
+// Target is a read-only target downloading resumable content to partial
+// files while it is read, and moving the partial files into place once the
+// content is completely read and verified. Consumers of Target should not
+// write the content of resumable descriptors themselves, see Destination.
+type Target struct {
+	oras.ReadOnlyTarget
+	path PathFunc
+
+	destinations sync.Map // map[string]string
+}
+
+// NewTarget returns a target downloading the content fetched from src to the
+// paths given by path. If a partial file of the content exists, the content
+// in it is read first and the remaining content is fetched with a range
+// request if src supports seeking, or from the start otherwise. The digest is
+// verified over the combined content. Partial files of other content to the
+// same path are removed.
+func NewTarget(src oras.ReadOnlyTarget, path PathFunc) *Target {
+	return &Target{
+		ReadOnlyTarget: src,
+		path:           path,
+	}
+}
+
+// Fetch fetches the content identified by desc.
+func (t *Target) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	path := t.path(desc)
+	if path == "" {
+		return t.ReadOnlyTarget.Fetch(ctx, desc)
+	}
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	partialPath := PartialPath(path, desc.Digest)
+	if err := removeStalePartials(path, partialPath); err != nil {
+		return nil, err
+	}
+	fp, err := os.OpenFile(partialPath, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := t.open(ctx, fp, desc, path)
+	if err != nil {
+		_ = fp.Close()
+		return nil, err
+	}
+	t.destinations.Store(descriptor.GenerateContentKey(desc), path)
+	return pr, nil
+}
+
+// Destination returns the path the content of desc fetched from t is moved
+// to once completely read, or an empty string if desc is not fetched as
+// resumable content.
+func (t *Target) Destination(desc ocispec.Descriptor) string {
+	path, _ := t.destinations.Load(descriptor.GenerateContentKey(desc))
+	s, _ := path.(string)
+	return s
+}
+
+// open resumes the download of desc into the partial file fp.
+func (t *Target) open(ctx context.Context, fp *os.File, desc ocispec.Descriptor, path string) (*partialReader, error) {
+	info, err := fp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := info.Size()
+	if offset > desc.Size {
+		offset = 0
+	}
+	var rc io.ReadCloser
+	if offset < desc.Size {
+		if rc, err = t.ReadOnlyTarget.Fetch(ctx, desc); err != nil {
+			return nil, err
+		}
+		if offset > 0 {
+			seeker, ok := rc.(io.Seeker)
+			if !ok {
+				offset = 0
+			} else if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				// range requests are not supported, download from the start
+				offset = 0
+			}
+		}
+		if offset == 0 {
+			if err := fp.Truncate(0); err != nil {
+				_ = rc.Close()
+				return nil, err
+			}
+		}
+	}
+
+	pr := &partialReader{
+		file:     fp,
+		rc:       rc,
+		path:     path,
+		desc:     desc,
+		digester: desc.Digest.Algorithm().Digester(),
+	}
+	r := io.Reader(io.NewSectionReader(fp, 0, offset))
+	if rc != nil {
+		remaining := io.TeeReader(io.LimitReader(rc, desc.Size-offset), io.NewOffsetWriter(fp, offset))
+		r = io.MultiReader(r, remaining)
+	}
+	pr.r = io.TeeReader(r, pr.digester.Hash())
+	return pr, nil
+}
+
+// removeStalePartials removes the partial files of other content downloaded
+// to path than partialPath.
+func removeStalePartials(path, partialPath string) error {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	prefix := filepath.Base(path) + "."
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, PartialSuffix) {
+			continue
+		}
+		if encoded := strings.TrimSuffix(strings.TrimPrefix(name, prefix), PartialSuffix); !isHex(encoded) {
+			continue
+		}
+		if stale := filepath.Join(filepath.Dir(path), name); stale != partialPath {
+			if err := os.Remove(stale); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isHex returns true if s is a non-empty lower case hexadecimal string.
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// partialReader reads the content in the partial file followed by the
+// remaining content, which is written to the partial file. Once the content
+// is completely read and verified, the partial file is moved to path.
+type partialReader struct {
+	r        io.Reader
+	file     *os.File
+	rc       io.ReadCloser
+	path     string
+	desc     ocispec.Descriptor
+	digester digest.Digester
+	n        int64
+	done     bool
+	corrupt  bool
+}
+
+// Read reads the content and verifies it once completely read.
+func (pr *partialReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.n += int64(n)
+	if pr.n == pr.desc.Size && !pr.done && !pr.corrupt {
+		if pr.digester.Digest() != pr.desc.Digest {
+			pr.corrupt = true
+			return n, fmt.Errorf("%s: %w", pr.desc.Digest, content.ErrMismatchedDigest)
+		}
+		if err := pr.commit(); err != nil {
+			return n, err
+		}
+	}
+	if err == io.EOF && pr.n != pr.desc.Size {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// commit moves the partial file of the verified content into place.
+func (pr *partialReader) commit() error {
+	pr.done = true
+	if err := pr.file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(pr.file.Name(), pr.path)
+}
+
+// Close closes the content, and removes the partial file if the content is
+// corrupted.
+func (pr *partialReader) Close() error {
+	var errs []error
+	if pr.rc != nil {
+		errs = append(errs, pr.rc.Close())
+	}
+	if !pr.done {
+		errs = append(errs, pr.file.Close())
+	}
+	if pr.corrupt {
+		errs = append(errs, os.Remove(pr.file.Name()))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,333 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resume
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/internal/cache"
+)
+
+// rangeStorage serves content as seekable readers if seekable is set, and
+// fails reading after failAt bytes if failAt is positive.
+type rangeStorage struct {
+	*memory.Store
+	seekable bool
+	failAt   int64
+	fetched  int64
+}
+
+type readSeekCloser struct {
+	io.ReadSeeker
+	io.Closer
+}
+
+func (s *rangeStorage) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	b, err := content.FetchAll(ctx, s.Store, desc)
+	if err != nil {
+		return nil, err
+	}
+	rs := &countingReader{ReadSeeker: bytes.NewReader(b), storage: s}
+	if s.seekable {
+		return readSeekCloser{ReadSeeker: rs, Closer: io.NopCloser(nil)}, nil
+	}
+	return io.NopCloser(struct{ io.Reader }{rs}), nil
+}
+
+type countingReader struct {
+	io.ReadSeeker
+	storage *rangeStorage
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	s := r.storage
+	if s.failAt > 0 {
+		if s.fetched >= s.failAt {
+			return 0, errors.New("connection reset")
+		}
+		if remaining := s.failAt - s.fetched; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+	n, err := r.ReadSeeker.Read(p)
+	s.fetched += int64(n)
+	return n, err
+}
+
+func newStorage(t *testing.T, blob []byte) (*rangeStorage, ocispec.Descriptor) {
+	t.Helper()
+	desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	store := memory.New()
+	if err := store.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	return &rangeStorage{Store: store}, desc
+}
+
+func fetchAll(t *testing.T, target *Target, desc ocispec.Descriptor) ([]byte, error) {
+	t.Helper()
+	rc, err := target.Fetch(context.Background(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	if closeErr := rc.Close(); err == nil {
+		err = closeErr
+	}
+	return got, err
+}
+
+// checkDownloaded checks that the content is moved to path and no partial
+// file is left.
+func checkDownloaded(t *testing.T, path string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("downloaded content = %q, want %q", got, want)
+	}
+	partials, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*"+PartialSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partials) != 0 {
+		t.Errorf("partial files are not removed: %v", partials)
+	}
+}
+
+func TestTarget_Fetch(t *testing.T) {
+	blob := []byte("hello world, this is a partially downloaded blob")
+	tests := []struct {
+		name        string
+		partial     []byte
+		seekable    bool
+		wantFetched int64
+	}{
+		{
+			name:        "no partial file",
+			seekable:    true,
+			wantFetched: int64(len(blob)),
+		},
+		{
+			name:        "resume with range request",
+			partial:     blob[:10],
+			seekable:    true,
+			wantFetched: int64(len(blob) - 10),
+		},
+		{
+			name:        "download from the start if not seekable",
+			partial:     blob[:10],
+			wantFetched: int64(len(blob)),
+		},
+		{
+			name:        "partial file of complete content",
+			partial:     blob,
+			seekable:    true,
+			wantFetched: 0,
+		},
+		{
+			name:        "partial file larger than content",
+			partial:     append(bytes.Clone(blob), "garbage"...),
+			seekable:    true,
+			wantFetched: int64(len(blob)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, desc := newStorage(t, blob)
+			storage.seekable = tt.seekable
+			path := filepath.Join(t.TempDir(), "out", "blob")
+			if tt.partial != nil {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(PartialPath(path, desc.Digest), tt.partial, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			target := NewTarget(storage, func(ocispec.Descriptor) string { return path })
+			got, err := fetchAll(t, target, desc)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if !bytes.Equal(got, blob) {
+				t.Errorf("Fetch() = %q, want %q", got, blob)
+			}
+			if storage.fetched != tt.wantFetched {
+				t.Errorf("fetched %d bytes from source, want %d", storage.fetched, tt.wantFetched)
+			}
+			if dest := target.Destination(desc); dest != path {
+				t.Errorf("Destination() = %q, want %q", dest, path)
+			}
+			checkDownloaded(t, path, blob)
+		})
+	}
+}
+
+func TestTarget_Fetch_interrupted(t *testing.T) {
+	blob := []byte("hello world, this is a partially downloaded blob")
+	storage, desc := newStorage(t, blob)
+	storage.seekable = true
+	storage.failAt = 20
+	path := filepath.Join(t.TempDir(), "blob")
+	target := NewTarget(storage, func(ocispec.Descriptor) string { return path })
+
+	if _, err := fetchAll(t, target, desc); err == nil {
+		t.Fatal("Fetch() error = nil, want error")
+	}
+	partial, err := os.ReadFile(PartialPath(path, desc.Digest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(partial, blob[:20]) {
+		t.Fatalf("partial file = %q, want %q", partial, blob[:20])
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("incomplete content is moved into place: %v", err)
+	}
+
+	// resume the download
+	storage.failAt = 0
+	storage.fetched = 0
+	got, err := fetchAll(t, target, desc)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+	if want := int64(len(blob) - 20); storage.fetched != want {
+		t.Errorf("fetched %d bytes from source, want %d", storage.fetched, want)
+	}
+	checkDownloaded(t, path, blob)
+}
+
+func TestTarget_Fetch_stalePartial(t *testing.T) {
+	blob := []byte("hello world, this is a partially downloaded blob")
+	storage, desc := newStorage(t, blob)
+	storage.seekable = true
+	path := filepath.Join(t.TempDir(), "blob")
+	// a partial download of the previous content of a moved tag
+	stale := PartialPath(path, digest.FromString("previous content"))
+	if err := os.WriteFile(stale, []byte("previous"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// files of users looking like partial files are kept
+	userFile := path + ".backup" + PartialSuffix
+	if err := os.WriteFile(userFile, []byte("backup"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	target := NewTarget(storage, func(ocispec.Descriptor) string { return path })
+
+	got, err := fetchAll(t, target, desc)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale partial file is not removed: %v", err)
+	}
+	if _, err := os.Stat(userFile); err != nil {
+		t.Errorf("user file is removed: %v", err)
+	}
+}
+
+func TestTarget_Fetch_corrupted(t *testing.T) {
+	blob := []byte("hello world, this is a partially downloaded blob")
+	storage, desc := newStorage(t, blob)
+	storage.seekable = true
+	path := filepath.Join(t.TempDir(), "blob")
+	partialPath := PartialPath(path, desc.Digest)
+	if err := os.WriteFile(partialPath, []byte("corrupted"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	target := NewTarget(storage, func(ocispec.Descriptor) string { return path })
+
+	if _, err := fetchAll(t, target, desc); !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Fatalf("Fetch() error = %v, want %v", err, content.ErrMismatchedDigest)
+	}
+	if _, err := os.Stat(partialPath); !os.IsNotExist(err) {
+		t.Errorf("corrupted partial file is not removed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("corrupted content is moved into place: %v", err)
+	}
+}
+
+func TestTarget_Fetch_notResumable(t *testing.T) {
+	blob := []byte("hello world")
+	storage, desc := newStorage(t, blob)
+	target := NewTarget(storage, func(ocispec.Descriptor) string { return "" })
+	got, err := fetchAll(t, target, desc)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+	if storage.fetched != int64(len(blob)) {
+		t.Errorf("fetched %d bytes from source, want %d", storage.fetched, len(blob))
+	}
+	if dest := target.Destination(desc); dest != "" {
+		t.Errorf("Destination() = %q, want empty", dest)
+	}
+}
+
+func TestTarget_Fetch_cached(t *testing.T) {
+	blob := []byte("hello world, this is a partially downloaded blob")
+	storage, desc := newStorage(t, blob)
+	storage.seekable = true
+	storage.failAt = 20
+	cacheStore := memory.New()
+	path := filepath.Join(t.TempDir(), "blob")
+	target := NewTarget(cache.New(storage, cacheStore), func(ocispec.Descriptor) string { return path })
+
+	if _, err := fetchAll(t, target, desc); err == nil {
+		t.Fatal("Fetch() error = nil, want error")
+	}
+
+	// resume the download through the cache
+	storage.failAt = 0
+	storage.fetched = 0
+	got, err := fetchAll(t, target, desc)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("Fetch() = %q, want %q", got, blob)
+	}
+	if want := int64(len(blob) - 20); storage.fetched != want {
+		t.Errorf("fetched %d bytes from source, want %d", storage.fetched, want)
+	}
+	checkDownloaded(t, path, blob)
+	if exists, err := cacheStore.Exists(context.Background(), desc); err != nil || exists {
+		t.Errorf("partially fetched content is cached: %v, %v", exists, err)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package segment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/content"
+)
+
+// maxPartSize is the maximum size of a part of the content downloaded with a
+// single range request, which bounds the memory buffering the parts.
+var maxPartSize int64 = 16 << 20
+
+// errClosed is returned when reading a closed body.
+var errClosed = errors.New("read on closed body")
+
+// part is a range of the content downloaded with a range request.
+type part struct {
+	start int64
+	end   int64 // exclusive
+	// done receives the content of the part, or the error downloading it
+	done chan result
+}
+
+// result is the result of downloading a part.
+type result struct {
+	data []byte
+	err  error
+}
+
+// body reads the content in order while downloading the parts ahead of the
+// reader concurrently, and verifies the digest of the content. At most the
+// window size of parts are buffered in memory at any time.
+type body struct {
+	segmenter *segmenter
+	digest    digest.Digest
+	size      int64
+	parts     []part
+	// window holds a token for each part being downloaded or buffered
+	window chan struct{}
+
+	start  sync.Once
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	current  int    // index of the part being read
+	data     []byte // unread content of the current part
+	err      error
+	offset   int64
+	digester digest.Digester
+}
+
+// newBody returns a body downloading the content of the given size with n
+// concurrent range requests sent by s. The content is split into parts of at
+// most maxPartSize, so that a window of n parts is buffered in memory.
+func newBody(s *segmenter, dgst digest.Digest, size int64, n int) *body {
+	partSize := min(max((size+int64(n)-1)/int64(n), 1), maxPartSize)
+	var parts []part
+	for start := int64(0); start < size; start += partSize {
+		parts = append(parts, part{
+			start: start,
+			end:   min(start+partSize, size),
+			done:  make(chan result, 1),
+		})
+	}
+	return &body{
+		segmenter: s,
+		digest:    dgst,
+		size:      size,
+		parts:     parts,
+		window:    make(chan struct{}, n),
+		digester:  dgst.Algorithm().Digester(),
+	}
+}
+
+// Read reads the content in order, waiting for the parts to be downloaded.
+// The downloads are started on the first read.
+func (b *body) Read(p []byte) (int, error) {
+	b.start.Do(b.download)
+	if b.err != nil {
+		return 0, b.err
+	}
+	if len(b.data) == 0 {
+		if b.offset == b.size {
+			return 0, io.EOF
+		}
+		if b.offset > 0 {
+			// release the window slot of the part read
+			<-b.window
+			b.current++
+		}
+		res := <-b.parts[b.current].done
+		if res.err != nil {
+			b.err = res.err
+			return 0, res.err
+		}
+		b.data = res.data
+	}
+
+	n := copy(p, b.data)
+	b.data = b.data[n:]
+	b.digester.Hash().Write(p[:n])
+	b.offset += int64(n)
+	if b.offset == b.size && b.digester.Digest() != b.digest {
+		b.err = fmt.Errorf("%s: %w", b.digest, content.ErrMismatchedDigest)
+		return n, b.err
+	}
+	return n, nil
+}
+
+// Close stops the downloads and releases the buffered parts.
+func (b *body) Close() error {
+	b.start.Do(func() {})
+	if b.err == nil {
+		b.err = errClosed
+	}
+	b.data = nil
+	if b.cancel == nil {
+		return nil
+	}
+	b.cancel()
+	b.wg.Wait()
+	return nil
+}
+
+// download starts downloading the parts in order, with at most the window
+// size of parts downloaded or buffered ahead of the reader.
+func (b *body) download() {
+	ctx, cancel := context.WithCancel(b.segmenter.req.Context())
+	b.cancel = cancel
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for i := range b.parts {
+			select {
+			case b.window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			b.wg.Add(1)
+			go func(p *part) {
+				defer b.wg.Done()
+				data, err := b.downloadPart(ctx, p)
+				p.done <- result{data: data, err: err}
+			}(&b.parts[i])
+		}
+	}()
+}
+
+// downloadPart downloads p with a range request.
+func (b *body) downloadPart(ctx context.Context, p *part) ([]byte, error) {
+	req := b.segmenter.newRequest(ctx, http.MethodGet)
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", p.start, p.end-1))
+	resp, err := b.segmenter.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, fmt.Errorf("%s %q: unexpected status code %d for range %d-%d", req.Method, req.URL, resp.StatusCode, p.start, p.end-1)
+	}
+	data := make([]byte, p.end-p.start)
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package segment downloads large blobs with concurrent range requests.
+package segment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+type contextKey int
+
+// optionsKey is the associated key type for Options in context.
+const optionsKey contextKey = iota
+
+// blobPathRegexp matches the path of a blob in the distribution API.
+var blobPathRegexp = regexp.MustCompile(`^/v2/.+/blobs/([^/]+)$`)
+
+// Options configures segmented downloads.
+type Options struct {
+	// Segments is the number of concurrent range requests to download a
+	// blob with, which is also the number of parts of the blob buffered in
+	// memory ahead of the reader. Segmented downloads are disabled if it is
+	// less than 2.
+	Segments int
+	// Threshold is the minimum size of a blob to be downloaded in segments.
+	Threshold int64
+}
+
+// WithOptions returns a context carrying opts.
+func WithOptions(ctx context.Context, opts *Options) context.Context {
+	return context.WithValue(ctx, optionsKey, opts)
+}
+
+// OptionsFromContext returns the options carried by ctx, or nil.
+func OptionsFromContext(ctx context.Context) *Options {
+	opts, _ := ctx.Value(optionsKey).(*Options)
+	return opts
+}
+
+// Transport is an http.RoundTripper downloading blobs in segments with the
+// options carried by the request context. A blob is downloaded in segments
+// only if a HEAD request shows that its size reaches the threshold and that
+// range requests are accepted. Other requests are passed through untouched.
+type Transport struct {
+	http.RoundTripper
+
+	// Client sends the HEAD and range requests of segmented downloads if
+	// set, such as an auth.Client whose transport is Transport, so that
+	// expired tokens are refreshed during long downloads. The requests are
+	// sent with the authorization of the download request by the base
+	// transport otherwise.
+	Client remote.Client
+}
+
+// NewTransport creates and returns a new instance of Transport.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		RoundTripper: base,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	opts := OptionsFromContext(req.Context())
+	if opts == nil || opts.Segments < 2 || req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	matches := blobPathRegexp.FindStringSubmatch(req.URL.Path)
+	if matches == nil {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	dgst, err := digest.Parse(matches[1])
+	if err != nil {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	// redirects to the blob storage are followed by the client
+	s := &segmenter{
+		client: t.Client,
+		req:    req,
+	}
+	if s.client == nil {
+		s.client = &http.Client{Transport: t.RoundTripper}
+		s.keepAuthorization = true
+	}
+	head, ok := s.head(opts.Threshold)
+	if !ok {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK)),
+		StatusCode:    http.StatusOK,
+		Proto:         head.Proto,
+		ProtoMajor:    head.ProtoMajor,
+		ProtoMinor:    head.ProtoMinor,
+		Header:        head.Header.Clone(),
+		ContentLength: head.ContentLength,
+		Body:          newBody(s, dgst, head.ContentLength, opts.Segments),
+		Request:       req,
+	}, nil
+}
+
+// segmenter sends the requests of a segmented download of the blob requested
+// by req.
+type segmenter struct {
+	client remote.Client
+	req    *http.Request
+	// keepAuthorization is set if the requests are sent with the
+	// authorization of the download request.
+	keepAuthorization bool
+}
+
+// newRequest returns a request for the blob with the headers of the download
+// request. The authorization is left to the client unless it is kept.
+func (s *segmenter) newRequest(ctx context.Context, method string) *http.Request {
+	req := s.req.Clone(ctx)
+	req.Method = method
+	if !s.keepAuthorization {
+		req.Header.Del("Authorization")
+	}
+	return req
+}
+
+// head sends a HEAD request for the blob, and returns the response if the blob
+// is eligible for segmented downloads.
+func (s *segmenter) head(threshold int64) (*http.Response, bool) {
+	resp, err := s.client.Do(s.newRequest(s.req.Context(), http.MethodHead))
+	if err != nil {
+		return nil, false
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK ||
+		resp.Header.Get("Accept-Ranges") != "bytes" ||
+		resp.ContentLength <= 0 ||
+		resp.ContentLength < threshold {
+		return nil, false
+	}
+	return resp, true
+}
@@ -0,0 +1,334 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package segment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// blobServer serves blob at any blob path, counting the requests by method
+// and whether they are range requests.
+type blobServer struct {
+	blob          []byte
+	disableRanges bool
+	failRanges    bool
+	// tokenUses is the number of requests a bearer token issued by the
+	// server is valid for. Requests are not authenticated if it is 0.
+	tokenUses int
+	issued    int
+	tokens    map[string]int // remaining uses by token
+
+	lock        sync.Mutex
+	requests    map[string]int
+	inflight    int
+	maxInflight int
+}
+
+func (s *blobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r) {
+		return
+	}
+	key := r.Method
+	if r.Header.Get("Range") != "" {
+		key += " range"
+	}
+	s.lock.Lock()
+	s.requests[key]++
+	s.inflight++
+	s.maxInflight = max(s.maxInflight, s.inflight)
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		s.inflight--
+		s.lock.Unlock()
+	}()
+	switch {
+	case s.failRanges && r.Header.Get("Range") != "":
+		w.WriteHeader(http.StatusInternalServerError)
+	case s.disableRanges:
+		w.Header().Set("Content-Length", strconv.Itoa(len(s.blob)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(s.blob)
+		}
+	default:
+		http.ServeContent(w, r, "", testTime, bytes.NewReader(s.blob))
+	}
+}
+
+// authorize issues tokens and checks the token of r if authentication is
+// enabled, and returns true if r is to be served.
+func (s *blobServer) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if s.tokenUses == 0 {
+		return true
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if r.URL.Path == "/token" {
+		s.issued++
+		token := fmt.Sprintf("token-%d", s.issued)
+		s.tokens[token] = s.tokenUses
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"token":%q}`, token)
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if s.tokens[token] <= 0 {
+		w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test",scope="repository:test:pull"`, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return false
+	}
+	s.tokens[token]--
+	return true
+}
+
+func (s *blobServer) count(key string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests[key]
+}
+
+func fetch(t *testing.T, ctx context.Context, url string) ([]byte, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code %d", resp.StatusCode)
+	}
+	got, err := io.ReadAll(resp.Body)
+	if err == nil && resp.ContentLength != int64(len(got)) {
+		t.Errorf("Content-Length = %d, want %d", resp.ContentLength, len(got))
+	}
+	return got, err
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	dgst := digest.FromBytes(blob)
+	blobPath := "/v2/test/blobs/" + dgst.String()
+	tests := []struct {
+		name          string
+		blob          []byte
+		path          string
+		opts          *Options
+		disableRanges bool
+		wantRanges    int
+	}{
+		{
+			name:       "segmented",
+			path:       blobPath,
+			opts:       &Options{Segments: 4, Threshold: 1024},
+			wantRanges: 4,
+		},
+		{
+			name:       "more segments than bytes",
+			blob:       []byte("x"),
+			path:       "/v2/test/blobs/" + digest.FromString("x").String(),
+			opts:       &Options{Segments: 4, Threshold: 1},
+			wantRanges: 1,
+		},
+		{
+			name: "no options",
+			path: blobPath,
+		},
+		{
+			name: "single segment",
+			path: blobPath,
+			opts: &Options{Segments: 1, Threshold: 1024},
+		},
+		{
+			name: "below threshold",
+			path: blobPath,
+			opts: &Options{Segments: 4, Threshold: int64(len(blob)) + 1},
+		},
+		{
+			name:          "range requests not accepted",
+			path:          blobPath,
+			opts:          &Options{Segments: 4, Threshold: 1024},
+			disableRanges: true,
+		},
+		{
+			name: "not a blob",
+			path: "/v2/test/manifests/" + dgst.String(),
+			opts: &Options{Segments: 4, Threshold: 1024},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := blob
+			if tt.blob != nil {
+				want = tt.blob
+			}
+			server := &blobServer{blob: want, disableRanges: tt.disableRanges, requests: map[string]int{}}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			ctx := context.Background()
+			if tt.opts != nil {
+				ctx = WithOptions(ctx, tt.opts)
+			}
+			got, err := fetch(t, ctx, ts.URL+tt.path)
+			if err != nil {
+				t.Fatalf("fetch() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("fetch() got %d bytes, want %d bytes", len(got), len(want))
+			}
+			if n := server.count("GET range"); n != tt.wantRanges {
+				t.Errorf("got %d range requests, want %d", n, tt.wantRanges)
+			}
+			if n := server.count("GET"); tt.wantRanges > 0 && n != 0 {
+				t.Errorf("got %d non-range GET requests, want 0", n)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip_window(t *testing.T) {
+	defer func(size int64) { maxPartSize = size }(maxPartSize)
+	maxPartSize = 1024
+
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	server := &blobServer{blob: blob, requests: map[string]int{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx := WithOptions(context.Background(), &Options{Segments: 4, Threshold: 1024})
+	got, err := fetch(t, ctx, ts.URL+"/v2/test/blobs/"+digest.FromBytes(blob).String())
+	if err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("fetch() got %d bytes, want %d bytes", len(got), len(blob))
+	}
+	if n, want := server.count("GET range"), len(blob)/1024; n != want {
+		t.Errorf("got %d range requests, want %d", n, want)
+	}
+	if server.maxInflight > 4 {
+		t.Errorf("got %d concurrent requests, want at most 4", server.maxInflight)
+	}
+}
+
+func TestTransport_RoundTrip_mismatchedDigest(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	server := &blobServer{blob: blob, requests: map[string]int{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx := WithOptions(context.Background(), &Options{Segments: 4, Threshold: 1024})
+	_, err := fetch(t, ctx, ts.URL+"/v2/test/blobs/"+digest.FromString("other").String())
+	if !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Errorf("fetch() error = %v, want %v", err, content.ErrMismatchedDigest)
+	}
+}
+
+func TestTransport_RoundTrip_segmentFailure(t *testing.T) {
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	server := &blobServer{blob: blob, failRanges: true, requests: map[string]int{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx := WithOptions(context.Background(), &Options{Segments: 4, Threshold: 1024})
+	if _, err := fetch(t, ctx, ts.URL+"/v2/test/blobs/"+digest.FromBytes(blob).String()); err == nil {
+		t.Error("fetch() error = nil, want error")
+	}
+}
+
+func TestTransport_RoundTrip_tokenRefresh(t *testing.T) {
+	defer func(size int64) { maxPartSize = size }(maxPartSize)
+	maxPartSize = 16 * 1024
+
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	server := &blobServer{
+		blob:      blob,
+		tokenUses: 3,
+		requests:  map[string]int{},
+		tokens:    map[string]int{},
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	transport := NewTransport(http.DefaultTransport)
+	client := &auth.Client{
+		Client: &http.Client{Transport: transport},
+		Cache:  auth.NewCache(),
+	}
+	transport.Client = client
+	ctx := WithOptions(context.Background(), &Options{Segments: 2, Threshold: 1024})
+	ctx = auth.AppendRepositoryScope(ctx, registry.Reference{Registry: ts.Listener.Addr().String(), Repository: "test"}, auth.ActionPull)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/v2/test/blobs/"+digest.FromBytes(blob).String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("fetch status code = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Errorf("fetched %d bytes, want %d bytes", len(got), len(blob))
+	}
+	if n := server.count(http.MethodGet + " range"); n != 4 {
+		t.Errorf("got %d range requests, want 4", n)
+	}
+	// 1 HEAD request and 4 range requests with 3 uses of each token
+	if server.issued < 2 {
+		t.Errorf("issued %d tokens, want at least 2", server.issued)
+	}
+}
+
+func TestBody_Close(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v2/test/blobs/sha256:abc", nil)
+	b := newBody(&segmenter{client: http.DefaultClient, req: req}, digest.FromString("x"), 1024, 4)
+	if err := b.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if n, err := b.Read(make([]byte, 8)); n != 0 || err == nil {
+		t.Errorf("Read() after Close() = %d, %v, want error", n, err)
+	}
+}
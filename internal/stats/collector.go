@@ -0,0 +1,174 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stats aggregates transfer statistics of a copy operation.
+package stats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/progress"
+)
+
+// Phase records the wall time spent in a named phase of an operation.
+type Phase struct {
+	Name    string
+	Elapsed time.Duration
+}
+
+// Summary is the aggregated transfer statistics.
+type Summary struct {
+	// BytesTransferred is the size of the content transmitted.
+	BytesTransferred int64
+	// BytesSkipped is the size of the content not transmitted as it already
+	// exists, is deduplicated or is skipped.
+	BytesSkipped int64
+	// BytesMounted is the size of the content mounted across repositories.
+	BytesMounted int64
+	// Counts is the number of nodes in each final state.
+	Counts map[progress.State]int
+	// Elapsed is the wall time since the collector is created.
+	Elapsed time.Duration
+	// Phases are the phases of the operation in the order they started.
+	Phases []Phase
+}
+
+// Throughput returns the average number of bytes transferred per second.
+func (s Summary) Throughput() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.BytesTransferred) / s.Elapsed.Seconds()
+}
+
+// Collector collects transfer statistics.
+// All methods of a nil *Collector are no-ops so that callers need not check
+// whether statistics are enabled.
+type Collector struct {
+	start  time.Time
+	lock   sync.Mutex
+	states map[string]progress.State
+	sizes  map[string]int64
+	phases []Phase
+}
+
+// NewCollector returns a collector starting the wall clock now.
+func NewCollector() *Collector {
+	return &Collector{
+		start:  time.Now(),
+		states: make(map[string]progress.State),
+		sizes:  make(map[string]int64),
+	}
+}
+
+// Record records the final state of desc. Only the first record of a node
+// is kept.
+func (c *Collector) Record(desc ocispec.Descriptor, state progress.State) {
+	if c == nil {
+		return
+	}
+	key := descriptor.GenerateContentKey(desc)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.states[key]; ok {
+		return
+	}
+	c.states[key] = state
+	c.sizes[key] = desc.Size
+}
+
+// StartPhase starts timing the named phase and returns the function ending
+// it. The time of phases with the same name is accumulated.
+func (c *Collector) StartPhase(name string) (end func()) {
+	if c == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		for i := range c.phases {
+			if c.phases[i].Name == name {
+				c.phases[i].Elapsed += elapsed
+				return
+			}
+		}
+		c.phases = append(c.phases, Phase{Name: name, Elapsed: elapsed})
+	}
+}
+
+// TrackCopyGraph wraps the hooks of opts to record the state of each node.
+func (c *Collector) TrackCopyGraph(opts *oras.CopyGraphOptions) {
+	if c == nil {
+		return
+	}
+	postCopy := opts.PostCopy
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.Record(desc, progress.StateTransmitted)
+		if postCopy != nil {
+			return postCopy(ctx, desc)
+		}
+		return nil
+	}
+	onMounted := opts.OnMounted
+	opts.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.Record(desc, progress.StateMounted)
+		if onMounted != nil {
+			return onMounted(ctx, desc)
+		}
+		return nil
+	}
+	onCopySkipped := opts.OnCopySkipped
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.Record(desc, progress.StateExists)
+		if onCopySkipped != nil {
+			return onCopySkipped(ctx, desc)
+		}
+		return nil
+	}
+}
+
+// Summary returns the statistics collected so far.
+func (c *Collector) Summary() Summary {
+	if c == nil {
+		return Summary{}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	summary := Summary{
+		Counts:  make(map[progress.State]int),
+		Elapsed: time.Since(c.start),
+		Phases:  append([]Phase(nil), c.phases...),
+	}
+	for key, state := range c.states {
+		summary.Counts[state]++
+		size := c.sizes[key]
+		switch state {
+		case progress.StateTransmitted:
+			summary.BytesTransferred += size
+		case progress.StateMounted:
+			summary.BytesMounted += size
+		default:
+			summary.BytesSkipped += size
+		}
+	}
+	return summary
+}
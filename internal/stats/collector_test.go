@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/internal/progress"
+)
+
+func newDesc(content string, size int64) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromString(content),
+		Size:      size,
+	}
+}
+
+func TestCollector_TrackCopyGraph(t *testing.T) {
+	c := NewCollector()
+	var postCopyCalled bool
+	opts := oras.CopyGraphOptions{
+		PostCopy: func(context.Context, ocispec.Descriptor) error {
+			postCopyCalled = true
+			return nil
+		},
+	}
+	c.TrackCopyGraph(&opts)
+
+	ctx := context.Background()
+	copied, exists, mounted := newDesc("copied", 10), newDesc("exists", 20), newDesc("mounted", 40)
+	if err := opts.PostCopy(ctx, copied); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.OnCopySkipped(ctx, exists); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.OnMounted(ctx, mounted); err != nil {
+		t.Fatal(err)
+	}
+	// only the first record of a node is kept
+	c.Record(copied, progress.StateSkipped)
+	c.Record(newDesc("restored", 80), progress.StateRestored)
+	if !postCopyCalled {
+		t.Error("original PostCopy is not called")
+	}
+
+	got := c.Summary()
+	if got.BytesTransferred != 10 || got.BytesSkipped != 100 || got.BytesMounted != 40 {
+		t.Errorf("unexpected bytes: transferred %d, skipped %d, mounted %d", got.BytesTransferred, got.BytesSkipped, got.BytesMounted)
+	}
+	wantCounts := map[progress.State]int{
+		progress.StateTransmitted: 1,
+		progress.StateExists:      1,
+		progress.StateMounted:     1,
+		progress.StateRestored:    1,
+	}
+	for state, want := range wantCounts {
+		if got.Counts[state] != want {
+			t.Errorf("Counts[%s] = %d, want %d", state, got.Counts[state], want)
+		}
+	}
+	if len(got.Counts) != len(wantCounts) {
+		t.Errorf("unexpected counts: %v", got.Counts)
+	}
+}
+
+func TestCollector_StartPhase(t *testing.T) {
+	c := NewCollector()
+	for _, name := range []string{"pull", "export", "pull"} {
+		end := c.StartPhase(name)
+		time.Sleep(time.Millisecond)
+		end()
+	}
+	got := c.Summary()
+	if len(got.Phases) != 2 || got.Phases[0].Name != "pull" || got.Phases[1].Name != "export" {
+		t.Fatalf("unexpected phases: %v", got.Phases)
+	}
+	if got.Phases[0].Elapsed < 2*time.Millisecond {
+		t.Errorf("phase time is not accumulated: %v", got.Phases[0].Elapsed)
+	}
+	if got.Elapsed < got.Phases[0].Elapsed+got.Phases[1].Elapsed {
+		t.Errorf("elapsed %v is less than the sum of phases", got.Elapsed)
+	}
+}
+
+func TestCollector_nil(t *testing.T) {
+	var c *Collector
+	opts := oras.CopyGraphOptions{}
+	c.TrackCopyGraph(&opts)
+	if opts.PostCopy != nil {
+		t.Error("nil collector should not wrap hooks")
+	}
+	c.Record(newDesc("foo", 1), progress.StateTransmitted)
+	c.StartPhase("foo")()
+	if got := c.Summary(); got.BytesTransferred != 0 || got.Counts != nil {
+		t.Errorf("nil collector should return an empty summary, got %v", got)
+	}
+}
+
+func TestSummary_Throughput(t *testing.T) {
+	s := Summary{BytesTransferred: 1024, Elapsed: 2 * time.Second}
+	if got := s.Throughput(); got != 512 {
+		t.Errorf("Summary.Throughput() = %v, want 512", got)
+	}
+	if got := (Summary{BytesTransferred: 1024}).Throughput(); got != 0 {
+		t.Errorf("Summary.Throughput() = %v, want 0", got)
+	}
+}
@@ -23,22 +23,42 @@ import (
 
 type contextKey int
 
-// loggerKey is the associated key type for logger entry in context.
-const loggerKey contextKey = iota
+const (
+	// loggerKey is the associated key type for logger entry in context.
+	loggerKey contextKey = iota
+	// spanKey is the associated key type for the active span in context.
+	spanKey
+)
 
-// NewLogger returns a logger.
-func NewLogger(ctx context.Context, debug bool) (context.Context, logrus.FieldLogger) {
-	var logLevel logrus.Level
-	if debug {
-		logLevel = logrus.DebugLevel
-	} else {
-		logLevel = logrus.WarnLevel
-	}
+// Log formats.
+const (
+	// LogFormatText renders logs in human-readable text.
+	LogFormatText = "text"
+	// LogFormatJSON renders logs as one JSON object per line.
+	LogFormatJSON = "json"
+)
 
+// LoggerOptions configures the logger returned by NewLogger.
+type LoggerOptions struct {
+	// Level is the minimum level of logs to be emitted.
+	Level logrus.Level
+	// Format is the log format. Defaults to LogFormatText.
+	Format string
+	// Fields are attached to every log entry.
+	Fields logrus.Fields
+}
+
+// NewLogger returns a logger.
+func NewLogger(ctx context.Context, opts LoggerOptions) (context.Context, logrus.FieldLogger) {
 	logger := logrus.New()
-	logger.SetFormatter(&TextFormatter{})
-	logger.SetLevel(logLevel)
-	entry := logger.WithContext(ctx)
+	switch opts.Format {
+	case LogFormatJSON:
+		logger.SetFormatter(&JSONFormatter{})
+	default:
+		logger.SetFormatter(&TextFormatter{})
+	}
+	logger.SetLevel(opts.Level)
+	entry := logger.WithContext(ctx).WithFields(opts.Fields)
 	return context.WithValue(ctx, loggerKey, entry), entry
 }
 
@@ -50,3 +70,22 @@ func Logger(ctx context.Context) logrus.FieldLogger {
 	}
 	return logger
 }
+
+// LogCommandError logs err with the logger attached to ctx if it emits JSON
+// logs, so that failures can be indexed along with other log entries.
+// Text logs are left untouched since the error is printed to stderr anyway.
+func LogCommandError(ctx context.Context, err error) {
+	if logger := Logger(ctx); isJSONLogger(logger) {
+		logger.WithError(err).Error("command failed")
+	}
+}
+
+// isJSONLogger returns true if logger emits logs with JSONFormatter.
+func isJSONLogger(logger logrus.FieldLogger) bool {
+	entry, ok := logger.(*logrus.Entry)
+	if !ok {
+		return false
+	}
+	_, ok = entry.Logger.Formatter.(*JSONFormatter)
+	return ok
+}
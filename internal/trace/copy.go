@@ -0,0 +1,105 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+)
+
+// TraceCopyGraph wraps the hooks of opts so that each node transfer, mount
+// attempt and skipped node is recorded as a span under the span carried by
+// the copy context. It is a no-op if tracing is disabled.
+func TraceCopyGraph(ctx context.Context, opts *oras.CopyGraphOptions) {
+	if SpanFromContext(ctx) == nil {
+		return
+	}
+	var spans sync.Map // map[digest]*Span
+	start := func(ctx context.Context, name string, desc ocispec.Descriptor) {
+		_, span := StartSpan(ctx, name, descriptorAttributes(desc)...)
+		if old, loaded := spans.Swap(desc.Digest, span); loaded {
+			old.(*Span).End(nil)
+		}
+	}
+	end := func(desc ocispec.Descriptor, attrs ...Attribute) {
+		if v, loaded := spans.LoadAndDelete(desc.Digest); loaded {
+			span := v.(*Span)
+			span.SetAttributes(attrs...)
+			span.End(nil)
+		}
+	}
+
+	if mountFrom := opts.MountFrom; mountFrom != nil {
+		opts.MountFrom = func(ctx context.Context, desc ocispec.Descriptor) ([]string, error) {
+			repos, err := mountFrom(ctx, desc)
+			if err == nil && len(repos) > 0 {
+				start(ctx, "oras.mount", desc)
+			}
+			return repos, err
+		}
+	}
+	preCopy := opts.PreCopy
+	opts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		// PreCopy is called on a failed mount attempt before falling back to
+		// a copy
+		end(desc, Bool("oras.mounted", false))
+		start(ctx, "oras.copy", desc)
+		if preCopy != nil {
+			return preCopy(ctx, desc)
+		}
+		return nil
+	}
+	postCopy := opts.PostCopy
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		end(desc)
+		if postCopy != nil {
+			return postCopy(ctx, desc)
+		}
+		return nil
+	}
+	onMounted := opts.OnMounted
+	opts.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
+		end(desc, Bool("oras.mounted", true))
+		if onMounted != nil {
+			return onMounted(ctx, desc)
+		}
+		return nil
+	}
+	onCopySkipped := opts.OnCopySkipped
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		_, span := StartSpan(ctx, "oras.skip", descriptorAttributes(desc)...)
+		span.End(nil)
+		if onCopySkipped != nil {
+			return onCopySkipped(ctx, desc)
+		}
+		return nil
+	}
+}
+
+func descriptorAttributes(desc ocispec.Descriptor) []Attribute {
+	attrs := []Attribute{
+		String("oci.digest", desc.Digest.String()),
+		String("oci.media_type", desc.MediaType),
+		Int64("oci.size", desc.Size),
+	}
+	if title := desc.Annotations[ocispec.AnnotationTitle]; title != "" {
+		attrs = append(attrs, String("oci.title", title))
+	}
+	return attrs
+}
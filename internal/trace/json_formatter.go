@@ -0,0 +1,72 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reserved keys of a JSON log entry.
+const (
+	// FieldTimestamp is the key of the log timestamp.
+	FieldTimestamp = "timestamp"
+	// FieldLevel is the key of the log level.
+	FieldLevel = "level"
+	// FieldMessage is the key of the log message.
+	FieldMessage = "message"
+	// FieldCommand is the key of the command being executed.
+	FieldCommand = "command"
+	// FieldRegistry is the key of the registry host a request is sent to.
+	FieldRegistry = "registry"
+	// FieldRepository is the key of the repository a request targets.
+	FieldRepository = "repository"
+	// FieldRequestID is the key of the id of a request-response pair.
+	FieldRequestID = "request_id"
+	// FieldError is the key of the error message, same as logrus.ErrorKey.
+	FieldError = "error"
+)
+
+// JSONFormatter formats logs into JSON, one object per line.
+type JSONFormatter struct{}
+
+// Format renders a single log entry.
+func (f *JSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(map[string]any, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		switch v := v.(type) {
+		case error:
+			// errors are not JSON marshalable in general
+			data[k] = v.Error()
+		case fmt.Stringer:
+			data[k] = v.String()
+		default:
+			data[k] = v
+		}
+	}
+	data[FieldTimestamp] = entry.Time.Format(time.RFC3339Nano)
+	data[FieldLevel] = entry.Level.String()
+	data[FieldMessage] = entry.Message
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestJSONFormatter_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   *logrus.Entry
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "debug log entry",
+			entry: &logrus.Entry{
+				Time:    time.Date(2024, time.December, 1, 23, 30, 1, 55, time.UTC),
+				Level:   logrus.DebugLevel,
+				Message: "test debug",
+				Data:    logrus.Fields{},
+			},
+			want: `{"level":"debug","message":"test debug","timestamp":"2024-12-01T23:30:01.000000055Z"}` + "\n",
+		},
+		{
+			name: "error log entry with data fields",
+			entry: &logrus.Entry{
+				Time:    time.Date(2024, time.December, 1, 23, 30, 1, 55, time.UTC),
+				Level:   logrus.ErrorLevel,
+				Message: "request failed",
+				Data: logrus.Fields{
+					FieldCommand:    "oras pull",
+					FieldRegistry:   "localhost:5000",
+					FieldRequestID:  uint64(3),
+					logrus.ErrorKey: errors.New("connection refused"),
+				},
+			},
+			want: `{"command":"oras pull","error":"connection refused","level":"error","message":"request failed","registry":"localhost:5000","request_id":3,"timestamp":"2024-12-01T23:30:01.000000055Z"}` + "\n",
+		},
+		{
+			name: "unsupported data field",
+			entry: &logrus.Entry{
+				Level: logrus.InfoLevel,
+				Data: logrus.Fields{
+					"channel": make(chan int),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &JSONFormatter{}
+			got, err := f.Format(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONFormatter.Format() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("JSONFormatter.Format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
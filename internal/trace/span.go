@@ -0,0 +1,202 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SpanKind describes the relationship between a span and its caller.
+// The values match the OTLP span kind enumeration.
+type SpanKind int
+
+const (
+	// SpanKindInternal indicates an operation internal to ORAS.
+	SpanKindInternal SpanKind = 1
+	// SpanKindClient indicates an outgoing request to a remote service.
+	SpanKindClient SpanKind = 3
+)
+
+// Attribute is a key-value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Exporter sends encoded OTLP-JSON trace payloads to a destination.
+type Exporter interface {
+	Export(ctx context.Context, payload []byte) error
+}
+
+// Tracer records spans of a single trace and exports them on shutdown.
+type Tracer struct {
+	traceID   [16]byte
+	resource  []Attribute
+	exporters []Exporter
+
+	lock  sync.Mutex
+	spans []*Span
+}
+
+// NewTracer returns a tracer exporting spans to the given exporters.
+// resource describes the entity producing the spans, e.g. the service name.
+func NewTracer(resource []Attribute, exporters ...Exporter) *Tracer {
+	t := &Tracer{
+		resource:  resource,
+		exporters: exporters,
+	}
+	_, _ = rand.Read(t.traceID[:])
+	return t
+}
+
+// TraceID returns the hex-encoded trace id.
+func (t *Tracer) TraceID() string {
+	return hex.EncodeToString(t.traceID[:])
+}
+
+// Start starts a root span and returns a context carrying it.
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	s := t.newSpan(nil, name, SpanKindInternal, attrs)
+	return context.WithValue(ctx, spanKey, s), s
+}
+
+// Shutdown ends all unfinished spans and exports the recorded spans.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.lock.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.lock.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+	for _, s := range spans {
+		s.End(nil)
+	}
+	payload, err := encodeOTLP(t, spans)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range t.exporters {
+		if err := e.Export(ctx, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Tracer) newSpan(parent *Span, name string, kind SpanKind, attrs []Attribute) *Span {
+	s := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: attrs,
+	}
+	_, _ = rand.Read(s.spanID[:])
+	if parent != nil {
+		s.parentID = parent.spanID
+		s.hasParent = true
+	}
+	t.lock.Lock()
+	t.spans = append(t.spans, s)
+	t.lock.Unlock()
+	return s
+}
+
+// Span records the timing and outcome of an operation.
+// All methods of a nil *Span are no-ops so that callers need not check
+// whether tracing is enabled.
+type Span struct {
+	tracer    *Tracer
+	name      string
+	kind      SpanKind
+	spanID    [8]byte
+	parentID  [8]byte
+	hasParent bool
+
+	lock       sync.Mutex
+	start      time.Time
+	end        time.Time
+	attributes []Attribute
+	err        error
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.attributes = append(s.attributes, attrs...)
+}
+
+// End ends the span and records err as the span status if not nil.
+// Only the first call takes effect.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.end.IsZero() {
+		return
+	}
+	s.end = time.Now()
+	s.err = err
+}
+
+// StartSpan starts a child span of the span carried by ctx.
+// If ctx carries no span, tracing is disabled and a nil span is returned.
+func StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return startSpan(ctx, name, SpanKindInternal, attrs)
+}
+
+func startSpan(ctx context.Context, name string, kind SpanKind, attrs []Attribute) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	s := parent.tracer.newSpan(parent, name, kind, attrs)
+	return context.WithValue(ctx, spanKey, s), s
+}
+
+// SpanFromContext returns the span carried by ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey).(*Span)
+	return s
+}
@@ -0,0 +1,168 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type mockExporter struct {
+	payloads [][]byte
+}
+
+func (e *mockExporter) Export(_ context.Context, payload []byte) error {
+	e.payloads = append(e.payloads, payload)
+	return nil
+}
+
+func decodeSpans(t *testing.T, payload []byte) []otlpSpan {
+	t.Helper()
+	var traces otlpTraces
+	if err := json.Unmarshal(payload, &traces); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if len(traces.ResourceSpans) != 1 || len(traces.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected payload structure: %s", payload)
+	}
+	return traces.ResourceSpans[0].ScopeSpans[0].Spans
+}
+
+func TestTracer_Shutdown(t *testing.T) {
+	exporter := &mockExporter{}
+	tracer := NewTracer([]Attribute{String("service.name", "oras")}, exporter)
+	ctx, root := tracer.Start(context.Background(), "oras cp")
+	_, child := StartSpan(ctx, "oras.copy", Int64("oci.size", 42))
+	child.End(errors.New("boom"))
+	root.SetAttributes(Bool("done", true))
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Tracer.Shutdown() error = %v", err)
+	}
+	if len(exporter.payloads) != 1 {
+		t.Fatalf("expected 1 payload, got %d", len(exporter.payloads))
+	}
+	spans := decodeSpans(t, exporter.payloads[0])
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	gotRoot, gotChild := spans[0], spans[1]
+	if gotRoot.Name != "oras cp" || gotRoot.ParentSpanID != "" || gotRoot.Status.Code != otlpStatusOK {
+		t.Errorf("unexpected root span: %+v", gotRoot)
+	}
+	if gotRoot.TraceID != tracer.TraceID() || gotChild.TraceID != tracer.TraceID() {
+		t.Errorf("unexpected trace id: root %q, child %q, want %q", gotRoot.TraceID, gotChild.TraceID, tracer.TraceID())
+	}
+	if gotChild.ParentSpanID != gotRoot.SpanID {
+		t.Errorf("child parent = %q, want %q", gotChild.ParentSpanID, gotRoot.SpanID)
+	}
+	if gotChild.Status.Code != otlpStatusError || gotChild.Status.Message != "boom" {
+		t.Errorf("unexpected child status: %+v", gotChild.Status)
+	}
+	if len(gotChild.Attributes) != 1 || gotChild.Attributes[0].Value.IntValue == nil || *gotChild.Attributes[0].Value.IntValue != "42" {
+		t.Errorf("unexpected child attributes: %+v", gotChild.Attributes)
+	}
+
+	// spans are exported only once
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Tracer.Shutdown() error = %v", err)
+	}
+	if len(exporter.payloads) != 1 {
+		t.Errorf("expected no more payloads, got %d", len(exporter.payloads))
+	}
+}
+
+func TestStartSpan_disabled(t *testing.T) {
+	ctx := context.Background()
+	gotCtx, span := StartSpan(ctx, "test")
+	if span != nil {
+		t.Fatalf("StartSpan() span = %v, want nil", span)
+	}
+	if gotCtx != ctx {
+		t.Errorf("StartSpan() should return the original context")
+	}
+	// nil span methods are no-ops
+	span.SetAttributes(String("key", "value"))
+	span.End(nil)
+}
+
+func TestFileExporter_Export(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	exporter := NewFileExporter(path)
+	for _, payload := range []string{"{}", "[]"} {
+		if err := exporter.Export(context.Background(), []byte(payload)); err != nil {
+			t.Fatalf("FileExporter.Export() error = %v", err)
+		}
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{}\n[]\n"; string(got) != want {
+		t.Errorf("file content = %q, want %q", got, want)
+	}
+}
+
+func TestHTTPExporter_Export(t *testing.T) {
+	var gotPath, gotBody, gotType string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	exporter, err := NewHTTPExporter(ts.URL, ts.Client())
+	if err != nil {
+		t.Fatalf("NewHTTPExporter() error = %v", err)
+	}
+	if err := exporter.Export(context.Background(), []byte("{}")); err != nil {
+		t.Fatalf("HTTPExporter.Export() error = %v", err)
+	}
+	if gotPath != "/v1/traces" || gotType != "application/json" || gotBody != "{}" {
+		t.Errorf("unexpected request: path %q, content type %q, body %q", gotPath, gotType, gotBody)
+	}
+
+	exporter, err = NewHTTPExporter(ts.URL+"/custom?fail=1", ts.Client())
+	if err != nil {
+		t.Fatalf("NewHTTPExporter() error = %v", err)
+	}
+	if err := exporter.Export(context.Background(), []byte("{}")); err == nil {
+		t.Error("HTTPExporter.Export() expected error on server failure")
+	}
+	if gotPath != "/custom" {
+		t.Errorf("request path = %q, want %q", gotPath, "/custom")
+	}
+}
+
+func TestNewHTTPExporter_invalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:4318", "ftp://localhost", "http://[::1"} {
+		if _, err := NewHTTPExporter(endpoint, nil); err == nil {
+			t.Errorf("NewHTTPExporter(%q) expected error", endpoint)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// SpanTransport is an http.RoundTripper that records a client span for each
+// request whose context carries a span. Requests without a span in context
+// are passed through untouched.
+type SpanTransport struct {
+	http.RoundTripper
+}
+
+// NewSpanTransport creates and returns a new instance of SpanTransport.
+func NewSpanTransport(base http.RoundTripper) *SpanTransport {
+	return &SpanTransport{
+		RoundTripper: base,
+	}
+}
+
+// RoundTrip records a span covering the request and the consumption of the
+// response body.
+func (t *SpanTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := startSpan(req.Context(), operationName(req), SpanKindClient, []Attribute{
+		String("http.request.method", req.Method),
+		String("server.address", req.URL.Host),
+		String("url.path", req.URL.Path),
+	})
+	if span == nil {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	resp, err := t.RoundTripper.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.End(err)
+		return nil, err
+	}
+	span.SetAttributes(Int64("http.response.status_code", int64(resp.StatusCode)))
+	if resp.StatusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("response status %q", resp.Status)
+	}
+	if resp.Body == nil || resp.Body == http.NoBody || req.Method == http.MethodHead {
+		span.End(err)
+		return resp, nil
+	}
+	resp.Body = &spanBody{
+		ReadCloser: resp.Body,
+		span:       span,
+		err:        err,
+	}
+	return resp, nil
+}
+
+// spanBody ends the span when the response body is closed.
+type spanBody struct {
+	io.ReadCloser
+	span *Span
+	err  error
+	read atomic.Int64
+}
+
+// Read implements io.Reader.
+func (b *spanBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read.Add(int64(n))
+	return n, err
+}
+
+// Close implements io.Closer.
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.span.SetAttributes(Int64("http.response.body.size", b.read.Load()))
+	b.span.End(b.err)
+	return err
+}
+
+// operationName classifies a registry request into a span name.
+func operationName(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case !strings.HasPrefix(path, "/v2/"):
+		// requests outside of the distribution API are sent to the auth
+		// service for fetching tokens
+		return "auth.token"
+	case strings.HasSuffix(path, "/tags/list"):
+		return "registry.tags.list"
+	case path == "/v2/_catalog":
+		return "registry.catalog"
+	case strings.Contains(path, "/referrers/"):
+		return "registry.referrers"
+	case strings.Contains(path, "/blobs/uploads/"):
+		if req.URL.Query().Get("mount") != "" {
+			return "registry.blob.mount"
+		}
+		return "registry.blob.upload"
+	case strings.Contains(path, "/blobs/"):
+		return "registry.blob." + strings.ToLower(req.Method)
+	case strings.Contains(path, "/manifests/"):
+		return "registry.manifest." + strings.ToLower(req.Method)
+	}
+	return "registry." + strings.ToLower(req.Method)
+}
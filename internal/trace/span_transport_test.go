@@ -0,0 +1,121 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_operationName(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{http.MethodGet, "https://auth.example.com/token?scope=x", "auth.token"},
+		{http.MethodGet, "https://example.com/v2/foo/tags/list", "registry.tags.list"},
+		{http.MethodGet, "https://example.com/v2/_catalog", "registry.catalog"},
+		{http.MethodGet, "https://example.com/v2/foo/referrers/sha256:abc", "registry.referrers"},
+		{http.MethodPost, "https://example.com/v2/foo/blobs/uploads/?mount=sha256:abc&from=bar", "registry.blob.mount"},
+		{http.MethodPost, "https://example.com/v2/foo/blobs/uploads/", "registry.blob.upload"},
+		{http.MethodPut, "https://example.com/v2/foo/blobs/uploads/uuid?digest=sha256:abc", "registry.blob.upload"},
+		{http.MethodHead, "https://example.com/v2/foo/blobs/sha256:abc", "registry.blob.head"},
+		{http.MethodGet, "https://example.com/v2/foo/manifests/latest", "registry.manifest.get"},
+		{http.MethodGet, "https://example.com/v2/", "registry.get"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want+" "+tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := &http.Request{Method: tt.method, URL: u}
+			if got := operationName(req); got != tt.want {
+				t.Errorf("operationName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpanTransport_RoundTrip(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/foo/manifests/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer ts.Close()
+	client := &http.Client{Transport: NewSpanTransport(http.DefaultTransport)}
+
+	exporter := &mockExporter{}
+	tracer := NewTracer(nil, exporter)
+	ctx, root := tracer.Start(context.Background(), "root")
+	for _, path := range []string{"/v2/foo/blobs/sha256:abc", "/v2/foo/manifests/missing"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	root.End(nil)
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := decodeSpans(t, exporter.payloads[0])
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	blob, manifest := spans[1], spans[2]
+	if blob.Name != "registry.blob.get" || blob.Kind != SpanKindClient || blob.Status.Code != otlpStatusOK {
+		t.Errorf("unexpected blob span: %+v", blob)
+	}
+	attrs := make(map[string]otlpAnyValue)
+	for _, kv := range blob.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if v := attrs["http.response.body.size"].IntValue; v == nil || *v != "5" {
+		t.Errorf("unexpected body size attribute: %v", v)
+	}
+	if manifest.Name != "registry.manifest.get" || manifest.Status.Code != otlpStatusError {
+		t.Errorf("unexpected manifest span: %+v", manifest)
+	}
+}
+
+func TestSpanTransport_RoundTrip_noSpan(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	client := &http.Client{Transport: NewSpanTransport(http.DefaultTransport)}
+	resp, err := client.Get(ts.URL + "/v2/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if _, ok := resp.Body.(*spanBody); ok {
+		t.Error("response body should not be wrapped without a span in context")
+	}
+}
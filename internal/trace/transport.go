@@ -23,6 +23,8 @@ import (
 	"net/http"
 	"strings"
 	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -58,6 +60,9 @@ func (t *Transport) RoundTrip(req *http.Request) (resp *http.Response, err error
 	id := atomic.AddUint64(&requestCount, 1) - 1
 	ctx := req.Context()
 	e := Logger(ctx)
+	if isJSONLogger(e) {
+		return t.roundTripJSON(req, id, e)
+	}
 
 	// log the request
 	e.Debugf("--> Request #%d\n> Request URL: %q\n> Request method: %q\n> Request headers:\n%s",
@@ -76,6 +81,72 @@ func (t *Transport) RoundTrip(req *http.Request) (resp *http.Response, err error
 	return resp, err
 }
 
+// roundTripJSON calls base roundtrip and logs the request-response pair as
+// structured fields.
+func (t *Transport) roundTripJSON(req *http.Request, id uint64, e logrus.FieldLogger) (*http.Response, error) {
+	e = e.WithFields(logrus.Fields{
+		FieldRequestID: id,
+		FieldRegistry:  req.URL.Host,
+	})
+	if repo := repositoryFromPath(req.URL.Path); repo != "" {
+		e = e.WithField(FieldRepository, repo)
+	}
+	e.WithFields(logrus.Fields{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": scrubHeader(req.Header),
+	}).Debug("request sent")
+
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		e.WithError(err).Error("failed to get response")
+		return resp, err
+	}
+	if resp == nil {
+		e.WithField(FieldError, "no response obtained").Error("failed to get response")
+		return resp, err
+	}
+	fields := logrus.Fields{
+		"status":  resp.StatusCode,
+		"headers": scrubHeader(resp.Header),
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		fields[FieldError] = resp.Status
+	}
+	e.WithFields(fields).Debug("response received")
+	return resp, nil
+}
+
+// repositoryFromPath returns the repository name of a distribution API path,
+// or an empty string if the path does not target a repository.
+func repositoryFromPath(path string) string {
+	path, ok := strings.CutPrefix(path, "/v2/")
+	if !ok {
+		return ""
+	}
+	for _, endpoint := range []string{"/manifests/", "/blobs/", "/tags/", "/referrers/"} {
+		if i := strings.Index(path, endpoint); i > 0 {
+			return path[:i]
+		}
+	}
+	return ""
+}
+
+// scrubHeader returns the provided header keys and values, with auth header
+// scrubbed.
+func scrubHeader(header http.Header) map[string]string {
+	scrubbed := make(map[string]string, len(header))
+	for k, v := range header {
+		for _, h := range toScrub {
+			if strings.EqualFold(k, h) {
+				v = []string{"*****"}
+			}
+		}
+		scrubbed[k] = strings.Join(v, ", ")
+	}
+	return scrubbed
+}
+
 // logHeader prints out the provided header keys and values, with auth header
 // scrubbed.
 func logHeader(header http.Header) string {
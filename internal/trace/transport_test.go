@@ -17,10 +17,16 @@ package trace
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/sirupsen/logrus"
 )
 
 var errMockRead = errors.New("mock read error")
@@ -396,3 +402,78 @@ func Test_containsCredentials(t *testing.T) {
 		})
 	}
 }
+
+func Test_repositoryFromPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"manifest", "/v2/foo/bar/manifests/latest", "foo/bar"},
+		{"blob", "/v2/foo/blobs/sha256:abc", "foo"},
+		{"upload", "/v2/foo/blobs/uploads/uuid", "foo"},
+		{"tags", "/v2/foo/tags/list", "foo"},
+		{"referrers", "/v2/foo/referrers/sha256:abc", "foo"},
+		{"ping", "/v2/", ""},
+		{"catalog", "/v2/_catalog", ""},
+		{"token", "/oauth2/token", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repositoryFromPath(tt.path); got != tt.want {
+				t.Errorf("repositoryFromPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip_json(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	ctx, logger := NewLogger(context.Background(), LoggerOptions{
+		Level:  logrus.DebugLevel,
+		Format: LogFormatJSON,
+		Fields: logrus.Fields{FieldCommand: "oras pull"},
+	})
+	var buf bytes.Buffer
+	logger.(*logrus.Entry).Logger.SetOutput(&buf)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/v2/foo/manifests/latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := NewTransport(http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	var entries []map[string]any
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line is not JSON: %s", line)
+		}
+		if entry[FieldCommand] != "oras pull" || entry[FieldRepository] != "foo" || entry[FieldRegistry] != req.URL.Host {
+			t.Errorf("unexpected log fields: %s", line)
+		}
+		if _, ok := entry[FieldRequestID]; !ok {
+			t.Errorf("missing request id: %s", line)
+		}
+		entries = append(entries, entry)
+	}
+	if strings.Contains(lines[0], "secret") {
+		t.Errorf("credentials are not scrubbed: %s", lines[0])
+	}
+	if entries[1][FieldError] != "404 Not Found" {
+		t.Errorf("unexpected error field: %v", entries[1][FieldError])
+	}
+}
@@ -41,6 +41,16 @@ const (
 	notResponding = -1
 )
 
+// Exit codes of the error classes reported by oras.
+const (
+	ExitCodeUnknown      = 1
+	ExitCodeInvalidInput = 2
+	ExitCodeNotFound     = 3
+	ExitCodeUnauthorized = 4
+	ExitCodeDenied       = 5
+	ExitCodeNetwork      = 6
+)
+
 // ExecOption provides option used to execute a command.
 type ExecOption struct {
 	binary  string
@@ -48,10 +58,11 @@ type ExecOption struct {
 	workDir string
 	timeout time.Duration
 
-	stdin    io.Reader
-	stdout   []match.Matcher
-	stderr   []match.Matcher
-	exitCode int
+	stdin     io.Reader
+	stdout    []match.Matcher
+	stderr    []match.Matcher
+	exitCode  int
+	exactExit bool
 
 	text string
 }
@@ -78,6 +89,13 @@ func (opts *ExecOption) ExpectFailure() *ExecOption {
 	return opts
 }
 
+// ExpectExitCode sets the exact exit code checking for a failed execution.
+func (opts *ExecOption) ExpectExitCode(code int) *ExecOption {
+	opts.exitCode = code
+	opts.exactExit = true
+	return opts
+}
+
 // ExpectBlocking consistently check if the execution is blocked.
 func (opts *ExecOption) ExpectBlocking() *ExecOption {
 	opts.exitCode = notResponding
@@ -204,7 +222,11 @@ func (opts *ExecOption) Exec() *gexec.Session {
 		session.Kill()
 	} else {
 		exitCode := session.Wait(opts.timeout).ExitCode()
-		Expect(opts.exitCode == 0).To(Equal(exitCode == 0))
+		if opts.exactExit {
+			Expect(exitCode).To(Equal(opts.exitCode))
+		} else {
+			Expect(opts.exitCode == 0).To(Equal(exitCode == 0))
+		}
 	}
 
 	// matching result
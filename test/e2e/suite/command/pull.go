@@ -52,6 +52,21 @@ var _ = Describe("ORAS beginners:", func() {
 			MatchDefaultFlagValue("format", "text", "pull")
 		})
 
+		It("should exit with the code of invalid input for an invalid progress type", func() {
+			ORAS("pull", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--progress", "bars").
+				ExpectExitCode(ExitCodeInvalidInput).
+				MatchErrKeyWords("Error:", "invalid progress type").
+				Exec()
+		})
+
+		It("should exit with the code of unknown error if the progress file cannot be created", func() {
+			progressFile := filepath.Join(GinkgoT().TempDir(), "missing", "progress.json")
+			ORAS("pull", RegistryRef(ZOTHost, ImageRepo, foobar.Tag), "--progress", "json", "--progress-file", progressFile).
+				ExpectExitCode(ExitCodeUnknown).
+				MatchErrKeyWords("Error:", "failed to create the progress file").
+				Exec()
+		})
+
 		It("should show deprecation message and print unnamed status output for --verbose", func() {
 			tempDir := PrepareTempFiles()
 			ref := RegistryRef(ZOTHost, ImageRepo, foobar.Tag)
@@ -295,6 +310,27 @@ var _ = Describe("OCI spec 1.1 registry users:", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
+		It("should report unchanged files as skipped in json", func() {
+			tempDir := GinkgoT().TempDir()
+			ref := RegistryRef(ZOTHost, ArtifactRepo, foobar.Tag)
+			ORAS("pull", ref).WithWorkDir(tempDir).Exec()
+			out := ORAS("pull", ref, "--skip-unchanged", "--format", "json").
+				WithWorkDir(tempDir).Exec().Out.Contents()
+			var parsed struct {
+				Files   []struct{} `json:"files"`
+				Skipped []struct {
+					Name string `json:"name"`
+				} `json:"skipped"`
+			}
+			Expect(json.Unmarshal(out, &parsed)).ShouldNot(HaveOccurred())
+			Expect(parsed.Files).Should(BeEmpty())
+			var skipped []string
+			for _, f := range parsed.Skipped {
+				skipped = append(skipped, f.Name)
+			}
+			Expect(skipped).Should(ConsistOf(foobar.ImageLayerNames))
+		})
+
 		It("should show correct reference", func() {
 			tempDir := PrepareTempFiles()
 			ref := RegistryRef(ZOTHost, ArtifactRepo, foobar.Tag)
@@ -17,6 +17,7 @@ package command
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -56,6 +57,26 @@ var _ = Describe("ORAS beginners:", func() {
 				Exec()
 		})
 
+		It("should exit with the code of invalid input when no tag or digest provided", func() {
+			ORAS("resolve", RegistryRef(ZOTHost, ImageRepo, "")).ExpectExitCode(ExitCodeInvalidInput).MatchErrKeyWords("Error:", `no tag or digest specified`).Exec()
+		})
+		It("should exit with the code of not found when provided manifest reference is not found", func() {
+			ORAS("resolve", RegistryRef(ZOTHost, ImageRepo, "i-dont-think-this-tag-exists")).ExpectExitCode(ExitCodeNotFound).MatchErrKeyWords(RegistryErrorPrefix, "not found").Exec()
+		})
+		It("should exit with the code of invalid input when the template file is missing", func() {
+			templateFile := filepath.Join(GinkgoT().TempDir(), "missing.tpl")
+			ORAS("resolve", RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag), "--format", "go-template", "--template-file", templateFile).
+				ExpectExitCode(ExitCodeInvalidInput).
+				MatchErrKeyWords("Error:", "failed to read template file").
+				Exec()
+		})
+		It("should exit with the code of unknown error when the template file is not readable", func() {
+			ORAS("resolve", RegistryRef(ZOTHost, ImageRepo, multi_arch.Tag), "--format", "go-template", "--template-file", GinkgoT().TempDir()).
+				ExpectExitCode(ExitCodeUnknown).
+				MatchErrKeyWords("Error:", "failed to read template file").
+				Exec()
+		})
+
 		It("should fail and show detailed error description if no argument provided", func() {
 			err := ORAS("resolve").ExpectFailure().Exec().Err
 			gomega.Expect(err).Should(gbytes.Say("Error"))